@@ -5,12 +5,20 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/KKogaa/mi-bolsillo-api/config"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers"
 	custommiddleware "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/middleware"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/grok"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/invoice"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/pdf"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/repositories"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/spreadsheet"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/statement"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/telegram"
+	"github.com/KKogaa/mi-bolsillo-api/internal/bootstrap"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
@@ -55,6 +63,42 @@ func connectToDatabase(cfg *config.Config) (*sqlx.DB, error) {
 	return db, nil
 }
 
+// newStatementParsers returns the bank CSV parsers from the formats file plus the OFX parser
+func newStatementParsers(cfg *config.Config) map[string]ports.StatementParser {
+	parsers, err := statement.LoadCSVParsers(cfg.BankStatementFormatsFile)
+	if err != nil {
+		log.Printf("Warning: bank CSV formats not loaded, only OFX imports are available: %v", err)
+		parsers = make(map[string]ports.StatementParser)
+	}
+	parsers["ofx"] = statement.NewOFXParser()
+	return parsers
+}
+
+// newNotifier returns the Telegram notifier, or nil when no bot token is configured
+func newNotifier(cfg *config.Config) ports.Notifier {
+	if cfg.TelegramBotToken == "" {
+		return nil
+	}
+	return telegram.NewTelegramClient(cfg.TelegramBotToken)
+}
+
+// runRecurringBillScheduler posts due recurring bills on startup and then on every tick.
+// Occurrences are claimed in the database, so restarts and overlapping runs never double-post
+func runRecurringBillScheduler(recurringBillService *services.RecurringBillService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := recurringBillService.MaterializeDue(time.Now())
+		if err != nil {
+			log.Printf("Recurring bill scheduler error: %v", err)
+		} else if created > 0 {
+			log.Printf("Recurring bill scheduler created %d bills", created)
+		}
+		<-ticker.C
+	}
+}
+
 func runMigrations(db *sqlx.DB) error {
 	// Create users table
 	_, err := db.Exec(`
@@ -62,6 +106,7 @@ func runMigrations(db *sqlx.DB) error {
 			user_id TEXT PRIMARY KEY,
 			clerk_id TEXT UNIQUE,
 			telegram_id INTEGER UNIQUE,
+			reporting_currency TEXT NOT NULL DEFAULT 'PEN',
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)
@@ -89,12 +134,25 @@ func runMigrations(db *sqlx.DB) error {
 			bill_id TEXT PRIMARY KEY,
 			amount_pen REAL NOT NULL,
 			amount_usd REAL NOT NULL,
+			amount_original REAL NOT NULL DEFAULT 0,
+			reporting_currency TEXT NOT NULL DEFAULT 'PEN',
+			amount_reporting REAL NOT NULL DEFAULT 0,
 			description TEXT,
 			category TEXT,
+			category_id TEXT NOT NULL DEFAULT '',
 			currency TEXT NOT NULL,
 			user_id TEXT NOT NULL,
+			wallet_id TEXT NOT NULL DEFAULT '',
 			source TEXT NOT NULL DEFAULT 'web',
 			date DATETIME NOT NULL,
+			receipt_key TEXT NOT NULL DEFAULT '',
+			supplier_ruc TEXT NOT NULL DEFAULT '',
+			invoice_number TEXT NOT NULL DEFAULT '',
+			subtotal REAL NOT NULL DEFAULT 0,
+			tax_amount REAL NOT NULL DEFAULT 0,
+			tip_amount REAL NOT NULL DEFAULT 0,
+			payment_method_id TEXT NOT NULL DEFAULT '',
+			statement_reference TEXT NOT NULL DEFAULT '',
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)
@@ -109,10 +167,14 @@ func runMigrations(db *sqlx.DB) error {
 			expense_id TEXT PRIMARY KEY,
 			amount_pen REAL NOT NULL,
 			amount_usd REAL NOT NULL,
+			amount_original REAL NOT NULL DEFAULT 0,
+			reporting_currency TEXT NOT NULL DEFAULT 'PEN',
+			amount_reporting REAL NOT NULL DEFAULT 0,
 			exchange_rate REAL NOT NULL,
 			currency TEXT NOT NULL,
 			description TEXT,
 			category TEXT,
+			category_id TEXT NOT NULL DEFAULT '',
 			date TEXT NOT NULL,
 			bill_id TEXT,
 			user_id TEXT NOT NULL,
@@ -126,6 +188,383 @@ func runMigrations(db *sqlx.DB) error {
 		return fmt.Errorf("failed to create expenses table: %w", err)
 	}
 
+	// Create exchange_rates table with one rate per pair per day
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS exchange_rates (
+			date TEXT NOT NULL,
+			base_currency TEXT NOT NULL,
+			quote_currency TEXT NOT NULL,
+			rate REAL NOT NULL,
+			source TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (date, base_currency, quote_currency)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create exchange_rates table: %w", err)
+	}
+
+	// Create budgets table with one monthly limit per category
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS budgets (
+			budget_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			category TEXT NOT NULL,
+			amount_limit REAL NOT NULL,
+			currency TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create budgets table: %w", err)
+	}
+
+	// Create recurring_bills table with the schedule of each recurring bill
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS recurring_bills (
+			recurring_bill_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			description TEXT NOT NULL,
+			category TEXT,
+			amount REAL NOT NULL,
+			currency TEXT NOT NULL,
+			frequency TEXT NOT NULL,
+			interval_count INTEGER NOT NULL DEFAULT 1,
+			day_of_month INTEGER NOT NULL DEFAULT 0,
+			day_of_week INTEGER NOT NULL DEFAULT 0,
+			month_of_year INTEGER NOT NULL DEFAULT 0,
+			start_date TEXT NOT NULL,
+			end_date TEXT,
+			next_occurrence TEXT NOT NULL,
+			active BOOLEAN NOT NULL DEFAULT 1,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring_bills table: %w", err)
+	}
+
+	// Create recurring_bill_occurrences table, the primary key makes posting idempotent per occurrence date
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS recurring_bill_occurrences (
+			recurring_bill_id TEXT NOT NULL,
+			occurrence_date TEXT NOT NULL,
+			bill_id TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (recurring_bill_id, occurrence_date)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring_bill_occurrences table: %w", err)
+	}
+
+	// Create categories table, each user's categories with one level of subcategories
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS categories (
+			category_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			parent_id TEXT NOT NULL DEFAULT '',
+			name TEXT NOT NULL,
+			icon TEXT NOT NULL DEFAULT '',
+			color TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create categories table: %w", err)
+	}
+
+	// Category names are unique per user regardless of case
+	_, err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_user_name ON categories (user_id, LOWER(name))`)
+	if err != nil {
+		return fmt.Errorf("failed to create categories index: %w", err)
+	}
+
+	// Create category_rules table, the user's auto-categorization rules
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS category_rules (
+			rule_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			field TEXT NOT NULL,
+			operator TEXT NOT NULL,
+			pattern TEXT NOT NULL,
+			category_id TEXT NOT NULL,
+			priority INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create category_rules table: %w", err)
+	}
+
+	// Create merchants and merchant_aliases tables, the stores the user buys from and the names they go by
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS merchants (
+			merchant_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			merchant_key TEXT NOT NULL,
+			category_id TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create merchants table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS merchant_aliases (
+			user_id TEXT NOT NULL,
+			alias TEXT NOT NULL,
+			merchant_id TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (user_id, alias)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create merchant_aliases table: %w", err)
+	}
+
+	// Create wallets, wallet_members and wallet_invites tables, the shared household ledgers
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS wallets (
+			wallet_id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			owner_id TEXT NOT NULL,
+			telegram_chat_id INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create wallets table: %w", err)
+	}
+
+	// A Telegram group posts into a single wallet
+	_, err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_telegram_chat ON wallets (telegram_chat_id) WHERE telegram_chat_id <> 0`)
+	if err != nil {
+		return fmt.Errorf("failed to create wallets telegram chat index: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS wallet_members (
+			wallet_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			role TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (wallet_id, user_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet_members table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS wallet_invites (
+			code TEXT PRIMARY KEY,
+			wallet_id TEXT NOT NULL,
+			role TEXT NOT NULL,
+			created_by TEXT NOT NULL,
+			expires_at DATETIME NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet_invites table: %w", err)
+	}
+
+	// Create incomes table, the money the user receives
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS incomes (
+			income_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			type TEXT NOT NULL DEFAULT 'other',
+			description TEXT NOT NULL DEFAULT '',
+			amount_pen REAL NOT NULL,
+			amount_usd REAL NOT NULL,
+			amount_original REAL NOT NULL,
+			reporting_currency TEXT NOT NULL DEFAULT 'PEN',
+			amount_reporting REAL NOT NULL,
+			exchange_rate REAL NOT NULL,
+			currency TEXT NOT NULL,
+			source TEXT NOT NULL DEFAULT 'web',
+			date DATETIME NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create incomes table: %w", err)
+	}
+
+	// Create payment_methods table, the cards, wallets and cash the user pays bills with
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS payment_methods (
+			payment_method_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			type TEXT NOT NULL,
+			currency TEXT NOT NULL DEFAULT 'PEN',
+			last_four TEXT NOT NULL DEFAULT '',
+			credit_limit REAL NOT NULL DEFAULT 0,
+			statement_closing_day INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create payment_methods table: %w", err)
+	}
+
+	// Create goals and goal_contributions tables, what the user is saving up for and the money put towards it
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS goals (
+			goal_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			target_amount REAL NOT NULL,
+			currency TEXT NOT NULL DEFAULT 'PEN',
+			deadline DATETIME NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create goals table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS goal_contributions (
+			contribution_id TEXT PRIMARY KEY,
+			goal_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			amount REAL NOT NULL,
+			note TEXT NOT NULL DEFAULT '',
+			date DATETIME NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (goal_id) REFERENCES goals(goal_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create goal_contributions table: %w", err)
+	}
+
+	// Create bill_splits, bill_split_shares and bill_split_items tables, who paid a shared bill and who owes what of it
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS bill_splits (
+			bill_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			wallet_id TEXT NOT NULL DEFAULT '',
+			paid_by TEXT NOT NULL,
+			amount REAL NOT NULL,
+			currency TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create bill_splits table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS bill_split_shares (
+			bill_id TEXT NOT NULL,
+			participant TEXT NOT NULL,
+			amount REAL NOT NULL,
+			percentage REAL NOT NULL DEFAULT 0,
+			PRIMARY KEY (bill_id, participant)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create bill_split_shares table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS bill_split_items (
+			bill_id TEXT NOT NULL,
+			expense_id TEXT NOT NULL,
+			participant TEXT NOT NULL,
+			PRIMARY KEY (bill_id, expense_id, participant)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create bill_split_items table: %w", err)
+	}
+
+	// Create settlements table, payments between participants that pay back what they owe
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS settlements (
+			settlement_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			wallet_id TEXT NOT NULL DEFAULT '',
+			from_participant TEXT NOT NULL,
+			to_participant TEXT NOT NULL,
+			amount REAL NOT NULL,
+			currency TEXT NOT NULL,
+			note TEXT NOT NULL DEFAULT '',
+			date DATETIME NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create settlements table: %w", err)
+	}
+
+	// Create bill_drafts table, parsed receipts awaiting the user's review
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS bill_drafts (
+			draft_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			wallet_id TEXT NOT NULL DEFAULT '',
+			source TEXT NOT NULL DEFAULT 'web',
+			description TEXT NOT NULL DEFAULT '',
+			currency TEXT NOT NULL DEFAULT '',
+			date TEXT NOT NULL DEFAULT '',
+			total_amount REAL NOT NULL DEFAULT 0,
+			items TEXT NOT NULL DEFAULT '[]',
+			warnings TEXT NOT NULL DEFAULT '[]',
+			receipt_key TEXT NOT NULL DEFAULT '',
+			supplier_ruc TEXT NOT NULL DEFAULT '',
+			invoice_number TEXT NOT NULL DEFAULT '',
+			subtotal REAL NOT NULL DEFAULT 0,
+			tax_amount REAL NOT NULL DEFAULT 0,
+			tip_amount REAL NOT NULL DEFAULT 0,
+			payment_method_id TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create bill_drafts table: %w", err)
+	}
+
+	// Sessions used to be kept per chat, they only live for a few minutes so the old table is dropped
+	if _, err := db.Exec(`SELECT telegram_id FROM bot_sessions LIMIT 1`); err != nil {
+		_, _ = db.Exec(`DROP TABLE IF EXISTS bot_sessions`)
+	}
+
+	// Create bot_sessions table, the Telegram bot's pending conversation with each user of a chat
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS bot_sessions (
+			chat_id INTEGER NOT NULL,
+			telegram_id INTEGER NOT NULL,
+			state TEXT NOT NULL,
+			intent TEXT NOT NULL DEFAULT '{}',
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			expires_at DATETIME NOT NULL,
+			PRIMARY KEY (chat_id, telegram_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create bot_sessions table: %w", err)
+	}
+
 	// Add source column to existing bills table if it doesn't exist
 	// SQLite doesn't have a simple way to check if column exists, so we try to add it
 	// and ignore errors if it already exists
@@ -134,6 +573,59 @@ func runMigrations(db *sqlx.DB) error {
 	// Add source column to existing expenses table if it doesn't exist
 	_, _ = db.Exec(`ALTER TABLE expenses ADD COLUMN source TEXT NOT NULL DEFAULT 'web'`)
 
+	// Add receipt photo key to existing bills table if it doesn't exist
+	_, _ = db.Exec(`ALTER TABLE bills ADD COLUMN receipt_key TEXT NOT NULL DEFAULT ''`)
+
+	// Add electronic invoice identifiers to existing bills and drafts if they don't exist
+	for _, table := range []string{"bills", "bill_drafts"} {
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN supplier_ruc TEXT NOT NULL DEFAULT ''`, table))
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN invoice_number TEXT NOT NULL DEFAULT ''`, table))
+	}
+
+	// Add tax breakdown columns to existing bills and drafts if they don't exist
+	for _, table := range []string{"bills", "bill_drafts"} {
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN subtotal REAL NOT NULL DEFAULT 0`, table))
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN tax_amount REAL NOT NULL DEFAULT 0`, table))
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN tip_amount REAL NOT NULL DEFAULT 0`, table))
+	}
+
+	// Link existing bills and expenses to categories, rows are linked when their user's categories are seeded
+	for _, table := range []string{"bills", "expenses"} {
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN category_id TEXT NOT NULL DEFAULT ''`, table))
+	}
+
+	// Add shared wallet to existing bills and drafts if it doesn't exist, empty for personal bills
+	for _, table := range []string{"bills", "bill_drafts"} {
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN wallet_id TEXT NOT NULL DEFAULT ''`, table))
+	}
+
+	// Add the payment method to existing bills and drafts if it doesn't exist, empty when unknown
+	for _, table := range []string{"bills", "bill_drafts"} {
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN payment_method_id TEXT NOT NULL DEFAULT ''`, table))
+	}
+
+	// Add the bank statement reference to existing bills if it doesn't exist, used to skip re-imported movements
+	_, _ = db.Exec(`ALTER TABLE bills ADD COLUMN statement_reference TEXT NOT NULL DEFAULT ''`)
+
+	// Add multi-currency columns to existing tables if they don't exist
+	_, _ = db.Exec(`ALTER TABLE users ADD COLUMN reporting_currency TEXT NOT NULL DEFAULT 'PEN'`)
+	for _, table := range []string{"bills", "expenses"} {
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN amount_original REAL NOT NULL DEFAULT 0`, table))
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN reporting_currency TEXT NOT NULL DEFAULT 'PEN'`, table))
+		_, _ = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN amount_reporting REAL NOT NULL DEFAULT 0`, table))
+
+		// Backfill rows created before multi-currency support, which were either PEN or USD
+		_, err = db.Exec(fmt.Sprintf(`
+			UPDATE %s
+			SET amount_original = CASE WHEN currency = 'USD' THEN amount_usd ELSE amount_pen END,
+				amount_reporting = amount_pen
+			WHERE amount_original = 0 AND amount_pen <> 0
+		`, table))
+		if err != nil {
+			return fmt.Errorf("failed to backfill %s original amounts: %w", table, err)
+		}
+	}
+
 	log.Println("Successfully ran database migrations")
 	return nil
 }
@@ -231,20 +723,64 @@ func main() {
 	expenseRepo := repositories.NewExpenseRepository(db)
 	userRepo := repositories.NewUserRepository(db)
 	otpRepo := repositories.NewOTPRepository(db)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db)
+	budgetRepo := repositories.NewBudgetRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	categoryRuleRepo := repositories.NewCategoryRuleRepository(db)
+	merchantRepo := repositories.NewMerchantRepository(db)
+	walletRepo := repositories.NewWalletRepository(db)
+	splitRepo := repositories.NewSplitRepository(db)
+	incomeRepo := repositories.NewIncomeRepository(db)
+	goalRepo := repositories.NewGoalRepository(db)
+	paymentMethodRepo := repositories.NewPaymentMethodRepository(db)
+	recurringBillRepo := repositories.NewRecurringBillRepository(db)
+	billDraftRepo := repositories.NewBillDraftRepository(db)
+	unitOfWork := repositories.NewUnitOfWork(db)
 
 	// Initialize services
-	billWithExpensesService := services.NewBillWithExpensesService(billRepo, expenseRepo)
-	accountLinkService := services.NewAccountLinkService(userRepo, otpRepo, billRepo, expenseRepo, cfg.OTPExpirationMinutes)
-	statisticsService := services.NewStatisticsService(billRepo)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepo, bootstrap.NewExchangeRateFetcher(cfg))
+	budgetService := services.NewBudgetService(budgetRepo, expenseRepo, categoryRepo, userRepo, exchangeRateService, newNotifier(cfg))
+	categoryService := services.NewCategoryService(categoryRepo, budgetRepo, unitOfWork)
+	merchantService := services.NewMerchantService(merchantRepo, unitOfWork)
+	walletService := services.NewWalletService(walletRepo, unitOfWork, cfg.WalletInviteExpirationHours)
+	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepo, billRepo, exchangeRateService, unitOfWork)
+	billWithExpensesService := services.NewBillWithExpensesService(billRepo, expenseRepo, userRepo, exchangeRateService, budgetService, categoryService, categoryRuleRepo, merchantService, walletService, paymentMethodService, unitOfWork, bootstrap.NewBlobStore(cfg))
+	billDraftService := services.NewBillDraftService(billDraftRepo, billWithExpensesService)
+	splitService := services.NewSplitService(splitRepo, expenseRepo, billWithExpensesService, walletService, unitOfWork)
+	accountLinkService := services.NewAccountLinkService(userRepo, otpRepo, unitOfWork, cfg.OTPExpirationMinutes)
+	categoryRuleService := services.NewCategoryRuleService(categoryRuleRepo, billRepo, expenseRepo, categoryService, unitOfWork)
+	incomeService := services.NewIncomeService(incomeRepo, userRepo, exchangeRateService)
+	statisticsService := services.NewStatisticsService(billRepo, incomeRepo)
+	goalService := services.NewGoalService(goalRepo, statisticsService, exchangeRateService, unitOfWork)
+	taxReportService := services.NewTaxReportService(billRepo, expenseRepo)
+	userPreferencesService := services.NewUserPreferencesService(userRepo, billRepo, expenseRepo, incomeRepo, exchangeRateService)
+	recurringBillService := services.NewRecurringBillService(recurringBillRepo, billWithExpensesService)
+	exportService := services.NewExportService(billRepo, expenseRepo, walletService, spreadsheet.NewCSVFormat(), spreadsheet.NewXLSXFormat())
+	importService := services.NewImportService(billRepo, billWithExpensesService, newStatementParsers(cfg))
 
 	// Initialize Grok client
 	grokClient := grok.NewGrokClient(cfg.GrokAPIKey)
+	receiptParsingService := services.NewReceiptParsingService(bootstrap.NewReceiptParser(cfg, grokClient), invoice.NewUBLParser(), pdf.NewReader(), categoryService)
 
 	// Initialize handlers
 	billWithExpensesHandler := handlers.NewBillWithExpensesHandler(billWithExpensesService, accountLinkService)
-	billUploadHandler := handlers.NewBillUploadHandler(grokClient, billWithExpensesService, accountLinkService)
+	billUploadHandler := handlers.NewBillUploadHandler(receiptParsingService, billWithExpensesService, billDraftService, accountLinkService)
+	billDraftHandler := handlers.NewBillDraftHandler(billDraftService, accountLinkService)
 	authHandler := handlers.NewAuthHandler(accountLinkService)
 	statisticsHandler := handlers.NewStatisticsHandler(statisticsService, accountLinkService)
+	reportHandler := handlers.NewReportHandler(taxReportService, accountLinkService)
+	userHandler := handlers.NewUserHandler(userPreferencesService, accountLinkService)
+	budgetHandler := handlers.NewBudgetHandler(budgetService, accountLinkService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService, accountLinkService)
+	categoryRuleHandler := handlers.NewCategoryRuleHandler(categoryRuleService, accountLinkService)
+	walletHandler := handlers.NewWalletHandler(walletService, accountLinkService, cfg.TelegramBotUsername)
+	splitHandler := handlers.NewSplitHandler(splitService, accountLinkService)
+	incomeHandler := handlers.NewIncomeHandler(incomeService, accountLinkService)
+	paymentMethodHandler := handlers.NewPaymentMethodHandler(paymentMethodService, accountLinkService)
+	goalHandler := handlers.NewGoalHandler(goalService, accountLinkService)
+	recurringBillHandler := handlers.NewRecurringBillHandler(recurringBillService, accountLinkService)
+	exportHandler := handlers.NewExportHandler(exportService, accountLinkService)
+	importHandler := handlers.NewImportHandler(importService, accountLinkService)
 
 	e := echo.New()
 	e.Use(middleware.Logger())
@@ -253,6 +789,7 @@ func main() {
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
 		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		ExposeHeaders:    []string{"X-Next-Cursor"},
 		AllowCredentials: false,
 		MaxAge:           86400,
 	}))
@@ -267,12 +804,84 @@ func main() {
 	// Register routes
 	api.POST("/bills", billWithExpensesHandler.CreateBillWithExpenses)
 	api.POST("/bills/upload", billUploadHandler.UploadBillPhoto)
+	api.GET("/bills/drafts/:id", billDraftHandler.GetBillDraft)
+	api.PUT("/bills/drafts/:id", billDraftHandler.UpdateBillDraft)
+	api.POST("/bills/drafts/:id/confirm", billDraftHandler.ConfirmBillDraft)
+	api.POST("/bills/drafts/:id/discard", billDraftHandler.DiscardBillDraft)
 	api.GET("/bills", billWithExpensesHandler.ListBills)
 	api.GET("/bills/:id", billWithExpensesHandler.GetBillByID)
+	api.GET("/bills/:id/receipt", billWithExpensesHandler.GetBillReceipt)
+	api.PUT("/bills/:id", billWithExpensesHandler.UpdateBill)
 	api.DELETE("/bills/:id", billWithExpensesHandler.DeleteBillByID)
+	api.POST("/bills/:id/expenses", billWithExpensesHandler.AddExpense)
+	api.PATCH("/bills/:id/expenses/:expenseId", billWithExpensesHandler.UpdateExpense)
+	api.DELETE("/bills/:id/expenses/:expenseId", billWithExpensesHandler.DeleteExpense)
+	api.GET("/bills/:id/split", splitHandler.GetBillSplit)
+	api.PUT("/bills/:id/split", splitHandler.SplitBill)
+	api.DELETE("/bills/:id/split", splitHandler.DeleteBillSplit)
+	api.GET("/balances", splitHandler.GetBalances)
+	api.GET("/settlements", splitHandler.ListSettlements)
+	api.POST("/settlements", splitHandler.CreateSettlement)
+	api.DELETE("/settlements/:id", splitHandler.DeleteSettlement)
 	api.POST("/auth/verify-otp", authHandler.VerifyOTP)
 	api.GET("/auth/link-status", authHandler.GetLinkStatus)
+	api.POST("/incomes", incomeHandler.CreateIncome)
+	api.GET("/incomes", incomeHandler.ListIncomes)
+	api.GET("/incomes/:id", incomeHandler.GetIncomeByID)
+	api.PUT("/incomes/:id", incomeHandler.UpdateIncome)
+	api.DELETE("/incomes/:id", incomeHandler.DeleteIncome)
+	api.POST("/payment-methods", paymentMethodHandler.CreatePaymentMethod)
+	api.GET("/payment-methods", paymentMethodHandler.ListPaymentMethods)
+	api.GET("/payment-methods/:id", paymentMethodHandler.GetPaymentMethodByID)
+	api.PUT("/payment-methods/:id", paymentMethodHandler.UpdatePaymentMethod)
+	api.DELETE("/payment-methods/:id", paymentMethodHandler.DeletePaymentMethod)
+	api.GET("/payment-methods/:id/statements", paymentMethodHandler.GetPaymentMethodStatements)
+	api.POST("/goals", goalHandler.CreateGoal)
+	api.GET("/goals", goalHandler.ListGoals)
+	api.GET("/goals/:id", goalHandler.GetGoalByID)
+	api.PUT("/goals/:id", goalHandler.UpdateGoal)
+	api.DELETE("/goals/:id", goalHandler.DeleteGoal)
+	api.GET("/goals/:id/contributions", goalHandler.ListContributions)
+	api.POST("/goals/:id/contributions", goalHandler.AddContribution)
+	api.DELETE("/goals/:id/contributions/:contributionId", goalHandler.DeleteContribution)
 	api.GET("/statistics/dashboard", statisticsHandler.GetDashboardStatistics)
+	api.GET("/statistics/payment-methods", paymentMethodHandler.GetPaymentMethodStatistics)
+	api.GET("/reports/deductible", reportHandler.GetDeductibleReport)
+	api.GET("/users/me/preferences", userHandler.GetPreferences)
+	api.PUT("/users/me/preferences", userHandler.UpdatePreferences)
+	api.POST("/budgets", budgetHandler.CreateBudget)
+	api.GET("/budgets", budgetHandler.ListBudgets)
+	api.GET("/budgets/:id", budgetHandler.GetBudgetByID)
+	api.PUT("/budgets/:id", budgetHandler.UpdateBudget)
+	api.DELETE("/budgets/:id", budgetHandler.DeleteBudget)
+	api.GET("/categories", categoryHandler.ListCategories)
+	api.POST("/categories", categoryHandler.CreateCategory)
+	api.PUT("/categories/:id", categoryHandler.UpdateCategory)
+	api.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+	api.GET("/rules", categoryRuleHandler.ListRules)
+	api.POST("/rules", categoryRuleHandler.CreateRule)
+	api.POST("/rules/apply", categoryRuleHandler.ApplyRules)
+	api.PUT("/rules/:id", categoryRuleHandler.UpdateRule)
+	api.DELETE("/rules/:id", categoryRuleHandler.DeleteRule)
+	api.GET("/wallets", walletHandler.ListWallets)
+	api.POST("/wallets", walletHandler.CreateWallet)
+	api.POST("/wallets/join/:code", walletHandler.JoinWallet)
+	api.GET("/wallets/:id", walletHandler.GetWallet)
+	api.PUT("/wallets/:id", walletHandler.UpdateWallet)
+	api.DELETE("/wallets/:id", walletHandler.DeleteWallet)
+	api.POST("/wallets/:id/invites", walletHandler.CreateInvite)
+	api.PUT("/wallets/:id/members/:userId", walletHandler.UpdateMember)
+	api.DELETE("/wallets/:id/members/:userId", walletHandler.RemoveMember)
+	api.POST("/recurring-bills", recurringBillHandler.CreateRecurringBill)
+	api.GET("/recurring-bills", recurringBillHandler.ListRecurringBills)
+	api.GET("/recurring-bills/:id", recurringBillHandler.GetRecurringBillByID)
+	api.PUT("/recurring-bills/:id", recurringBillHandler.UpdateRecurringBill)
+	api.DELETE("/recurring-bills/:id", recurringBillHandler.DeleteRecurringBill)
+	api.GET("/exports/bills", exportHandler.ExportBills)
+	api.POST("/imports", importHandler.ImportStatement)
+
+	// Post due recurring bills in the background
+	go runRecurringBillScheduler(recurringBillService, time.Duration(cfg.RecurringBillsIntervalMinutes)*time.Minute)
 
 	// Use PORT from config (Render will set this automatically)
 	port := cfg.Port
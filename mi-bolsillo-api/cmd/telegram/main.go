@@ -8,7 +8,13 @@ import (
 	"github.com/KKogaa/mi-bolsillo-api/config"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/telegram"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/grok"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/invoice"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/pdf"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/repositories"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/spreadsheet"
+	telegramclient "github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/telegram"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/whisper"
+	"github.com/KKogaa/mi-bolsillo-api/internal/bootstrap"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
@@ -51,20 +57,57 @@ func main() {
 	expenseRepo := repositories.NewExpenseRepository(db)
 	userRepo := repositories.NewUserRepository(db)
 	otpRepo := repositories.NewOTPRepository(db)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db)
+	budgetRepo := repositories.NewBudgetRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	categoryRuleRepo := repositories.NewCategoryRuleRepository(db)
+	merchantRepo := repositories.NewMerchantRepository(db)
+	walletRepo := repositories.NewWalletRepository(db)
+	splitRepo := repositories.NewSplitRepository(db)
+	incomeRepo := repositories.NewIncomeRepository(db)
+	goalRepo := repositories.NewGoalRepository(db)
+	paymentMethodRepo := repositories.NewPaymentMethodRepository(db)
+	billDraftRepo := repositories.NewBillDraftRepository(db)
+	botSessionRepo := repositories.NewBotSessionRepository(db)
+	unitOfWork := repositories.NewUnitOfWork(db)
 
 	// Initialize services
-	billWithExpensesService := services.NewBillWithExpensesService(billRepo, expenseRepo)
-	accountLinkService := services.NewAccountLinkService(userRepo, otpRepo, billRepo, expenseRepo, cfg.OTPExpirationMinutes)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepo, bootstrap.NewExchangeRateFetcher(cfg))
+	budgetService := services.NewBudgetService(budgetRepo, expenseRepo, categoryRepo, userRepo, exchangeRateService, telegramclient.NewTelegramClient(cfg.TelegramBotToken))
+	categoryService := services.NewCategoryService(categoryRepo, budgetRepo, unitOfWork)
+	merchantService := services.NewMerchantService(merchantRepo, unitOfWork)
+	walletService := services.NewWalletService(walletRepo, unitOfWork, cfg.WalletInviteExpirationHours)
+	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepo, billRepo, exchangeRateService, unitOfWork)
+	billWithExpensesService := services.NewBillWithExpensesService(billRepo, expenseRepo, userRepo, exchangeRateService, budgetService, categoryService, categoryRuleRepo, merchantService, walletService, paymentMethodService, unitOfWork, bootstrap.NewBlobStore(cfg))
+	billDraftService := services.NewBillDraftService(billDraftRepo, billWithExpensesService)
+	splitService := services.NewSplitService(splitRepo, expenseRepo, billWithExpensesService, walletService, unitOfWork)
+	incomeService := services.NewIncomeService(incomeRepo, userRepo, exchangeRateService)
+	statisticsService := services.NewStatisticsService(billRepo, incomeRepo)
+	goalService := services.NewGoalService(goalRepo, statisticsService, exchangeRateService, unitOfWork)
+	botSessionService := services.NewBotSessionService(botSessionRepo, cfg.BotSessionTTLMinutes)
+	accountLinkService := services.NewAccountLinkService(userRepo, otpRepo, unitOfWork, cfg.OTPExpirationMinutes)
+	exportService := services.NewExportService(billRepo, expenseRepo, walletService, spreadsheet.NewCSVFormat(), spreadsheet.NewXLSXFormat())
 
 	// Initialize Grok client (implements IntentDetector interface)
 	grokClient := grok.NewGrokClient(cfg.GrokAPIKey)
+	receiptParsingService := services.NewReceiptParsingService(bootstrap.NewReceiptParser(cfg, grokClient), invoice.NewUBLParser(), pdf.NewReader(), categoryService)
 
 	// Create bot handler
 	botHandler := telegram.NewBotHandler(
 		grokClient, // GrokClient implements ports.IntentDetector
 		billWithExpensesService,
 		accountLinkService,
-		grokClient,
+		exportService,
+		billDraftService,
+		botSessionService,
+		receiptParsingService,
+		categoryService,
+		merchantService,
+		walletService,
+		splitService,
+		incomeService,
+		goalService,
+		whisper.NewWhisperClient(cfg.SpeechToTextBaseURL, cfg.SpeechToTextAPIKey, cfg.SpeechToTextModel, cfg.SpeechToTextLanguage),
 		messages,
 	)
 
@@ -82,8 +125,25 @@ func main() {
 	// Register handlers
 	bot.Handle("/start", botHandler.HandleStart)
 	bot.Handle("/link", botHandler.HandleLink)
+	bot.Handle("/export", botHandler.HandleExport)
+	bot.Handle("/ultimas", botHandler.HandleRecentBills)
+	bot.Handle("/deudas", botHandler.HandleDebts)
+	bot.Handle("/metas", botHandler.HandleGoals)
 	bot.Handle(tele.OnText, botHandler.HandleText)
 	bot.Handle(tele.OnPhoto, botHandler.HandlePhoto)
+	bot.Handle(tele.OnDocument, botHandler.HandleDocument)
+	bot.Handle(tele.OnVoice, botHandler.HandleVoice)
+	bot.Handle(&telegram.BtnSaveDraft, botHandler.HandleSaveDraft)
+	bot.Handle(&telegram.BtnEditDraft, botHandler.HandleEditDraft)
+	bot.Handle(&telegram.BtnDiscardDraft, botHandler.HandleDiscardDraft)
+	bot.Handle(&telegram.BtnBillItems, botHandler.HandleBillItems)
+	bot.Handle(&telegram.BtnBillCategory, botHandler.HandleBillCategory)
+	bot.Handle(&telegram.BtnBillSetCategory, botHandler.HandleBillSetCategory)
+	bot.Handle(&telegram.BtnBillDate, botHandler.HandleBillDate)
+	bot.Handle(&telegram.BtnBillSetDate, botHandler.HandleBillSetDate)
+	bot.Handle(&telegram.BtnBillDelete, botHandler.HandleBillDelete)
+	bot.Handle(&telegram.BtnBillConfirmDelete, botHandler.HandleBillConfirmDelete)
+	bot.Handle(&telegram.BtnBillBack, botHandler.HandleBillBack)
 
 	log.Println("Telegram bot started successfully using long polling")
 	bot.Start()
@@ -3,20 +3,54 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DatabaseUrl           string
-	DatabaseToken         string
-	Port                  string
-	EmailProviderUrl      string
-	EmailProviderToken    string
-	ClerkJWKSUrl          string
-	GrokAPIKey            string
-	TelegramBotToken      string
-	OTPExpirationMinutes  int
+	DatabaseUrl          string
+	DatabaseToken        string
+	Port                 string
+	EmailProviderUrl     string
+	EmailProviderToken   string
+	ClerkJWKSUrl         string
+	GrokAPIKey           string
+	TelegramBotToken     string
+	OTPExpirationMinutes int
+	ExchangeRateSource   string
+	ExchangeRateAPIUrl   string
+	ExchangeRateFile     string
+	// RecurringBillsIntervalMinutes is how often due recurring bills are posted
+	RecurringBillsIntervalMinutes int
+	// BotSessionTTLMinutes is how long the bot waits for the answer to a follow-up question
+	BotSessionTTLMinutes int
+	// ReceiptParser selects the LLM used to read receipt photos: grok or openai
+	ReceiptParser        string
+	ReceiptParserBaseURL string
+	ReceiptParserAPIKey  string
+	ReceiptParserModel   string
+	// SpeechToText settings point at an OpenAI-compatible transcriptions API, e.g. a local
+	// faster-whisper server, used to transcribe Telegram voice notes
+	SpeechToTextBaseURL  string
+	SpeechToTextAPIKey   string
+	SpeechToTextModel    string
+	SpeechToTextLanguage string
+	// BlobStore selects where receipt photos are kept: local or s3
+	BlobStore     string
+	BlobStorePath string
+	// S3 settings also work with S3-compatible services such as MinIO
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// BankStatementFormatsFile holds the CSV column mappings of each bank's statement export
+	BankStatementFormatsFile string
+	// TelegramBotUsername builds the t.me links of wallet invites, e.g. MiBolsilloBot
+	TelegramBotUsername string
+	// WalletInviteExpirationHours is how long a shared wallet invite can be used
+	WalletInviteExpirationHours int
 }
 
 func LoadConfig() *Config {
@@ -29,15 +63,124 @@ func LoadConfig() *Config {
 		}
 	}
 
+	walletInviteExpiration := 72 // Default 3 days
+	if envVal := os.Getenv("WALLET_INVITE_EXPIRATION_HOURS"); envVal != "" {
+		if val, err := strconv.Atoi(envVal); err == nil && val > 0 {
+			walletInviteExpiration = val
+		}
+	}
+
+	exchangeRateSource := os.Getenv("EXCHANGE_RATE_SOURCE")
+	if exchangeRateSource == "" {
+		exchangeRateSource = "http"
+	}
+
+	exchangeRateAPIUrl := os.Getenv("EXCHANGE_RATE_API_URL")
+	if exchangeRateAPIUrl == "" {
+		exchangeRateAPIUrl = "https://cdn.jsdelivr.net/npm/@fawazahmed0/currency-api@{date}/v1/currencies/{base}.json"
+	}
+
+	exchangeRateFile := os.Getenv("EXCHANGE_RATE_FILE")
+	if exchangeRateFile == "" {
+		exchangeRateFile = "config/exchange_rates.json"
+	}
+
+	recurringBillsInterval := 60 // Default every hour
+	if envVal := os.Getenv("RECURRING_BILLS_INTERVAL_MINUTES"); envVal != "" {
+		if val, err := strconv.Atoi(envVal); err == nil && val > 0 {
+			recurringBillsInterval = val
+		}
+	}
+
+	botSessionTTL := 15 // Default 15 minutes
+	if envVal := os.Getenv("BOT_SESSION_TTL_MINUTES"); envVal != "" {
+		if val, err := strconv.Atoi(envVal); err == nil && val > 0 {
+			botSessionTTL = val
+		}
+	}
+
+	receiptParser := os.Getenv("RECEIPT_PARSER")
+	if receiptParser == "" {
+		receiptParser = "grok"
+	}
+
+	receiptParserBaseURL := os.Getenv("RECEIPT_PARSER_BASE_URL")
+	if receiptParserBaseURL == "" {
+		receiptParserBaseURL = "http://localhost:11434/v1"
+	}
+
+	receiptParserModel := os.Getenv("RECEIPT_PARSER_MODEL")
+	if receiptParserModel == "" {
+		receiptParserModel = "llava"
+	}
+
+	speechToTextBaseURL := os.Getenv("SPEECH_TO_TEXT_BASE_URL")
+	if speechToTextBaseURL == "" {
+		speechToTextBaseURL = "http://localhost:8000/v1"
+	}
+
+	speechToTextModel := os.Getenv("SPEECH_TO_TEXT_MODEL")
+	if speechToTextModel == "" {
+		speechToTextModel = "whisper-1"
+	}
+
+	speechToTextLanguage := os.Getenv("SPEECH_TO_TEXT_LANGUAGE")
+	if speechToTextLanguage == "" {
+		speechToTextLanguage = "es"
+	}
+
+	blobStore := os.Getenv("BLOB_STORE")
+	if blobStore == "" {
+		blobStore = "local"
+	}
+
+	blobStorePath := os.Getenv("BLOB_STORE_PATH")
+	if blobStorePath == "" {
+		blobStorePath = "data/blobs"
+	}
+
+	s3Region := os.Getenv("S3_REGION")
+	if s3Region == "" {
+		s3Region = "us-east-1"
+	}
+
+	bankStatementFormatsFile := os.Getenv("BANK_STATEMENT_FORMATS_FILE")
+	if bankStatementFormatsFile == "" {
+		bankStatementFormatsFile = "config/bank_statement_formats.json"
+	}
+
 	return &Config{
-		DatabaseUrl:          os.Getenv("DATABASE_URL"),
-		DatabaseToken:        os.Getenv("DATABASE_TOKEN"),
-		Port:                 os.Getenv("PORT"),
-		EmailProviderUrl:     os.Getenv("EMAIL_PROVIDER_URL"),
-		EmailProviderToken:   os.Getenv("EMAIL_PROVIDER_TOKEN"),
-		ClerkJWKSUrl:         os.Getenv("CLERK_JWKS_URL"),
-		GrokAPIKey:           os.Getenv("GROK_API_KEY"),
-		TelegramBotToken:     os.Getenv("TELEGRAM_BOT_TOKEN"),
-		OTPExpirationMinutes: otpExpiration,
+		DatabaseUrl:                   os.Getenv("DATABASE_URL"),
+		DatabaseToken:                 os.Getenv("DATABASE_TOKEN"),
+		Port:                          os.Getenv("PORT"),
+		EmailProviderUrl:              os.Getenv("EMAIL_PROVIDER_URL"),
+		EmailProviderToken:            os.Getenv("EMAIL_PROVIDER_TOKEN"),
+		ClerkJWKSUrl:                  os.Getenv("CLERK_JWKS_URL"),
+		GrokAPIKey:                    os.Getenv("GROK_API_KEY"),
+		TelegramBotToken:              os.Getenv("TELEGRAM_BOT_TOKEN"),
+		OTPExpirationMinutes:          otpExpiration,
+		TelegramBotUsername:           strings.TrimPrefix(os.Getenv("TELEGRAM_BOT_USERNAME"), "@"),
+		WalletInviteExpirationHours:   walletInviteExpiration,
+		ExchangeRateSource:            exchangeRateSource,
+		ExchangeRateAPIUrl:            exchangeRateAPIUrl,
+		ExchangeRateFile:              exchangeRateFile,
+		RecurringBillsIntervalMinutes: recurringBillsInterval,
+		BotSessionTTLMinutes:          botSessionTTL,
+		ReceiptParser:                 receiptParser,
+		ReceiptParserBaseURL:          receiptParserBaseURL,
+		ReceiptParserAPIKey:           os.Getenv("RECEIPT_PARSER_API_KEY"),
+		ReceiptParserModel:            receiptParserModel,
+		SpeechToTextBaseURL:           speechToTextBaseURL,
+		SpeechToTextAPIKey:            os.Getenv("SPEECH_TO_TEXT_API_KEY"),
+		SpeechToTextModel:             speechToTextModel,
+		SpeechToTextLanguage:          speechToTextLanguage,
+		BlobStore:                     blobStore,
+		BlobStorePath:                 blobStorePath,
+		S3Endpoint:                    os.Getenv("S3_ENDPOINT"),
+		S3Region:                      s3Region,
+		S3Bucket:                      os.Getenv("S3_BUCKET"),
+		S3AccessKeyID:                 os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey:             os.Getenv("S3_SECRET_ACCESS_KEY"),
+		BankStatementFormatsFile:      bankStatementFormatsFile,
 	}
 }
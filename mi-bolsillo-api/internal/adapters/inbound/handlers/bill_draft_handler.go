@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type BillDraftHandler struct {
+	billDraftService   *services.BillDraftService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewBillDraftHandler(billDraftService *services.BillDraftService, accountLinkService *services.AccountLinkService) *BillDraftHandler {
+	return &BillDraftHandler{
+		billDraftService:   billDraftService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// GetBillDraft godoc
+// @Summary Get a bill draft
+// @Description Retrieves a parsed receipt awaiting review, with its validation warnings
+// @Tags bills
+// @Produce json
+// @Param id path string true "Draft ID"
+// @Success 200 {object} entities.BillDraft
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this draft"
+// @Failure 404 {object} map[string]string "Draft not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve draft"
+// @Security BearerAuth
+// @Router /bills/drafts/{id} [get]
+func (h *BillDraftHandler) GetBillDraft(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	draft, err := h.billDraftService.GetDraft(c.Param("id"), user.UserID)
+	if err != nil {
+		return billDraftErrorResponse(c, err, "Failed to retrieve draft")
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// UpdateBillDraft godoc
+// @Summary Correct a bill draft
+// @Description Applies corrections to a parsed receipt before it's saved and validates it again
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Param id path string true "Draft ID"
+// @Param request body dtos.UpdateBillDraftRequest true "Draft corrections"
+// @Success 200 {object} entities.BillDraft
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this draft"
+// @Failure 404 {object} map[string]string "Draft not found"
+// @Failure 500 {object} map[string]string "Failed to update draft"
+// @Security BearerAuth
+// @Router /bills/drafts/{id} [put]
+func (h *BillDraftHandler) UpdateBillDraft(c echo.Context) error {
+	var req handlerdtos.UpdateBillDraftRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	draft, err := h.billDraftService.UpdateDraft(c.Param("id"), user.UserID, mappers.ToUpdateBillDraftServiceDTO(req))
+	if err != nil {
+		return billDraftErrorResponse(c, err, "Failed to update draft")
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// ConfirmBillDraft godoc
+// @Summary Confirm a bill draft
+// @Description Saves a reviewed draft as a bill with one expense per item and removes the draft.
+// @Description When the total is above the sum of the items, the difference is saved as one more expense
+// @Tags bills
+// @Produce json
+// @Param id path string true "Draft ID"
+// @Success 201 {object} map[string]interface{} "Bill and expenses created from the draft"
+// @Failure 400 {object} map[string]string "Unsupported currency, items above the total or empty draft"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this draft"
+// @Failure 404 {object} map[string]string "Draft not found"
+// @Failure 500 {object} map[string]string "Failed to confirm draft"
+// @Security BearerAuth
+// @Router /bills/drafts/{id}/confirm [post]
+func (h *BillDraftHandler) ConfirmBillDraft(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	bill, expenses, err := h.billDraftService.ConfirmDraft(c.Param("id"), user.UserID)
+	if err != nil {
+		return billDraftErrorResponse(c, err, "Failed to confirm draft")
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"bill":     bill,
+		"expenses": expenses,
+	})
+}
+
+// DiscardBillDraft godoc
+// @Summary Discard a bill draft
+// @Description Deletes a parsed receipt without saving it, along with its photo
+// @Tags bills
+// @Produce json
+// @Param id path string true "Draft ID"
+// @Success 200 {object} map[string]string "Draft discarded successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this draft"
+// @Failure 404 {object} map[string]string "Draft not found"
+// @Failure 500 {object} map[string]string "Failed to discard draft"
+// @Security BearerAuth
+// @Router /bills/drafts/{id}/discard [post]
+func (h *BillDraftHandler) DiscardBillDraft(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.billDraftService.DiscardDraft(c.Param("id"), user.UserID); err != nil {
+		return billDraftErrorResponse(c, err, "Failed to discard draft")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Draft discarded successfully",
+	})
+}
+
+// billDraftErrorResponse maps bill draft service errors to HTTP responses
+func billDraftErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrInvalidExpense):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Item amount must be greater than zero",
+		})
+	case errors.Is(err, services.ErrDraftItemsExceedTotal):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Items add up to more than the draft total, correct the total or the items",
+		})
+	case errors.Is(err, services.ErrEmptyBillDraft):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Draft has no items nor total",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this draft",
+		})
+	case errors.Is(err, services.ErrBillDraftNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Draft not found",
+		})
+	case errors.Is(err, services.ErrUnauthorizedWallet):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this wallet",
+		})
+	case errors.Is(err, services.ErrWalletNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Wallet not found",
+		})
+	case errors.Is(err, services.ErrUnauthorizedPaymentMethod):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this payment method",
+		})
+	case errors.Is(err, services.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Payment method not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
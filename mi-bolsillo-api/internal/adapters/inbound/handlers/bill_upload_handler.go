@@ -1,42 +1,50 @@
 package handlers
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"time"
 
 	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
-	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/grok"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
 	"github.com/labstack/echo/v4"
 )
 
 type BillUploadHandler struct {
-	grokClient              *grok.GrokClient
+	receiptParsingService   *services.ReceiptParsingService
 	billWithExpensesService *services.BillWithExpensesService
+	billDraftService        *services.BillDraftService
 	accountLinkService      *services.AccountLinkService
 }
 
-func NewBillUploadHandler(grokClient *grok.GrokClient, billWithExpensesService *services.BillWithExpensesService, accountLinkService *services.AccountLinkService) *BillUploadHandler {
+func NewBillUploadHandler(receiptParsingService *services.ReceiptParsingService, billWithExpensesService *services.BillWithExpensesService, billDraftService *services.BillDraftService, accountLinkService *services.AccountLinkService) *BillUploadHandler {
 	return &BillUploadHandler{
-		grokClient:              grokClient,
+		receiptParsingService:   receiptParsingService,
 		billWithExpensesService: billWithExpensesService,
+		billDraftService:        billDraftService,
 		accountLinkService:      accountLinkService,
 	}
 }
 
 // UploadBillPhoto godoc
-// @Summary Upload a bill photo and parse it
-// @Description Uploads a photo of a bill, parses it using Grok API, and creates a bill with expenses
+// @Summary Upload a bill photo or document and parse it
+// @Description Uploads a photo or PDF of a bill, or a SUNAT electronic invoice (UBL 2.1 XML), parses it and creates a bill with expenses.
+// @Description Photos and PDFs are read by the configured LLM provider, XML invoices are parsed directly and keep the supplier RUC and invoice number.
+// @Description With mode=draft the parsed bill is returned as a draft with validation warnings instead, to be confirmed or discarded
 // @Tags bills
 // @Accept multipart/form-data
 // @Produce json
 // @Param Authorization header string true "Bearer token"
-// @Param image formData file true "Bill photo (JPEG, PNG, or other image format)"
-// @Success 201 {object} map[string]interface{} "bill and expenses created successfully from image"
-// @Failure 400 {object} map[string]string "Invalid request or image"
+// @Param image formData file false "Bill photo (JPEG, PNG, or other image format)"
+// @Param file formData file false "Bill document (PDF or SUNAT UBL XML), sent instead of image"
+// @Param mode query string false "Set to draft to review the parsed bill before saving it"
+// @Param walletId query string false "Shared wallet to add the bill to"
+// @Success 201 {object} map[string]interface{} "bill and expenses created successfully from image, or the draft in draft mode"
+// @Failure 400 {object} map[string]string "Invalid request, image or document"
 // @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
 // @Failure 500 {object} map[string]string "Failed to process image or create bill"
 // @Security BearerAuth
 // @Router /bills/upload [post]
@@ -57,11 +65,14 @@ func (h *BillUploadHandler) UploadBillPhoto(c echo.Context) error {
 		})
 	}
 
-	// Get the uploaded file
+	// Get the uploaded file, documents may be sent as file rather than image
 	file, err := c.FormFile("image")
+	if err != nil {
+		file, err = c.FormFile("file")
+	}
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Image file is required",
+			"error": "Image or document file is required",
 		})
 	}
 
@@ -82,11 +93,29 @@ func (h *BillUploadHandler) UploadBillPhoto(c echo.Context) error {
 		})
 	}
 
-	// Parse the bill image using Grok
-	parsedData, err := h.grokClient.ParseBillImage(imageData)
+	// Parse the photo, PDF or electronic invoice
+	parsedData, err := h.receiptParsingService.ParseReceipt(user.UserID, imageData)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to parse bill image: " + err.Error(),
+		return receiptParsingErrorResponse(c, err)
+	}
+
+	// In draft mode nothing is saved until the user confirms the draft
+	if c.QueryParam("mode") == "draft" {
+		draftDTO := mappers.ToCreateBillDraftServiceDTO(parsedData, user.UserID, "web", imageData)
+		draftDTO.WalletID = c.QueryParam("walletId")
+		draft, err := h.billDraftService.CreateDraft(draftDTO)
+		if err != nil {
+			if errors.Is(err, services.ErrUnauthorizedWallet) || errors.Is(err, services.ErrWalletNotFound) {
+				return billErrorResponse(c, err, "Failed to create bill draft")
+			}
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to create bill draft: " + err.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"draft":       draft,
+			"parsed_data": parsedData,
 		})
 	}
 
@@ -98,13 +127,20 @@ func (h *BillUploadHandler) UploadBillPhoto(c echo.Context) error {
 
 	// Convert parsed data to CreateBillWithExpensesRequest
 	handlerDTO := handlerdtos.CreateBillWithExpensesRequest{
-		UserID:      user.UserID,
-		Source:      "web",
-		Description: parsedData.MerchantName,
-		Category:    "General", // Default category for the bill
-		Currency:    parsedData.Currency,
-		Date:        billDate,
-		Expenses:    make([]handlerdtos.CreateExpenseForBill, len(parsedData.Items)),
+		UserID:        user.UserID,
+		WalletID:      c.QueryParam("walletId"),
+		Source:        "web",
+		Description:   parsedData.MerchantName,
+		Category:      "General", // Default category for the bill
+		Currency:      parsedData.Currency,
+		Date:          billDate,
+		ReceiptImage:  imageData,
+		SupplierRUC:   parsedData.SupplierRUC,
+		InvoiceNumber: parsedData.InvoiceNumber,
+		Subtotal:      parsedData.Subtotal,
+		TaxAmount:     parsedData.TaxAmount,
+		TipAmount:     parsedData.TipAmount,
+		Expenses:      make([]handlerdtos.CreateExpenseForBill, len(parsedData.Items)),
 	}
 
 	// Convert bill items to expenses
@@ -119,10 +155,15 @@ func (h *BillUploadHandler) UploadBillPhoto(c echo.Context) error {
 
 	// Map handler DTO to service DTO using mapper
 	serviceDTO := mappers.ToCreateBillWithExpensesServiceDTO(handlerDTO)
+	serviceDTO.CategoryGuessed = true
+	serviceDTO.CardLastFour = parsedData.CardLastFour
 
 	// Create the bill with expenses
 	bill, expenses, err := h.billWithExpensesService.CreateBillWithExpenses(serviceDTO)
 	if err != nil {
+		if errors.Is(err, services.ErrUnauthorizedWallet) || errors.Is(err, services.ErrWalletNotFound) {
+			return billErrorResponse(c, err, "Failed to create bill with expenses")
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to create bill with expenses: " + err.Error(),
 		})
@@ -134,3 +175,19 @@ func (h *BillUploadHandler) UploadBillPhoto(c echo.Context) error {
 		"parsed_data": parsedData,
 	})
 }
+
+// receiptParsingErrorResponse maps receipt parsing errors to HTTP responses
+func receiptParsingErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedReceiptFormat),
+		errors.Is(err, services.ErrInvalidInvoice),
+		errors.Is(err, services.ErrUnreadablePDF):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to parse bill: " + err.Error(),
+		})
+	}
+}
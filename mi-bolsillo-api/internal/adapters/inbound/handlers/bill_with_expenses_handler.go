@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
@@ -30,8 +32,10 @@ func NewBillWithExpensesHandler(service *services.BillWithExpensesService, accou
 // @Param Authorization header string true "Bearer token"
 // @Param request body dtos.CreateBillWithExpensesRequest true "Bill and expenses data"
 // @Success 201 {object} map[string]interface{} "bill and expenses created successfully"
-// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 400 {object} map[string]string "Invalid request body or unsupported currency"
 // @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
 // @Failure 500 {object} map[string]string "Failed to create bill with expenses"
 // @Security BearerAuth
 // @Router /bills [post]
@@ -69,9 +73,7 @@ func (h *BillWithExpensesHandler) CreateBillWithExpenses(c echo.Context) error {
 
 	bill, expenses, err := h.service.CreateBillWithExpenses(serviceDTO)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create bill with expenses",
-		})
+		return billErrorResponse(c, err, "Failed to create bill with expenses")
 	}
 
 	return c.JSON(http.StatusCreated, map[string]interface{}{
@@ -81,14 +83,34 @@ func (h *BillWithExpensesHandler) CreateBillWithExpenses(c echo.Context) error {
 }
 
 // ListBills godoc
-// @Summary List all bills for the authenticated user
-// @Description Retrieves all bills with their associated expenses for the authenticated user
+// @Summary List bills for the authenticated user
+// @Description Retrieves the bills with their associated expenses for the authenticated user,
+// @Description or the bills of a shared wallet with walletId. Every bill is returned unless limit or cursor is set,
+// @Description then a page is returned and the cursor of the next page is in the X-Next-Cursor header
 // @Tags bills
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param walletId query string false "Shared wallet to list the bills of"
+// @Param from query string false "Bills on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Bills on or before this date (YYYY-MM-DD)"
+// @Param category query string false "Category"
+// @Param currency query string false "Original currency"
+// @Param source query string false "Source (web, telegram, recurring)"
+// @Param paymentMethodId query string false "Payment method the bills were paid with"
+// @Param minAmount query number false "Minimum amount in the reporting currency"
+// @Param maxAmount query number false "Maximum amount in the reporting currency"
+// @Param q query string false "Text contained in the description"
+// @Param sort query string false "Sort field: date, amount or createdAt (default date)"
+// @Param order query string false "asc or desc (default desc)"
+// @Param cursor query string false "Cursor returned in X-Next-Cursor by the previous page"
+// @Param limit query int false "Page size (default 50 when paging with cursor, max 200)"
 // @Success 200 {array} dtos.BillWithExpensesResponse "List of bills with expenses"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
 // @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
 // @Failure 500 {object} map[string]string "Failed to retrieve bills"
 // @Security BearerAuth
 // @Router /bills [get]
@@ -109,14 +131,29 @@ func (h *BillWithExpensesHandler) ListBills(c echo.Context) error {
 		})
 	}
 
-	billsWithExpenses, err := h.service.ListBillsByUserID(user.UserID)
+	searchDTO, err := mappers.ToBillSearchServiceDTO(c.QueryParams())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to retrieve bills",
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
 		})
 	}
+	searchDTO.UserID = user.UserID
+
+	result, err := h.service.SearchBills(searchDTO)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBillSearch) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": strings.TrimPrefix(err.Error(), services.ErrInvalidBillSearch.Error()+": "),
+			})
+		}
+		return billErrorResponse(c, err, "Failed to retrieve bills")
+	}
 
-	return c.JSON(http.StatusOK, billsWithExpenses)
+	if result.NextCursor != "" {
+		c.Response().Header().Set("X-Next-Cursor", result.NextCursor)
+	}
+
+	return c.JSON(http.StatusOK, result.Bills)
 }
 
 // GetBillByID godoc
@@ -131,6 +168,7 @@ func (h *BillWithExpensesHandler) ListBills(c echo.Context) error {
 // @Failure 400 {object} map[string]string "Bill ID is required"
 // @Failure 401 {object} map[string]string "User ID not found in context"
 // @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill not found"
 // @Failure 500 {object} map[string]string "Failed to retrieve bill"
 // @Security BearerAuth
 // @Router /bills/{id} [get]
@@ -161,14 +199,7 @@ func (h *BillWithExpensesHandler) GetBillByID(c echo.Context) error {
 
 	bill, expenses, err := h.service.GetBillWithExpenses(billID, user.UserID)
 	if err != nil {
-		if err == services.ErrUnauthorized {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "Access denied to this bill",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to retrieve bill",
-		})
+		return billErrorResponse(c, err, "Failed to retrieve bill")
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -189,6 +220,7 @@ func (h *BillWithExpensesHandler) GetBillByID(c echo.Context) error {
 // @Failure 400 {object} map[string]string "Bill ID is required"
 // @Failure 401 {object} map[string]string "User ID not found in context"
 // @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill not found"
 // @Failure 500 {object} map[string]string "Failed to delete bill"
 // @Security BearerAuth
 // @Router /bills/{id} [delete]
@@ -219,17 +251,308 @@ func (h *BillWithExpensesHandler) DeleteBillByID(c echo.Context) error {
 
 	err = h.service.DeleteBillWithExpenses(billID, user.UserID)
 	if err != nil {
-		if err == services.ErrUnauthorized {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "Access denied to this bill",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete bill",
-		})
+		return billErrorResponse(c, err, "Failed to delete bill")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "Bill and associated expenses deleted successfully",
 	})
 }
+
+// GetBillReceipt godoc
+// @Summary Get the receipt photo of a bill
+// @Description Streams the original receipt photo a bill was parsed from
+// @Tags bills
+// @Produce image/jpeg
+// @Produce image/png
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Bill ID"
+// @Success 200 {file} binary "Receipt photo"
+// @Failure 400 {object} map[string]string "Bill ID is required"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill or receipt not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve receipt"
+// @Security BearerAuth
+// @Router /bills/{id}/receipt [get]
+func (h *BillWithExpensesHandler) GetBillReceipt(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	// Get bill ID from URL parameter
+	billID := c.Param("id")
+	if billID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Bill ID is required",
+		})
+	}
+
+	receipt, contentType, err := h.service.GetReceipt(billID, user.UserID)
+	if err != nil {
+		return billErrorResponse(c, err, "Failed to retrieve receipt")
+	}
+	defer receipt.Close()
+
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	return c.Stream(http.StatusOK, contentType, receipt)
+}
+
+// UpdateBill godoc
+// @Summary Update a bill
+// @Description Updates a bill's details for the authenticated user. Changing the currency, date or exchange rate reconverts its expenses
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Bill ID"
+// @Param request body dtos.UpdateBillRequest true "Bill data"
+// @Success 200 {object} map[string]interface{} "Updated bill with expenses"
+// @Failure 400 {object} map[string]string "Invalid request body or unsupported currency"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill not found"
+// @Failure 500 {object} map[string]string "Failed to update bill"
+// @Security BearerAuth
+// @Router /bills/{id} [put]
+func (h *BillWithExpensesHandler) UpdateBill(c echo.Context) error {
+	var handlerDTO handlerdtos.UpdateBillRequest
+	if err := c.Bind(&handlerDTO); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	bill, expenses, err := h.service.UpdateBill(c.Param("id"), user.UserID, mappers.ToUpdateBillServiceDTO(handlerDTO))
+	if err != nil {
+		return billErrorResponse(c, err, "Failed to update bill")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"bill":     bill,
+		"expenses": expenses,
+	})
+}
+
+// AddExpense godoc
+// @Summary Add an expense to a bill
+// @Description Adds an expense in the bill's currency and recomputes the bill's totals
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Bill ID"
+// @Param request body dtos.CreateExpenseForBill true "Expense data"
+// @Success 201 {object} map[string]interface{} "Updated bill and created expense"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill not found"
+// @Failure 500 {object} map[string]string "Failed to add expense"
+// @Security BearerAuth
+// @Router /bills/{id}/expenses [post]
+func (h *BillWithExpensesHandler) AddExpense(c echo.Context) error {
+	var handlerDTO handlerdtos.CreateExpenseForBill
+	if err := c.Bind(&handlerDTO); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	bill, expense, err := h.service.AddExpense(c.Param("id"), user.UserID, mappers.ToCreateExpenseForBillServiceDTO(handlerDTO))
+	if err != nil {
+		return billErrorResponse(c, err, "Failed to add expense")
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"bill":    bill,
+		"expense": expense,
+	})
+}
+
+// UpdateExpense godoc
+// @Summary Update an expense of a bill
+// @Description Partially updates an expense and recomputes the bill's totals
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Bill ID"
+// @Param expenseId path string true "Expense ID"
+// @Param request body dtos.UpdateExpenseRequest true "Expense fields to update"
+// @Success 200 {object} map[string]interface{} "Updated bill and expense"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill or expense not found"
+// @Failure 500 {object} map[string]string "Failed to update expense"
+// @Security BearerAuth
+// @Router /bills/{id}/expenses/{expenseId} [patch]
+func (h *BillWithExpensesHandler) UpdateExpense(c echo.Context) error {
+	var handlerDTO handlerdtos.UpdateExpenseRequest
+	if err := c.Bind(&handlerDTO); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	bill, expense, err := h.service.UpdateExpense(c.Param("id"), c.Param("expenseId"), user.UserID, mappers.ToUpdateExpenseServiceDTO(handlerDTO))
+	if err != nil {
+		return billErrorResponse(c, err, "Failed to update expense")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"bill":    bill,
+		"expense": expense,
+	})
+}
+
+// DeleteExpense godoc
+// @Summary Delete an expense of a bill
+// @Description Deletes an expense and recomputes the bill's totals
+// @Tags bills
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Bill ID"
+// @Param expenseId path string true "Expense ID"
+// @Success 200 {object} entities.Bill "Updated bill"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill or expense not found"
+// @Failure 500 {object} map[string]string "Failed to delete expense"
+// @Security BearerAuth
+// @Router /bills/{id}/expenses/{expenseId} [delete]
+func (h *BillWithExpensesHandler) DeleteExpense(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	bill, err := h.service.DeleteExpense(c.Param("id"), c.Param("expenseId"), user.UserID)
+	if err != nil {
+		return billErrorResponse(c, err, "Failed to delete expense")
+	}
+
+	return c.JSON(http.StatusOK, bill)
+}
+
+// billErrorResponse maps bill service errors to HTTP responses
+func billErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrInvalidExpense):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Expense amount must be greater than zero",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this bill",
+		})
+	case errors.Is(err, services.ErrBillNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Bill not found",
+		})
+	case errors.Is(err, services.ErrExpenseNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Expense not found",
+		})
+	case errors.Is(err, services.ErrReceiptNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Bill has no stored receipt",
+		})
+	case errors.Is(err, services.ErrUnauthorizedWallet):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this wallet",
+		})
+	case errors.Is(err, services.ErrWalletNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Wallet not found",
+		})
+	case errors.Is(err, services.ErrUnauthorizedPaymentMethod):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this payment method",
+		})
+	case errors.Is(err, services.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Payment method not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
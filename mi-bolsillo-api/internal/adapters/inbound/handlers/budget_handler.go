@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type BudgetHandler struct {
+	budgetService      *services.BudgetService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewBudgetHandler(budgetService *services.BudgetService, accountLinkService *services.AccountLinkService) *BudgetHandler {
+	return &BudgetHandler{
+		budgetService:      budgetService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// CreateBudget godoc
+// @Summary Create a monthly budget for a category
+// @Description Creates a monthly spending limit for a category of the authenticated user
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateBudgetRequest true "Budget data"
+// @Success 201 {object} entities.Budget
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 409 {object} map[string]string "A budget already exists for this category"
+// @Failure 500 {object} map[string]string "Failed to create budget"
+// @Security BearerAuth
+// @Router /budgets [post]
+func (h *BudgetHandler) CreateBudget(c echo.Context) error {
+	var req handlerdtos.CreateBudgetRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	budget, err := h.budgetService.CreateBudget(user, req.Category, req.AmountLimit, req.Currency)
+	if err != nil {
+		return budgetErrorResponse(c, err, "Failed to create budget")
+	}
+
+	return c.JSON(http.StatusCreated, budget)
+}
+
+// ListBudgets godoc
+// @Summary List budgets with their spending status
+// @Description Returns every budget of the authenticated user with spent, remaining and burn rate for a month
+// @Tags budgets
+// @Produce json
+// @Param month query string false "Month in YYYY-MM format (defaults to the current month)"
+// @Success 200 {array} dtos.BudgetStatus
+// @Failure 400 {object} map[string]string "Invalid month"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve budgets"
+// @Security BearerAuth
+// @Router /budgets [get]
+func (h *BudgetHandler) ListBudgets(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	month, err := parseMonthParam(c.QueryParam("month"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid month, expected YYYY-MM",
+		})
+	}
+
+	statuses, err := h.budgetService.ListBudgetStatuses(user.UserID, month)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve budgets",
+		})
+	}
+
+	return c.JSON(http.StatusOK, statuses)
+}
+
+// GetBudgetByID godoc
+// @Summary Get a budget's spending status
+// @Description Returns spent, remaining and burn rate of a budget for a month
+// @Tags budgets
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Param month query string false "Month in YYYY-MM format (defaults to the current month)"
+// @Success 200 {object} dtos.BudgetStatus
+// @Failure 400 {object} map[string]string "Invalid month"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this budget"
+// @Failure 404 {object} map[string]string "Budget not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve budget"
+// @Security BearerAuth
+// @Router /budgets/{id} [get]
+func (h *BudgetHandler) GetBudgetByID(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	month, err := parseMonthParam(c.QueryParam("month"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid month, expected YYYY-MM",
+		})
+	}
+
+	status, err := h.budgetService.GetBudgetStatus(c.Param("id"), user.UserID, month)
+	if err != nil {
+		return budgetErrorResponse(c, err, "Failed to retrieve budget")
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// UpdateBudget godoc
+// @Summary Update a budget
+// @Description Updates the monthly limit of a budget owned by the authenticated user
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Param request body dtos.UpdateBudgetRequest true "Budget data"
+// @Success 200 {object} entities.Budget
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this budget"
+// @Failure 404 {object} map[string]string "Budget not found"
+// @Failure 500 {object} map[string]string "Failed to update budget"
+// @Security BearerAuth
+// @Router /budgets/{id} [put]
+func (h *BudgetHandler) UpdateBudget(c echo.Context) error {
+	var req handlerdtos.UpdateBudgetRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	budget, err := h.budgetService.UpdateBudget(c.Param("id"), user.UserID, req.AmountLimit, req.Currency)
+	if err != nil {
+		return budgetErrorResponse(c, err, "Failed to update budget")
+	}
+
+	return c.JSON(http.StatusOK, budget)
+}
+
+// DeleteBudget godoc
+// @Summary Delete a budget
+// @Description Deletes a budget owned by the authenticated user
+// @Tags budgets
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Success 200 {object} map[string]string "Budget deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this budget"
+// @Failure 404 {object} map[string]string "Budget not found"
+// @Failure 500 {object} map[string]string "Failed to delete budget"
+// @Security BearerAuth
+// @Router /budgets/{id} [delete]
+func (h *BudgetHandler) DeleteBudget(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.budgetService.DeleteBudget(c.Param("id"), user.UserID); err != nil {
+		return budgetErrorResponse(c, err, "Failed to delete budget")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Budget deleted successfully",
+	})
+}
+
+// budgetErrorResponse maps budget service errors to HTTP responses
+func budgetErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidBudget):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Budget requires a category and a positive amount limit",
+		})
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrBudgetAlreadyExists):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "A budget already exists for this category",
+		})
+	case errors.Is(err, services.ErrUnauthorizedBudget):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this budget",
+		})
+	case errors.Is(err, services.ErrBudgetNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Budget not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
+
+// parseMonthParam parses a YYYY-MM query parameter, defaulting to the current month
+func parseMonthParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	return time.ParseInLocation("2006-01", value, time.Local)
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryHandler struct {
+	categoryService    *services.CategoryService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewCategoryHandler(categoryService *services.CategoryService, accountLinkService *services.AccountLinkService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService:    categoryService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// ListCategories godoc
+// @Summary List categories
+// @Description Returns the categories and subcategories of the authenticated user, creating the default set on first use
+// @Tags categories
+// @Produce json
+// @Success 200 {array} entities.Category
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve categories"
+// @Security BearerAuth
+// @Router /categories [get]
+func (h *CategoryHandler) ListCategories(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	categories, err := h.categoryService.ListCategories(user.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve categories",
+		})
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory godoc
+// @Summary Create a category
+// @Description Creates a category, or a subcategory of a top-level category, for the authenticated user
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateCategoryRequest true "Category data"
+// @Success 201 {object} entities.Category
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 409 {object} map[string]string "A category with this name already exists"
+// @Failure 500 {object} map[string]string "Failed to create category"
+// @Security BearerAuth
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var req handlerdtos.CreateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	category, err := h.categoryService.CreateCategory(user.UserID, mappers.ToCreateCategoryServiceDTO(req))
+	if err != nil {
+		return categoryErrorResponse(c, err, "Failed to create category")
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory godoc
+// @Summary Update a category
+// @Description Renames, recolors or moves a category. Renaming it also renames the category of its bills, expenses, budgets and recurring bills
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param request body dtos.UpdateCategoryRequest true "Fields to update"
+// @Success 200 {object} entities.Category
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this category"
+// @Failure 404 {object} map[string]string "Category not found"
+// @Failure 409 {object} map[string]string "A category with this name already exists"
+// @Failure 500 {object} map[string]string "Failed to update category"
+// @Security BearerAuth
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	var req handlerdtos.UpdateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Param("id"), user.UserID, mappers.ToUpdateCategoryServiceDTO(req))
+	if err != nil {
+		return categoryErrorResponse(c, err, "Failed to update category")
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory godoc
+// @Summary Delete a category
+// @Description Deletes a category, its bills and expenses move to the parent category or to Other
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} map[string]string "Category deleted successfully"
+// @Failure 400 {object} map[string]string "The last category can't be deleted"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this category"
+// @Failure 404 {object} map[string]string "Category not found"
+// @Failure 409 {object} map[string]string "Category has subcategories or a budget"
+// @Failure 500 {object} map[string]string "Failed to delete category"
+// @Security BearerAuth
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.categoryService.DeleteCategory(c.Param("id"), user.UserID); err != nil {
+		return categoryErrorResponse(c, err, "Failed to delete category")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Category deleted successfully",
+	})
+}
+
+// categoryErrorResponse maps category service errors to HTTP responses
+func categoryErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidCategory):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrCategoryNameTaken):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "A category with this name already exists",
+		})
+	case errors.Is(err, services.ErrCategoryHasChildren):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Delete or move the subcategories of this category first",
+		})
+	case errors.Is(err, services.ErrCategoryInUse):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Delete the budget of this category first",
+		})
+	case errors.Is(err, services.ErrUnauthorizedCategory):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this category",
+		})
+	case errors.Is(err, services.ErrCategoryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Category not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryRuleHandler struct {
+	categoryRuleService *services.CategoryRuleService
+	accountLinkService  *services.AccountLinkService
+}
+
+func NewCategoryRuleHandler(categoryRuleService *services.CategoryRuleService, accountLinkService *services.AccountLinkService) *CategoryRuleHandler {
+	return &CategoryRuleHandler{
+		categoryRuleService: categoryRuleService,
+		accountLinkService:  accountLinkService,
+	}
+}
+
+// ListRules godoc
+// @Summary List auto-categorization rules
+// @Description Returns the category rules of the authenticated user in the order they are checked
+// @Tags rules
+// @Produce json
+// @Success 200 {array} entities.CategoryRule
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve rules"
+// @Security BearerAuth
+// @Router /rules [get]
+func (h *CategoryRuleHandler) ListRules(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	rules, err := h.categoryRuleService.ListRules(user.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve rules",
+		})
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// CreateRule godoc
+// @Summary Create an auto-categorization rule
+// @Description Creates a rule such as "description contains 'uber'" → Transportation, applied to new bills before they are saved
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param request body dtos.CategoryRuleRequest true "Rule data"
+// @Success 201 {object} entities.CategoryRule
+// @Failure 400 {object} map[string]string "Invalid rule"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to create rule"
+// @Security BearerAuth
+// @Router /rules [post]
+func (h *CategoryRuleHandler) CreateRule(c echo.Context) error {
+	var req handlerdtos.CategoryRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	rule, err := h.categoryRuleService.CreateRule(user.UserID, mappers.ToCategoryRuleServiceDTO(req))
+	if err != nil {
+		return categoryRuleErrorResponse(c, err, "Failed to create rule")
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule godoc
+// @Summary Update an auto-categorization rule
+// @Description Replaces the condition, category and priority of a rule owned by the authenticated user
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param request body dtos.CategoryRuleRequest true "Rule data"
+// @Success 200 {object} entities.CategoryRule
+// @Failure 400 {object} map[string]string "Invalid rule"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this rule"
+// @Failure 404 {object} map[string]string "Rule not found"
+// @Failure 500 {object} map[string]string "Failed to update rule"
+// @Security BearerAuth
+// @Router /rules/{id} [put]
+func (h *CategoryRuleHandler) UpdateRule(c echo.Context) error {
+	var req handlerdtos.CategoryRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	rule, err := h.categoryRuleService.UpdateRule(c.Param("id"), user.UserID, mappers.ToCategoryRuleServiceDTO(req))
+	if err != nil {
+		return categoryRuleErrorResponse(c, err, "Failed to update rule")
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule godoc
+// @Summary Delete an auto-categorization rule
+// @Description Deletes a rule owned by the authenticated user, bills it already categorized keep their category
+// @Tags rules
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} map[string]string "Rule deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this rule"
+// @Failure 404 {object} map[string]string "Rule not found"
+// @Failure 500 {object} map[string]string "Failed to delete rule"
+// @Security BearerAuth
+// @Router /rules/{id} [delete]
+func (h *CategoryRuleHandler) DeleteRule(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.categoryRuleService.DeleteRule(c.Param("id"), user.UserID); err != nil {
+		return categoryRuleErrorResponse(c, err, "Failed to delete rule")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Rule deleted successfully",
+	})
+}
+
+// ApplyRules godoc
+// @Summary Apply the rules to existing bills
+// @Description Recategorizes the authenticated user's existing bills and expenses with their rules. With dryRun=true nothing is saved and the response lists what would change
+// @Tags rules
+// @Produce json
+// @Param dryRun query bool false "Report the changes without saving them"
+// @Success 200 {object} dtos.RuleApplyResult
+// @Failure 400 {object} map[string]string "Invalid dryRun"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to apply rules"
+// @Security BearerAuth
+// @Router /rules/apply [post]
+func (h *CategoryRuleHandler) ApplyRules(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	dryRun := false
+	if dryRunParam := c.QueryParam("dryRun"); dryRunParam != "" {
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid dryRun, expected true or false",
+			})
+		}
+	}
+
+	result, err := h.categoryRuleService.ApplyRules(user.UserID, dryRun)
+	if err != nil {
+		return categoryRuleErrorResponse(c, err, "Failed to apply rules")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// categoryRuleErrorResponse maps category rule service errors to HTTP responses
+func categoryRuleErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidRule):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrUnauthorizedRule):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this rule",
+		})
+	case errors.Is(err, services.ErrRuleNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Rule not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
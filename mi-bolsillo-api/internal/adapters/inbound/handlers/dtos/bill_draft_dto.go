@@ -0,0 +1,20 @@
+package dtos
+
+// UpdateBillDraftRequest represents the corrections to a parsed receipt, omitted fields are left unchanged
+type UpdateBillDraftRequest struct {
+	Description *string  `json:"description,omitempty" example:"Wong"`
+	Currency    *string  `json:"currency,omitempty" example:"PEN"`
+	Date        *string  `json:"date,omitempty" example:"2025-10-10"`
+	TotalAmount *float64 `json:"totalAmount,omitempty" example:"45.80"`
+	// Items replaces every line of the draft when present
+	Items *[]BillDraftItemRequest `json:"items,omitempty"`
+	// PaymentMethodID changes the account the bill is paid with, an empty string unlinks it
+	PaymentMethodID *string `json:"paymentMethodId,omitempty" example:"123e4567-e89b-12d3-a456-426614174012"`
+}
+
+// BillDraftItemRequest represents a line of a bill draft
+type BillDraftItemRequest struct {
+	Description string  `json:"description" example:"Leche Gloria"`
+	Amount      float64 `json:"amount" example:"4.50"`
+	Category    string  `json:"category" example:"Food"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+// CreateBudgetRequest represents the request to create a monthly category budget
+type CreateBudgetRequest struct {
+	Category    string  `json:"category" example:"Food"`
+	AmountLimit float64 `json:"amountLimit" example:"800.00"`
+	// Currency defaults to the user's reporting currency
+	Currency string `json:"currency,omitempty" example:"PEN"`
+}
+
+// UpdateBudgetRequest represents the request to update a budget's limit
+type UpdateBudgetRequest struct {
+	AmountLimit float64 `json:"amountLimit" example:"950.00"`
+	Currency    string  `json:"currency,omitempty" example:"PEN"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+// CreateCategoryRequest represents the request to create a category or subcategory
+type CreateCategoryRequest struct {
+	Name string `json:"name" example:"Groceries"`
+	// ParentID makes it a subcategory of one of the user's top-level categories
+	ParentID string `json:"parentId,omitempty" example:"123e4567-e89b-12d3-a456-426614174005"`
+	Icon     string `json:"icon,omitempty" example:"🥦"`
+	// Color in #RRGGBB format
+	Color string `json:"color,omitempty" example:"#66BB6A"`
+}
+
+// UpdateCategoryRequest represents a partial update of a category, omitted fields are left unchanged.
+// An empty parentId moves a subcategory to the top level
+type UpdateCategoryRequest struct {
+	Name     *string `json:"name,omitempty" example:"Supermarket"`
+	ParentID *string `json:"parentId,omitempty" example:""`
+	Icon     *string `json:"icon,omitempty" example:"🛒"`
+	Color    *string `json:"color,omitempty" example:"#43A047"`
+}
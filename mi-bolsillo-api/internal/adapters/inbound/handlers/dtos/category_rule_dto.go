@@ -0,0 +1,14 @@
+package dtos
+
+// CategoryRuleRequest represents the request to create or update a category rule
+type CategoryRuleRequest struct {
+	// Field is description (the bill or expense description) or merchant (the bill's description), defaults to description
+	Field string `json:"field,omitempty" example:"description"`
+	// Operator is contains, equals or starts_with, defaults to contains. Matching ignores case and accents
+	Operator string `json:"operator,omitempty" example:"contains"`
+	Pattern  string `json:"pattern" example:"uber"`
+	// Category is the ID or name of one of the user's categories
+	Category string `json:"category" example:"Transportation"`
+	// Priority orders the rules, lower values are checked first
+	Priority int `json:"priority,omitempty" example:"0"`
+}
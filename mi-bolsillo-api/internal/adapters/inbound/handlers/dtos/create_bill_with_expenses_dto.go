@@ -4,16 +4,31 @@ import "time"
 
 // CreateBillWithExpensesRequest represents the request to create a bill with expenses
 type CreateBillWithExpensesRequest struct {
-	Description  string                 `json:"description" example:"Grocery shopping"`
-	Category     string                 `json:"category" example:"Food"`
-	Date         time.Time              `json:"date" example:"2025-10-10T10:00:00Z"`
-	Currency     string                 `json:"currency" example:"USD"`
+	Description string    `json:"description" example:"Grocery shopping"`
+	Category    string    `json:"category" example:"Food"`
+	Date        time.Time `json:"date" example:"2025-10-10T10:00:00Z"`
+	// Currency is any ISO 4217 code, amounts are converted through the exchange rate table
+	Currency string `json:"currency" example:"USD"`
+	// ExchangeRate optionally overrides the USD to PEN rate, it's resolved for Date when omitted
 	ExchangeRate float64                `json:"exchangeRate" example:"3.75"`
 	Expenses     []CreateExpenseForBill `json:"expenses"`
+	// SupplierRUC and InvoiceNumber optionally identify the SUNAT electronic invoice of the bill
+	SupplierRUC   string `json:"supplierRuc" example:"20100070970"`
+	InvoiceNumber string `json:"invoiceNumber" example:"F001-00012345"`
+	// Subtotal, TaxAmount (IGV) and TipAmount optionally break down the bill, in its currency
+	Subtotal  float64 `json:"subtotal" example:"77.97"`
+	TaxAmount float64 `json:"taxAmount" example:"14.03"`
+	TipAmount float64 `json:"tipAmount" example:"9.20"`
+	// WalletID optionally adds the bill to a shared wallet the user can edit
+	WalletID string `json:"walletId,omitempty" example:"123e4567-e89b-12d3-a456-426614174008"`
+	// PaymentMethodID optionally records the account the bill was paid with
+	PaymentMethodID string `json:"paymentMethodId,omitempty" example:"123e4567-e89b-12d3-a456-426614174012"`
 	// UserID is set from JWT token in the handler, not from request body
 	UserID string `json:"-" swaggerignore:"true"`
 	// Source is set by the handler (web or telegram), not from request body
 	Source string `json:"-" swaggerignore:"true"`
+	// ReceiptImage is set by the upload handlers with the parsed photo, not from request body
+	ReceiptImage []byte `json:"-" swaggerignore:"true"`
 }
 
 // CreateExpenseForBill represents an expense item within a bill
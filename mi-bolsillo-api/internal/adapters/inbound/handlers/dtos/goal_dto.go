@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+// GoalRequest represents the request to create or update a savings goal
+type GoalRequest struct {
+	Name         string  `json:"name" example:"Viaje a Cusco"`
+	TargetAmount float64 `json:"targetAmount" example:"3000"`
+	// Currency is any ISO 4217 code, PEN when omitted on creation
+	Currency string    `json:"currency,omitempty" example:"PEN"`
+	Deadline time.Time `json:"deadline" example:"2026-07-28T00:00:00Z"`
+}
+
+// GoalContributionRequest represents money put towards a goal, in the goal's currency. A negative
+// amount is a withdrawal
+type GoalContributionRequest struct {
+	Amount float64 `json:"amount" example:"250"`
+	Note   string  `json:"note,omitempty" example:"Gratificación"`
+	// Date defaults to now
+	Date time.Time `json:"date,omitempty" example:"2025-10-30T10:00:00Z"`
+}
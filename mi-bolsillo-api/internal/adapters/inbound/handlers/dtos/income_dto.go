@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+// IncomeRequest represents the request to record or update an income
+type IncomeRequest struct {
+	// Type is salary, freelance, refund or other (default)
+	Type        string  `json:"type,omitempty" example:"salary"`
+	Description string  `json:"description" example:"Sueldo de octubre"`
+	Amount      float64 `json:"amount" example:"3000.00"`
+	// Currency is any ISO 4217 code, PEN when omitted on creation
+	Currency string `json:"currency,omitempty" example:"PEN"`
+	// Date defaults to now on creation
+	Date time.Time `json:"date,omitempty" example:"2025-10-30T10:00:00Z"`
+	// ExchangeRate optionally overrides the USD to PEN rate, it's resolved for Date when omitted
+	ExchangeRate float64 `json:"exchangeRate,omitempty" example:"3.75"`
+}
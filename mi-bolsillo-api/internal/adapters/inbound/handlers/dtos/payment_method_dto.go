@@ -0,0 +1,15 @@
+package dtos
+
+// PaymentMethodRequest represents the request to add or update a payment method
+type PaymentMethodRequest struct {
+	Name string `json:"name" example:"Visa BCP"`
+	// Type is credit_card, debit_card, yape, plin, cash or bank_account
+	Type string `json:"type" example:"credit_card"`
+	// Currency is any ISO 4217 code, PEN when omitted on creation
+	Currency string `json:"currency,omitempty" example:"PEN"`
+	// LastFour are the last 4 digits of a card, used to match the card printed on receipts
+	LastFour *string `json:"lastFour,omitempty" example:"4321"`
+	// CreditLimit and StatementClosingDay only apply to credit cards
+	CreditLimit         *float64 `json:"creditLimit,omitempty" example:"5000"`
+	StatementClosingDay *int     `json:"statementClosingDay,omitempty" example:"20"`
+}
@@ -0,0 +1,23 @@
+package dtos
+
+// RecurringBillRequest represents the request to create or update a recurring bill
+type RecurringBillRequest struct {
+	Description string  `json:"description" example:"Netflix"`
+	Category    string  `json:"category" example:"Entertainment"`
+	Amount      float64 `json:"amount" example:"44.90"`
+	Currency    string  `json:"currency" example:"PEN"`
+	// Frequency is one of weekly, monthly or yearly
+	Frequency string `json:"frequency" example:"monthly"`
+	// Interval repeats the schedule every N periods (default 1)
+	Interval int `json:"interval,omitempty" example:"1"`
+	// DayOfMonth is used by monthly and yearly schedules, defaults to the start date's day
+	DayOfMonth int `json:"dayOfMonth,omitempty" example:"15"`
+	// DayOfWeek is used by weekly schedules, 0 = Sunday, defaults to the start date's weekday
+	DayOfWeek *int `json:"dayOfWeek,omitempty" example:"1"`
+	// MonthOfYear is used by yearly schedules, defaults to the start date's month
+	MonthOfYear int `json:"monthOfYear,omitempty" example:"3"`
+	// StartDate in YYYY-MM-DD format, defaults to today
+	StartDate string  `json:"startDate,omitempty" example:"2025-10-01"`
+	EndDate   *string `json:"endDate,omitempty" example:"2026-10-01"`
+	Active    *bool   `json:"active,omitempty" example:"true"`
+}
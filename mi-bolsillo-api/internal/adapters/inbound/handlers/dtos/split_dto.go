@@ -0,0 +1,33 @@
+package dtos
+
+import "time"
+
+// SplitBillRequest represents the request to split a bill between participants
+type SplitBillRequest struct {
+	// PaidBy is who paid the bill, "me" by default on personal bills
+	PaidBy       string                    `json:"paidBy,omitempty" example:"me"`
+	Participants []SplitParticipantRequest `json:"participants"`
+}
+
+// SplitParticipantRequest is a participant of a split. ExpenseIDs are the bill lines they had, split evenly
+// with others listing them. The rest of the bill goes by Percentage when any participant has one,
+// otherwise in proportion to each one's lines, or evenly when no line is assigned
+type SplitParticipantRequest struct {
+	Name       string   `json:"name" example:"Ana"`
+	ExpenseIDs []string `json:"expenseIds,omitempty"`
+	Percentage float64  `json:"percentage,omitempty" example:"50"`
+}
+
+// CreateSettlementRequest represents the request to record a payment between participants
+type CreateSettlementRequest struct {
+	// WalletID records the settlement in a shared wallet's ledger instead of the personal one
+	WalletID string  `json:"walletId,omitempty" example:"123e4567-e89b-12d3-a456-426614174008"`
+	From     string  `json:"from" example:"Ana"`
+	To       string  `json:"to" example:"me"`
+	Amount   float64 `json:"amount" example:"60.00"`
+	// Currency defaults to the user's reporting currency
+	Currency string `json:"currency,omitempty" example:"PEN"`
+	Note     string `json:"note,omitempty" example:"Yape"`
+	// Date defaults to now
+	Date time.Time `json:"date,omitempty" example:"2025-10-12T10:00:00Z"`
+}
@@ -0,0 +1,30 @@
+package dtos
+
+import "time"
+
+// UpdateBillRequest represents the request to update a bill's details.
+// Omitted fields keep their current value
+type UpdateBillRequest struct {
+	Description string    `json:"description" example:"Grocery shopping"`
+	Category    string    `json:"category" example:"Food"`
+	Date        time.Time `json:"date" example:"2025-10-10T10:00:00Z"`
+	// Currency changes reconvert every expense of the bill
+	Currency string `json:"currency,omitempty" example:"USD"`
+	// ExchangeRate optionally overrides the USD to PEN rate, it's resolved for Date when omitted
+	ExchangeRate float64 `json:"exchangeRate,omitempty" example:"3.75"`
+	// The tax details are left unchanged when omitted
+	SupplierRUC *string  `json:"supplierRuc,omitempty" example:"20100070970"`
+	Subtotal    *float64 `json:"subtotal,omitempty" example:"77.97"`
+	TaxAmount   *float64 `json:"taxAmount,omitempty" example:"14.03"`
+	TipAmount   *float64 `json:"tipAmount,omitempty" example:"9.20"`
+	// PaymentMethodID changes the account the bill was paid with, an empty string unlinks it
+	PaymentMethodID *string `json:"paymentMethodId,omitempty" example:"123e4567-e89b-12d3-a456-426614174012"`
+}
+
+// UpdateExpenseRequest represents a partial update of an expense, omitted fields are left unchanged
+type UpdateExpenseRequest struct {
+	Amount      *float64 `json:"amount,omitempty" example:"25.50"`
+	Description *string  `json:"description,omitempty" example:"Apples"`
+	Category    *string  `json:"category,omitempty" example:"Fruits"`
+	Date        *string  `json:"date,omitempty" example:"2025-10-10"`
+}
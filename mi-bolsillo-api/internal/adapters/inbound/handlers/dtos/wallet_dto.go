@@ -0,0 +1,32 @@
+package dtos
+
+import "time"
+
+// WalletRequest represents the request to create or rename a shared wallet
+type WalletRequest struct {
+	Name string `json:"name" example:"Casa"`
+}
+
+// WalletInviteRequest represents the request to invite someone to a wallet
+type WalletInviteRequest struct {
+	// Role of whoever joins with the invite: editor (default) or viewer
+	Role string `json:"role,omitempty" example:"editor"`
+}
+
+// WalletMemberRequest represents the request to change the role of a wallet member
+type WalletMemberRequest struct {
+	// Role is editor or viewer
+	Role string `json:"role" example:"viewer"`
+}
+
+// WalletInviteResponse is a wallet invite with the links to share it
+type WalletInviteResponse struct {
+	Code      string    `json:"code" example:"3f2b9c1d8e7a4b6c9d0e1f2a3b4c5d6e"`
+	WalletID  string    `json:"walletId" example:"123e4567-e89b-12d3-a456-426614174008"`
+	Role      string    `json:"role" example:"editor"`
+	ExpiresAt time.Time `json:"expiresAt" example:"2025-10-13T10:00:00Z"`
+	// TelegramLink joins the wallet from the bot, TelegramGroupLink adds the bot to a group that
+	// posts into the wallet. Both are empty when the bot's username isn't configured
+	TelegramLink      string `json:"telegramLink,omitempty" example:"https://t.me/MiBolsilloBot?start=3f2b9c1d8e7a4b6c9d0e1f2a3b4c5d6e"`
+	TelegramGroupLink string `json:"telegramGroupLink,omitempty" example:"https://t.me/MiBolsilloBot?startgroup=3f2b9c1d8e7a4b6c9d0e1f2a3b4c5d6e"`
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ExportHandler struct {
+	exportService      *services.ExportService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewExportHandler(exportService *services.ExportService, accountLinkService *services.AccountLinkService) *ExportHandler {
+	return &ExportHandler{
+		exportService:      exportService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// ExportBills godoc
+// @Summary Export bills as a spreadsheet
+// @Description Streams the authenticated user's bills, or every bill of a shared wallet with walletId,
+// @Description with one row per expense, bill columns are repeated on each row
+// @Tags exports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv or xlsx (default csv)"
+// @Param walletId query string false "Shared wallet to export the bills of"
+// @Param from query string false "Bills on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Bills on or before this date (YYYY-MM-DD)"
+// @Success 200 {file} file "Spreadsheet with bills and expenses"
+// @Failure 400 {object} map[string]string "Invalid format or date"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to export bills"
+// @Security BearerAuth
+// @Router /exports/bills [get]
+func (h *ExportHandler) ExportBills(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	formatName := c.QueryParam("format")
+	if formatName == "" {
+		formatName = "csv"
+	}
+	format, err := h.exportService.GetFormat(formatName)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported format, expected csv or xlsx",
+		})
+	}
+
+	from, to, err := parseDateRangeParams(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid date, expected YYYY-MM-DD",
+		})
+	}
+
+	walletID := c.QueryParam("walletId")
+	if err := h.exportService.AuthorizeExport(user.UserID, walletID); err != nil {
+		return walletErrorResponse(c, err, "Failed to export bills")
+	}
+
+	filename := fmt.Sprintf("mi-bolsillo-bills-%s.%s", time.Now().Format("2006-01-02"), format.Name())
+	c.Response().Header().Set(echo.HeaderContentType, format.ContentType())
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so errors can only be logged and the download is left truncated
+	if err := h.exportService.ExportBills(user.UserID, walletID, format, from, to, c.Response()); err != nil {
+		log.Printf("Failed to export bills for user %s: %v", user.UserID, err)
+	}
+
+	return nil
+}
+
+// parseDateRangeParams parses optional YYYY-MM-DD bounds, the to date is inclusive so it's
+// returned as the start of the following day
+func parseDateRangeParams(fromValue string, toValue string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if fromValue != "" {
+		date, err := time.ParseInLocation("2006-01-02", fromValue, time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = &date
+	}
+
+	if toValue != "" {
+		date, err := time.ParseInLocation("2006-01-02", toValue, time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		date = date.AddDate(0, 0, 1)
+		to = &date
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type GoalHandler struct {
+	goalService        *services.GoalService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewGoalHandler(goalService *services.GoalService, accountLinkService *services.AccountLinkService) *GoalHandler {
+	return &GoalHandler{
+		goalService:        goalService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// CreateGoal godoc
+// @Summary Create a savings goal
+// @Description Creates an amount the authenticated user is saving up for by a deadline
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param request body dtos.GoalRequest true "Goal data"
+// @Success 201 {object} entities.Goal
+// @Failure 400 {object} map[string]string "Invalid goal"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to create goal"
+// @Security BearerAuth
+// @Router /goals [post]
+func (h *GoalHandler) CreateGoal(c echo.Context) error {
+	var req handlerdtos.GoalRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	goal, err := h.goalService.CreateGoal(mappers.ToGoalServiceDTO(req, user.UserID))
+	if err != nil {
+		return goalErrorResponse(c, err, "Failed to create goal")
+	}
+
+	return c.JSON(http.StatusCreated, goal)
+}
+
+// ListGoals godoc
+// @Summary List savings goals
+// @Description Returns the savings goals of the authenticated user with their progress, the nearest deadline first. The projection assumes the user keeps saving their average net savings of the last 3 complete months
+// @Tags goals
+// @Produce json
+// @Success 200 {array} dtos.GoalProgress
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve goals"
+// @Security BearerAuth
+// @Router /goals [get]
+func (h *GoalHandler) ListGoals(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	goals, err := h.goalService.ListGoals(user.UserID)
+	if err != nil {
+		return goalErrorResponse(c, err, "Failed to retrieve goals")
+	}
+
+	return c.JSON(http.StatusOK, goals)
+}
+
+// GetGoalByID godoc
+// @Summary Get a savings goal
+// @Description Returns a savings goal of the authenticated user with its progress and whether it will be met by its deadline
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Success 200 {object} dtos.GoalProgress
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this goal"
+// @Failure 404 {object} map[string]string "Goal not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve goal"
+// @Security BearerAuth
+// @Router /goals/{id} [get]
+func (h *GoalHandler) GetGoalByID(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	goal, err := h.goalService.GetGoal(c.Param("id"), user.UserID)
+	if err != nil {
+		return goalErrorResponse(c, err, "Failed to retrieve goal")
+	}
+
+	return c.JSON(http.StatusOK, goal)
+}
+
+// UpdateGoal godoc
+// @Summary Update a savings goal
+// @Description Updates a savings goal of the authenticated user, omitted fields keep their value. The currency can't change once the goal has contributions
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Param request body dtos.GoalRequest true "Goal data"
+// @Success 200 {object} entities.Goal
+// @Failure 400 {object} map[string]string "Invalid goal"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this goal"
+// @Failure 404 {object} map[string]string "Goal not found"
+// @Failure 500 {object} map[string]string "Failed to update goal"
+// @Security BearerAuth
+// @Router /goals/{id} [put]
+func (h *GoalHandler) UpdateGoal(c echo.Context) error {
+	var req handlerdtos.GoalRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	goal, err := h.goalService.UpdateGoal(c.Param("id"), user.UserID, mappers.ToGoalServiceDTO(req, user.UserID))
+	if err != nil {
+		return goalErrorResponse(c, err, "Failed to update goal")
+	}
+
+	return c.JSON(http.StatusOK, goal)
+}
+
+// DeleteGoal godoc
+// @Summary Delete a savings goal
+// @Description Deletes a savings goal of the authenticated user along with its contributions
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Success 200 {object} map[string]string "Goal deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this goal"
+// @Failure 404 {object} map[string]string "Goal not found"
+// @Failure 500 {object} map[string]string "Failed to delete goal"
+// @Security BearerAuth
+// @Router /goals/{id} [delete]
+func (h *GoalHandler) DeleteGoal(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.goalService.DeleteGoal(c.Param("id"), user.UserID); err != nil {
+		return goalErrorResponse(c, err, "Failed to delete goal")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Goal deleted successfully",
+	})
+}
+
+// AddContribution godoc
+// @Summary Contribute to a savings goal
+// @Description Records money put towards a goal of the authenticated user in the goal's currency, a negative amount withdraws from it
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Param request body dtos.GoalContributionRequest true "Contribution data"
+// @Success 201 {object} entities.GoalContribution
+// @Failure 400 {object} map[string]string "Invalid contribution"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this goal"
+// @Failure 404 {object} map[string]string "Goal not found"
+// @Failure 500 {object} map[string]string "Failed to add contribution"
+// @Security BearerAuth
+// @Router /goals/{id}/contributions [post]
+func (h *GoalHandler) AddContribution(c echo.Context) error {
+	var req handlerdtos.GoalContributionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	contribution, err := h.goalService.AddContribution(c.Param("id"), user.UserID, mappers.ToGoalContributionServiceDTO(req))
+	if err != nil {
+		return goalErrorResponse(c, err, "Failed to add contribution")
+	}
+
+	return c.JSON(http.StatusCreated, contribution)
+}
+
+// ListContributions godoc
+// @Summary List contributions to a savings goal
+// @Description Returns the contributions to a goal of the authenticated user, the latest first
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Success 200 {array} entities.GoalContribution
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this goal"
+// @Failure 404 {object} map[string]string "Goal not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve contributions"
+// @Security BearerAuth
+// @Router /goals/{id}/contributions [get]
+func (h *GoalHandler) ListContributions(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	contributions, err := h.goalService.ListContributions(c.Param("id"), user.UserID)
+	if err != nil {
+		return goalErrorResponse(c, err, "Failed to retrieve contributions")
+	}
+
+	return c.JSON(http.StatusOK, contributions)
+}
+
+// DeleteContribution godoc
+// @Summary Delete a contribution to a savings goal
+// @Description Deletes a contribution to a goal of the authenticated user
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Param contributionId path string true "Contribution ID"
+// @Success 200 {object} map[string]string "Contribution deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this goal"
+// @Failure 404 {object} map[string]string "Goal or contribution not found"
+// @Failure 500 {object} map[string]string "Failed to delete contribution"
+// @Security BearerAuth
+// @Router /goals/{id}/contributions/{contributionId} [delete]
+func (h *GoalHandler) DeleteContribution(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.goalService.DeleteContribution(c.Param("id"), c.Param("contributionId"), user.UserID); err != nil {
+		return goalErrorResponse(c, err, "Failed to delete contribution")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Contribution deleted successfully",
+	})
+}
+
+// goalErrorResponse maps goal service errors to HTTP responses
+func goalErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidGoal), errors.Is(err, services.ErrInvalidContribution):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrUnauthorizedGoal):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this goal",
+		})
+	case errors.Is(err, services.ErrGoalNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Goal not found",
+		})
+	case errors.Is(err, services.ErrContributionNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Contribution not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
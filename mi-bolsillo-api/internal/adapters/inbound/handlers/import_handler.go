@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ImportHandler struct {
+	importService      *services.ImportService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewImportHandler(importService *services.ImportService, accountLinkService *services.AccountLinkService) *ImportHandler {
+	return &ImportHandler{
+		importService:      importService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// ImportStatement godoc
+// @Summary Import a bank statement
+// @Description Imports the charges of a bank statement as bills with source "import".
+// @Description Charges that probably match an existing bill are reported as duplicates and not saved
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Statement file (CSV or OFX)"
+// @Param format formData string false "Statement format: bcp, interbank, bbva or ofx (defaults to ofx for .ofx files)"
+// @Success 201 {object} dtos.ImportResult
+// @Failure 400 {object} map[string]string "Invalid or unsupported statement"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to import statement"
+// @Security BearerAuth
+// @Router /imports [post]
+func (h *ImportHandler) ImportStatement(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	// Get the uploaded file
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Statement file is required",
+		})
+	}
+
+	format := c.FormValue("format")
+	if format == "" && strings.EqualFold(filepath.Ext(file.Filename), ".ofx") {
+		format = "ofx"
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to open uploaded file",
+		})
+	}
+	defer src.Close()
+
+	result, err := h.importService.ImportStatement(user.UserID, format, src)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnsupportedStatementFormat):
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Unsupported format, expected one of: " + strings.Join(h.importService.Formats(), ", "),
+			})
+		case errors.Is(err, services.ErrInvalidStatement):
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to import statement",
+			})
+		}
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type IncomeHandler struct {
+	incomeService      *services.IncomeService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewIncomeHandler(incomeService *services.IncomeService, accountLinkService *services.AccountLinkService) *IncomeHandler {
+	return &IncomeHandler{
+		incomeService:      incomeService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// CreateIncome godoc
+// @Summary Record an income
+// @Description Records money the authenticated user received, like their salary, a freelance payment or a refund
+// @Tags incomes
+// @Accept json
+// @Produce json
+// @Param request body dtos.IncomeRequest true "Income data"
+// @Success 201 {object} entities.Income
+// @Failure 400 {object} map[string]string "Invalid income"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to create income"
+// @Security BearerAuth
+// @Router /incomes [post]
+func (h *IncomeHandler) CreateIncome(c echo.Context) error {
+	var req handlerdtos.IncomeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	income, err := h.incomeService.CreateIncome(mappers.ToIncomeServiceDTO(req, user.UserID, "web"))
+	if err != nil {
+		return incomeErrorResponse(c, err, "Failed to create income")
+	}
+
+	return c.JSON(http.StatusCreated, income)
+}
+
+// ListIncomes godoc
+// @Summary List incomes
+// @Description Returns the incomes of the authenticated user, the latest first
+// @Tags incomes
+// @Produce json
+// @Param from query string false "Earliest date, YYYY-MM-DD"
+// @Param to query string false "Latest date, YYYY-MM-DD"
+// @Success 200 {array} entities.Income
+// @Failure 400 {object} map[string]string "Invalid date"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve incomes"
+// @Security BearerAuth
+// @Router /incomes [get]
+func (h *IncomeHandler) ListIncomes(c echo.Context) error {
+	from, to, err := mappers.ToDateRange(c.QueryParams())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	incomes, err := h.incomeService.ListIncomes(user.UserID, from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve incomes",
+		})
+	}
+
+	return c.JSON(http.StatusOK, incomes)
+}
+
+// GetIncomeByID godoc
+// @Summary Get an income
+// @Description Returns an income of the authenticated user
+// @Tags incomes
+// @Produce json
+// @Param id path string true "Income ID"
+// @Success 200 {object} entities.Income
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this income"
+// @Failure 404 {object} map[string]string "Income not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve income"
+// @Security BearerAuth
+// @Router /incomes/{id} [get]
+func (h *IncomeHandler) GetIncomeByID(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	income, err := h.incomeService.GetIncome(c.Param("id"), user.UserID)
+	if err != nil {
+		return incomeErrorResponse(c, err, "Failed to retrieve income")
+	}
+
+	return c.JSON(http.StatusOK, income)
+}
+
+// UpdateIncome godoc
+// @Summary Update an income
+// @Description Updates an income of the authenticated user, omitted fields keep their value. Changing the amount, currency, date or exchange rate reconverts it
+// @Tags incomes
+// @Accept json
+// @Produce json
+// @Param id path string true "Income ID"
+// @Param request body dtos.IncomeRequest true "Income data"
+// @Success 200 {object} entities.Income
+// @Failure 400 {object} map[string]string "Invalid income"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this income"
+// @Failure 404 {object} map[string]string "Income not found"
+// @Failure 500 {object} map[string]string "Failed to update income"
+// @Security BearerAuth
+// @Router /incomes/{id} [put]
+func (h *IncomeHandler) UpdateIncome(c echo.Context) error {
+	var req handlerdtos.IncomeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	income, err := h.incomeService.UpdateIncome(c.Param("id"), user.UserID, mappers.ToIncomeServiceDTO(req, user.UserID, ""))
+	if err != nil {
+		return incomeErrorResponse(c, err, "Failed to update income")
+	}
+
+	return c.JSON(http.StatusOK, income)
+}
+
+// DeleteIncome godoc
+// @Summary Delete an income
+// @Description Deletes an income of the authenticated user
+// @Tags incomes
+// @Produce json
+// @Param id path string true "Income ID"
+// @Success 200 {object} map[string]string "Income deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this income"
+// @Failure 404 {object} map[string]string "Income not found"
+// @Failure 500 {object} map[string]string "Failed to delete income"
+// @Security BearerAuth
+// @Router /incomes/{id} [delete]
+func (h *IncomeHandler) DeleteIncome(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.incomeService.DeleteIncome(c.Param("id"), user.UserID); err != nil {
+		return incomeErrorResponse(c, err, "Failed to delete income")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Income deleted successfully",
+	})
+}
+
+// incomeErrorResponse maps income service errors to HTTP responses
+func incomeErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidIncome):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrUnauthorizedIncome):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this income",
+		})
+	case errors.Is(err, services.ErrIncomeNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Income not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
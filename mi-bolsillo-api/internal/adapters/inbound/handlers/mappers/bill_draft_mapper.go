@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	domainentities "github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+// ToCreateBillDraftServiceDTO maps a parsed receipt to a draft awaiting the user's review
+func ToCreateBillDraftServiceDTO(parsedData *domainentities.ParsedBill, userID string, source string, imageData []byte) servicedtos.CreateBillDraftDTO {
+	items := make([]entities.BillDraftItem, len(parsedData.Items))
+	for i, item := range parsedData.Items {
+		items[i] = entities.BillDraftItem{
+			Description: item.Description,
+			Amount:      item.Amount,
+			Category:    item.Category,
+		}
+	}
+
+	return servicedtos.CreateBillDraftDTO{
+		UserID:        userID,
+		Source:        source,
+		Description:   parsedData.MerchantName,
+		Currency:      parsedData.Currency,
+		Date:          parsedData.Date,
+		TotalAmount:   parsedData.TotalAmount,
+		Items:         items,
+		SupplierRUC:   parsedData.SupplierRUC,
+		InvoiceNumber: parsedData.InvoiceNumber,
+		Subtotal:      parsedData.Subtotal,
+		TaxAmount:     parsedData.TaxAmount,
+		TipAmount:     parsedData.TipAmount,
+		ReceiptImage:  imageData,
+		CardLastFour:  parsedData.CardLastFour,
+	}
+}
+
+func ToUpdateBillDraftServiceDTO(handlerDTO handlerdtos.UpdateBillDraftRequest) servicedtos.UpdateBillDraftDTO {
+	dto := servicedtos.UpdateBillDraftDTO{
+		Description:     handlerDTO.Description,
+		Currency:        handlerDTO.Currency,
+		Date:            handlerDTO.Date,
+		TotalAmount:     handlerDTO.TotalAmount,
+		PaymentMethodID: handlerDTO.PaymentMethodID,
+	}
+
+	if handlerDTO.Items != nil {
+		items := make([]entities.BillDraftItem, len(*handlerDTO.Items))
+		for i, item := range *handlerDTO.Items {
+			items[i] = entities.BillDraftItem{
+				Description: item.Description,
+				Amount:      item.Amount,
+				Category:    item.Category,
+			}
+		}
+		dto.Items = &items
+	}
+
+	return dto
+}
@@ -0,0 +1,72 @@
+package mappers
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+// ToBillSearchServiceDTO parses the GET /bills query parameters
+func ToBillSearchServiceDTO(query url.Values) (servicedtos.BillSearchDTO, error) {
+	dto := servicedtos.BillSearchDTO{
+		WalletID:        query.Get("walletId"),
+		Category:        query.Get("category"),
+		Currency:        query.Get("currency"),
+		Source:          query.Get("source"),
+		Description:     query.Get("q"),
+		SortBy:          query.Get("sort"),
+		Cursor:          query.Get("cursor"),
+		PaymentMethodID: query.Get("paymentMethodId"),
+	}
+
+	if value := query.Get("from"); value != "" {
+		from, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return dto, errors.New("Invalid from date, expected YYYY-MM-DD")
+		}
+		dto.From = &from
+	}
+	if value := query.Get("to"); value != "" {
+		to, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return dto, errors.New("Invalid to date, expected YYYY-MM-DD")
+		}
+		// The to date is inclusive, so search up to the start of the next day
+		to = to.AddDate(0, 0, 1)
+		dto.To = &to
+	}
+	if value := query.Get("minAmount"); value != "" {
+		minAmount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return dto, errors.New("Invalid minAmount")
+		}
+		dto.MinAmount = &minAmount
+	}
+	if value := query.Get("maxAmount"); value != "" {
+		maxAmount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return dto, errors.New("Invalid maxAmount")
+		}
+		dto.MaxAmount = &maxAmount
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return dto, errors.New("Invalid limit")
+		}
+		dto.Limit = limit
+	}
+
+	switch query.Get("order") {
+	case "", "desc":
+	case "asc":
+		dto.Ascending = true
+	default:
+		return dto, errors.New("Invalid order, expected asc or desc")
+	}
+
+	return dto, nil
+}
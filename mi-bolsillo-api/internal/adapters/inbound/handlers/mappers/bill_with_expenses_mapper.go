@@ -17,13 +17,54 @@ func ToCreateBillWithExpensesServiceDTO(handlerDTO handlerdtos.CreateBillWithExp
 	}
 
 	return servicedtos.CreateBillWithExpensesDTO{
-		Description:  handlerDTO.Description,
-		Category:     handlerDTO.Category,
-		UserID:       handlerDTO.UserID,
-		Source:       handlerDTO.Source,
-		Date:         handlerDTO.Date,
-		Currency:     handlerDTO.Currency,
-		ExchangeRate: handlerDTO.ExchangeRate,
-		Expenses:     serviceExpenses,
+		Description:     handlerDTO.Description,
+		Category:        handlerDTO.Category,
+		UserID:          handlerDTO.UserID,
+		WalletID:        handlerDTO.WalletID,
+		Source:          handlerDTO.Source,
+		Date:            handlerDTO.Date,
+		Currency:        handlerDTO.Currency,
+		ExchangeRate:    handlerDTO.ExchangeRate,
+		Expenses:        serviceExpenses,
+		SupplierRUC:     handlerDTO.SupplierRUC,
+		InvoiceNumber:   handlerDTO.InvoiceNumber,
+		Subtotal:        handlerDTO.Subtotal,
+		TaxAmount:       handlerDTO.TaxAmount,
+		TipAmount:       handlerDTO.TipAmount,
+		ReceiptImage:    handlerDTO.ReceiptImage,
+		PaymentMethodID: handlerDTO.PaymentMethodID,
+	}
+}
+
+func ToUpdateBillServiceDTO(handlerDTO handlerdtos.UpdateBillRequest) servicedtos.UpdateBillDTO {
+	return servicedtos.UpdateBillDTO{
+		Description:     handlerDTO.Description,
+		Category:        handlerDTO.Category,
+		Date:            handlerDTO.Date,
+		Currency:        handlerDTO.Currency,
+		ExchangeRate:    handlerDTO.ExchangeRate,
+		SupplierRUC:     handlerDTO.SupplierRUC,
+		Subtotal:        handlerDTO.Subtotal,
+		TaxAmount:       handlerDTO.TaxAmount,
+		TipAmount:       handlerDTO.TipAmount,
+		PaymentMethodID: handlerDTO.PaymentMethodID,
+	}
+}
+
+func ToCreateExpenseForBillServiceDTO(handlerDTO handlerdtos.CreateExpenseForBill) servicedtos.CreateExpenseForBill {
+	return servicedtos.CreateExpenseForBill{
+		Amount:      handlerDTO.Amount,
+		Description: handlerDTO.Description,
+		Category:    handlerDTO.Category,
+		Date:        handlerDTO.Date,
+	}
+}
+
+func ToUpdateExpenseServiceDTO(handlerDTO handlerdtos.UpdateExpenseRequest) servicedtos.UpdateExpenseDTO {
+	return servicedtos.UpdateExpenseDTO{
+		Amount:      handlerDTO.Amount,
+		Description: handlerDTO.Description,
+		Category:    handlerDTO.Category,
+		Date:        handlerDTO.Date,
 	}
 }
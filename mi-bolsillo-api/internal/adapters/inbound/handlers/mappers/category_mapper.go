@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+func ToCreateCategoryServiceDTO(handlerDTO handlerdtos.CreateCategoryRequest) servicedtos.CreateCategoryDTO {
+	return servicedtos.CreateCategoryDTO{
+		ParentID: handlerDTO.ParentID,
+		Name:     handlerDTO.Name,
+		Icon:     handlerDTO.Icon,
+		Color:    handlerDTO.Color,
+	}
+}
+
+func ToUpdateCategoryServiceDTO(handlerDTO handlerdtos.UpdateCategoryRequest) servicedtos.UpdateCategoryDTO {
+	return servicedtos.UpdateCategoryDTO{
+		ParentID: handlerDTO.ParentID,
+		Name:     handlerDTO.Name,
+		Icon:     handlerDTO.Icon,
+		Color:    handlerDTO.Color,
+	}
+}
+
+func ToCategoryRuleServiceDTO(handlerDTO handlerdtos.CategoryRuleRequest) servicedtos.CategoryRuleDTO {
+	return servicedtos.CategoryRuleDTO{
+		Field:    handlerDTO.Field,
+		Operator: handlerDTO.Operator,
+		Pattern:  handlerDTO.Pattern,
+		Category: handlerDTO.Category,
+		Priority: handlerDTO.Priority,
+	}
+}
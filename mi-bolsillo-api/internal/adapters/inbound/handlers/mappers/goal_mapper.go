@@ -0,0 +1,24 @@
+package mappers
+
+import (
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+func ToGoalServiceDTO(handlerDTO handlerdtos.GoalRequest, userID string) servicedtos.GoalDTO {
+	return servicedtos.GoalDTO{
+		UserID:       userID,
+		Name:         handlerDTO.Name,
+		TargetAmount: handlerDTO.TargetAmount,
+		Currency:     handlerDTO.Currency,
+		Deadline:     handlerDTO.Deadline,
+	}
+}
+
+func ToGoalContributionServiceDTO(handlerDTO handlerdtos.GoalContributionRequest) servicedtos.GoalContributionDTO {
+	return servicedtos.GoalContributionDTO{
+		Amount: handlerDTO.Amount,
+		Note:   handlerDTO.Note,
+		Date:   handlerDTO.Date,
+	}
+}
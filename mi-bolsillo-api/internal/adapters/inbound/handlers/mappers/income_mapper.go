@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+func ToIncomeServiceDTO(handlerDTO handlerdtos.IncomeRequest, userID string, source string) servicedtos.IncomeDTO {
+	return servicedtos.IncomeDTO{
+		UserID:       userID,
+		Source:       source,
+		Type:         handlerDTO.Type,
+		Description:  handlerDTO.Description,
+		Amount:       handlerDTO.Amount,
+		Currency:     handlerDTO.Currency,
+		Date:         handlerDTO.Date,
+		ExchangeRate: handlerDTO.ExchangeRate,
+	}
+}
+
+// ToDateRange parses the from and to query parameters of a listing, both inclusive YYYY-MM-DD dates
+func ToDateRange(query url.Values) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if value := query.Get("from"); value != "" {
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return nil, nil, errors.New("Invalid from date, expected YYYY-MM-DD")
+		}
+		from = &date
+	}
+	if value := query.Get("to"); value != "" {
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return nil, nil, errors.New("Invalid to date, expected YYYY-MM-DD")
+		}
+		// The to date is inclusive, so search up to the start of the next day
+		date = date.AddDate(0, 0, 1)
+		to = &date
+	}
+	return from, to, nil
+}
@@ -0,0 +1,18 @@
+package mappers
+
+import (
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+func ToPaymentMethodServiceDTO(handlerDTO handlerdtos.PaymentMethodRequest, userID string) servicedtos.PaymentMethodDTO {
+	return servicedtos.PaymentMethodDTO{
+		UserID:              userID,
+		Name:                handlerDTO.Name,
+		Type:                handlerDTO.Type,
+		Currency:            handlerDTO.Currency,
+		LastFour:            handlerDTO.LastFour,
+		CreditLimit:         handlerDTO.CreditLimit,
+		StatementClosingDay: handlerDTO.StatementClosingDay,
+	}
+}
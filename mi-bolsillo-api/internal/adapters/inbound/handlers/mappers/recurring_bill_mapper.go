@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+func ToRecurringBillServiceDTO(handlerDTO handlerdtos.RecurringBillRequest) servicedtos.RecurringBillDTO {
+	return servicedtos.RecurringBillDTO{
+		Description: handlerDTO.Description,
+		Category:    handlerDTO.Category,
+		Amount:      handlerDTO.Amount,
+		Currency:    handlerDTO.Currency,
+		Frequency:   handlerDTO.Frequency,
+		Interval:    handlerDTO.Interval,
+		DayOfMonth:  handlerDTO.DayOfMonth,
+		DayOfWeek:   handlerDTO.DayOfWeek,
+		MonthOfYear: handlerDTO.MonthOfYear,
+		StartDate:   handlerDTO.StartDate,
+		EndDate:     handlerDTO.EndDate,
+		Active:      handlerDTO.Active,
+	}
+}
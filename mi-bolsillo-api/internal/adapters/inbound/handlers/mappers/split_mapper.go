@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+func ToSplitBillServiceDTO(handlerDTO handlerdtos.SplitBillRequest) servicedtos.SplitBillDTO {
+	participants := make([]servicedtos.SplitParticipantDTO, len(handlerDTO.Participants))
+	for i, participant := range handlerDTO.Participants {
+		participants[i] = servicedtos.SplitParticipantDTO{
+			Name:       participant.Name,
+			ExpenseIDs: participant.ExpenseIDs,
+			Percentage: participant.Percentage,
+		}
+	}
+
+	return servicedtos.SplitBillDTO{
+		PaidBy:       handlerDTO.PaidBy,
+		Participants: participants,
+	}
+}
+
+func ToCreateSettlementServiceDTO(handlerDTO handlerdtos.CreateSettlementRequest) servicedtos.CreateSettlementDTO {
+	return servicedtos.CreateSettlementDTO{
+		WalletID: handlerDTO.WalletID,
+		From:     handlerDTO.From,
+		To:       handlerDTO.To,
+		Amount:   handlerDTO.Amount,
+		Currency: handlerDTO.Currency,
+		Note:     handlerDTO.Note,
+		Date:     handlerDTO.Date,
+	}
+}
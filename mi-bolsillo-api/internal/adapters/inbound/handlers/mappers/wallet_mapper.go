@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"fmt"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// ToWalletInviteResponse adds the t.me links of the bot to an invite, none without a bot username
+func ToWalletInviteResponse(invite *entities.WalletInvite, botUsername string) handlerdtos.WalletInviteResponse {
+	response := handlerdtos.WalletInviteResponse{
+		Code:      invite.Code,
+		WalletID:  invite.WalletID,
+		Role:      invite.Role,
+		ExpiresAt: invite.ExpiresAt,
+	}
+	if botUsername != "" {
+		response.TelegramLink = fmt.Sprintf("https://t.me/%s?start=%s", botUsername, invite.Code)
+		response.TelegramGroupLink = fmt.Sprintf("https://t.me/%s?startgroup=%s", botUsername, invite.Code)
+	}
+	return response
+}
@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type PaymentMethodHandler struct {
+	paymentMethodService *services.PaymentMethodService
+	accountLinkService   *services.AccountLinkService
+}
+
+func NewPaymentMethodHandler(paymentMethodService *services.PaymentMethodService, accountLinkService *services.AccountLinkService) *PaymentMethodHandler {
+	return &PaymentMethodHandler{
+		paymentMethodService: paymentMethodService,
+		accountLinkService:   accountLinkService,
+	}
+}
+
+// CreatePaymentMethod godoc
+// @Summary Add a payment method
+// @Description Adds an account the authenticated user pays bills with, like a credit card, Yape, Plin or cash
+// @Tags payment-methods
+// @Accept json
+// @Produce json
+// @Param request body dtos.PaymentMethodRequest true "Payment method data"
+// @Success 201 {object} entities.PaymentMethod
+// @Failure 400 {object} map[string]string "Invalid payment method"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to create payment method"
+// @Security BearerAuth
+// @Router /payment-methods [post]
+func (h *PaymentMethodHandler) CreatePaymentMethod(c echo.Context) error {
+	var req handlerdtos.PaymentMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	paymentMethod, err := h.paymentMethodService.CreatePaymentMethod(mappers.ToPaymentMethodServiceDTO(req, user.UserID))
+	if err != nil {
+		return paymentMethodErrorResponse(c, err, "Failed to create payment method")
+	}
+
+	return c.JSON(http.StatusCreated, paymentMethod)
+}
+
+// ListPaymentMethods godoc
+// @Summary List payment methods
+// @Description Returns the payment methods of the authenticated user by name
+// @Tags payment-methods
+// @Produce json
+// @Success 200 {array} entities.PaymentMethod
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve payment methods"
+// @Security BearerAuth
+// @Router /payment-methods [get]
+func (h *PaymentMethodHandler) ListPaymentMethods(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	paymentMethods, err := h.paymentMethodService.ListPaymentMethods(user.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve payment methods",
+		})
+	}
+
+	return c.JSON(http.StatusOK, paymentMethods)
+}
+
+// GetPaymentMethodByID godoc
+// @Summary Get a payment method
+// @Description Returns a payment method of the authenticated user
+// @Tags payment-methods
+// @Produce json
+// @Param id path string true "Payment method ID"
+// @Success 200 {object} entities.PaymentMethod
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this payment method"
+// @Failure 404 {object} map[string]string "Payment method not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve payment method"
+// @Security BearerAuth
+// @Router /payment-methods/{id} [get]
+func (h *PaymentMethodHandler) GetPaymentMethodByID(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	paymentMethod, err := h.paymentMethodService.GetPaymentMethod(c.Param("id"), user.UserID)
+	if err != nil {
+		return paymentMethodErrorResponse(c, err, "Failed to retrieve payment method")
+	}
+
+	return c.JSON(http.StatusOK, paymentMethod)
+}
+
+// UpdatePaymentMethod godoc
+// @Summary Update a payment method
+// @Description Updates a payment method of the authenticated user, omitted fields keep their value
+// @Tags payment-methods
+// @Accept json
+// @Produce json
+// @Param id path string true "Payment method ID"
+// @Param request body dtos.PaymentMethodRequest true "Payment method data"
+// @Success 200 {object} entities.PaymentMethod
+// @Failure 400 {object} map[string]string "Invalid payment method"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this payment method"
+// @Failure 404 {object} map[string]string "Payment method not found"
+// @Failure 500 {object} map[string]string "Failed to update payment method"
+// @Security BearerAuth
+// @Router /payment-methods/{id} [put]
+func (h *PaymentMethodHandler) UpdatePaymentMethod(c echo.Context) error {
+	var req handlerdtos.PaymentMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	paymentMethod, err := h.paymentMethodService.UpdatePaymentMethod(c.Param("id"), user.UserID, mappers.ToPaymentMethodServiceDTO(req, user.UserID))
+	if err != nil {
+		return paymentMethodErrorResponse(c, err, "Failed to update payment method")
+	}
+
+	return c.JSON(http.StatusOK, paymentMethod)
+}
+
+// DeletePaymentMethod godoc
+// @Summary Delete a payment method
+// @Description Deletes a payment method of the authenticated user, the bills paid with it are kept without one
+// @Tags payment-methods
+// @Produce json
+// @Param id path string true "Payment method ID"
+// @Success 200 {object} map[string]string "Payment method deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this payment method"
+// @Failure 404 {object} map[string]string "Payment method not found"
+// @Failure 500 {object} map[string]string "Failed to delete payment method"
+// @Security BearerAuth
+// @Router /payment-methods/{id} [delete]
+func (h *PaymentMethodHandler) DeletePaymentMethod(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.paymentMethodService.DeletePaymentMethod(c.Param("id"), user.UserID); err != nil {
+		return paymentMethodErrorResponse(c, err, "Failed to delete payment method")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Payment method deleted successfully",
+	})
+}
+
+// GetPaymentMethodStatements godoc
+// @Summary Get credit card statements
+// @Description Returns the totals of a credit card's last billing cycles, the open one first. Cycles end on the card's statement closing day
+// @Tags payment-methods
+// @Produce json
+// @Param id path string true "Payment method ID"
+// @Param count query int false "Number of billing cycles to include" default(6)
+// @Success 200 {array} dtos.StatementCycle
+// @Failure 400 {object} map[string]string "Payment method is not a credit card with a statement closing day"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this payment method"
+// @Failure 404 {object} map[string]string "Payment method not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve statements"
+// @Security BearerAuth
+// @Router /payment-methods/{id}/statements [get]
+func (h *PaymentMethodHandler) GetPaymentMethodStatements(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	count := 0
+	if countParam := c.QueryParam("count"); countParam != "" {
+		if parsed, err := strconv.Atoi(countParam); err == nil && parsed > 0 {
+			count = parsed
+		}
+	}
+
+	statements, err := h.paymentMethodService.GetStatements(c.Param("id"), user.UserID, count)
+	if err != nil {
+		return paymentMethodErrorResponse(c, err, "Failed to retrieve statements")
+	}
+
+	return c.JSON(http.StatusOK, statements)
+}
+
+// GetPaymentMethodStatistics godoc
+// @Summary Get spending per payment method
+// @Description Returns what the authenticated user paid with each payment method, with the open statement and available credit of their credit cards
+// @Tags statistics
+// @Produce json
+// @Param from query string false "Earliest date, YYYY-MM-DD"
+// @Param to query string false "Latest date, YYYY-MM-DD"
+// @Success 200 {array} dtos.PaymentMethodStatistics
+// @Failure 400 {object} map[string]string "Invalid date"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve payment method statistics"
+// @Security BearerAuth
+// @Router /statistics/payment-methods [get]
+func (h *PaymentMethodHandler) GetPaymentMethodStatistics(c echo.Context) error {
+	from, to, err := mappers.ToDateRange(c.QueryParams())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	stats, err := h.paymentMethodService.GetStatistics(user.UserID, from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve payment method statistics",
+		})
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// paymentMethodErrorResponse maps payment method service errors to HTTP responses
+func paymentMethodErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidPaymentMethod):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrNoStatements):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Payment method is not a credit card with a statement closing day",
+		})
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrUnauthorizedPaymentMethod):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this payment method",
+		})
+	case errors.Is(err, services.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Payment method not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
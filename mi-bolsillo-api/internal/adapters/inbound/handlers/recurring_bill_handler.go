@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type RecurringBillHandler struct {
+	recurringBillService *services.RecurringBillService
+	accountLinkService   *services.AccountLinkService
+}
+
+func NewRecurringBillHandler(recurringBillService *services.RecurringBillService, accountLinkService *services.AccountLinkService) *RecurringBillHandler {
+	return &RecurringBillHandler{
+		recurringBillService: recurringBillService,
+		accountLinkService:   accountLinkService,
+	}
+}
+
+// CreateRecurringBill godoc
+// @Summary Create a recurring bill
+// @Description Creates a bill that is posted automatically on a weekly, monthly or yearly schedule
+// @Tags recurring-bills
+// @Accept json
+// @Produce json
+// @Param request body dtos.RecurringBillRequest true "Recurring bill data"
+// @Success 201 {object} entities.RecurringBill
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to create recurring bill"
+// @Security BearerAuth
+// @Router /recurring-bills [post]
+func (h *RecurringBillHandler) CreateRecurringBill(c echo.Context) error {
+	var req handlerdtos.RecurringBillRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	recurringBill, err := h.recurringBillService.CreateRecurringBill(user.UserID, mappers.ToRecurringBillServiceDTO(req))
+	if err != nil {
+		return recurringBillErrorResponse(c, err, "Failed to create recurring bill")
+	}
+
+	return c.JSON(http.StatusCreated, recurringBill)
+}
+
+// ListRecurringBills godoc
+// @Summary List recurring bills
+// @Description Returns every recurring bill of the authenticated user ordered by next occurrence
+// @Tags recurring-bills
+// @Produce json
+// @Success 200 {array} entities.RecurringBill
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve recurring bills"
+// @Security BearerAuth
+// @Router /recurring-bills [get]
+func (h *RecurringBillHandler) ListRecurringBills(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	recurringBills, err := h.recurringBillService.ListRecurringBills(user.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve recurring bills",
+		})
+	}
+
+	return c.JSON(http.StatusOK, recurringBills)
+}
+
+// GetRecurringBillByID godoc
+// @Summary Get a recurring bill
+// @Description Returns a recurring bill owned by the authenticated user
+// @Tags recurring-bills
+// @Produce json
+// @Param id path string true "Recurring bill ID"
+// @Success 200 {object} entities.RecurringBill
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this recurring bill"
+// @Failure 404 {object} map[string]string "Recurring bill not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve recurring bill"
+// @Security BearerAuth
+// @Router /recurring-bills/{id} [get]
+func (h *RecurringBillHandler) GetRecurringBillByID(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	recurringBill, err := h.recurringBillService.GetRecurringBill(c.Param("id"), user.UserID)
+	if err != nil {
+		return recurringBillErrorResponse(c, err, "Failed to retrieve recurring bill")
+	}
+
+	return c.JSON(http.StatusOK, recurringBill)
+}
+
+// UpdateRecurringBill godoc
+// @Summary Update a recurring bill
+// @Description Replaces the schedule and amount of a recurring bill, bills already posted are kept
+// @Tags recurring-bills
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring bill ID"
+// @Param request body dtos.RecurringBillRequest true "Recurring bill data"
+// @Success 200 {object} entities.RecurringBill
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this recurring bill"
+// @Failure 404 {object} map[string]string "Recurring bill not found"
+// @Failure 500 {object} map[string]string "Failed to update recurring bill"
+// @Security BearerAuth
+// @Router /recurring-bills/{id} [put]
+func (h *RecurringBillHandler) UpdateRecurringBill(c echo.Context) error {
+	var req handlerdtos.RecurringBillRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	recurringBill, err := h.recurringBillService.UpdateRecurringBill(c.Param("id"), user.UserID, mappers.ToRecurringBillServiceDTO(req))
+	if err != nil {
+		return recurringBillErrorResponse(c, err, "Failed to update recurring bill")
+	}
+
+	return c.JSON(http.StatusOK, recurringBill)
+}
+
+// DeleteRecurringBill godoc
+// @Summary Delete a recurring bill
+// @Description Stops and deletes a recurring bill, bills already posted are kept
+// @Tags recurring-bills
+// @Produce json
+// @Param id path string true "Recurring bill ID"
+// @Success 200 {object} map[string]string "Recurring bill deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this recurring bill"
+// @Failure 404 {object} map[string]string "Recurring bill not found"
+// @Failure 500 {object} map[string]string "Failed to delete recurring bill"
+// @Security BearerAuth
+// @Router /recurring-bills/{id} [delete]
+func (h *RecurringBillHandler) DeleteRecurringBill(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.recurringBillService.DeleteRecurringBill(c.Param("id"), user.UserID); err != nil {
+		return recurringBillErrorResponse(c, err, "Failed to delete recurring bill")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Recurring bill deleted successfully",
+	})
+}
+
+// recurringBillErrorResponse maps recurring bill service errors to HTTP responses
+func recurringBillErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidRecurringBill):
+		// The wrapped message explains which field is invalid
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": strings.TrimPrefix(err.Error(), services.ErrInvalidRecurringBill.Error()+": "),
+		})
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrUnauthorizedRecurringBill):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this recurring bill",
+		})
+	case errors.Is(err, services.ErrRecurringBillNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Recurring bill not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
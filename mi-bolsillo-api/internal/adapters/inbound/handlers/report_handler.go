@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportHandler struct {
+	taxReportService   *services.TaxReportService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewReportHandler(taxReportService *services.TaxReportService, accountLinkService *services.AccountLinkService) *ReportHandler {
+	return &ReportHandler{
+		taxReportService:   taxReportService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// GetDeductibleReport godoc
+// @Summary Get the deductible expenses report
+// @Description Adds up the year's spending that qualifies for SUNAT's additional deduction of up to 3 UIT:
+// @Description 15% of restaurants, bars and hotels and 30% of rent, counting only bills with the issuer's RUC. Amounts are in PEN
+// @Tags reports
+// @Produce json
+// @Param year query int false "Tax year (default current year)"
+// @Success 200 {object} dtos.DeductibleReport
+// @Failure 400 {object} map[string]string "Invalid year"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to build the report"
+// @Security BearerAuth
+// @Router /reports/deductible [get]
+func (h *ReportHandler) GetDeductibleReport(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	year := time.Now().Year()
+	if yearParam := c.QueryParam("year"); yearParam != "" {
+		year, err = strconv.Atoi(yearParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid year",
+			})
+		}
+	}
+
+	report, err := h.taxReportService.GetDeductibleReport(user.UserID, year)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidReportYear) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid year",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to build deductible report",
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
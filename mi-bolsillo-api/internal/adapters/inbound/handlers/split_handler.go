@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type SplitHandler struct {
+	splitService       *services.SplitService
+	accountLinkService *services.AccountLinkService
+}
+
+func NewSplitHandler(splitService *services.SplitService, accountLinkService *services.AccountLinkService) *SplitHandler {
+	return &SplitHandler{
+		splitService:       splitService,
+		accountLinkService: accountLinkService,
+	}
+}
+
+// SplitBill godoc
+// @Summary Split a bill between participants
+// @Description Assigns the lines of a bill, or percentages of it, to the people who shared it and records who paid. Replaces the bill's previous split
+// @Tags splits
+// @Accept json
+// @Produce json
+// @Param id path string true "Bill ID"
+// @Param request body dtos.SplitBillRequest true "Split data"
+// @Success 200 {object} entities.BillSplit
+// @Failure 400 {object} map[string]string "Invalid split"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill not found"
+// @Failure 500 {object} map[string]string "Failed to split bill"
+// @Security BearerAuth
+// @Router /bills/{id}/split [put]
+func (h *SplitHandler) SplitBill(c echo.Context) error {
+	var req handlerdtos.SplitBillRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	split, err := h.splitService.SplitBill(c.Param("id"), user.UserID, mappers.ToSplitBillServiceDTO(req))
+	if err != nil {
+		return splitErrorResponse(c, err, "Failed to split bill")
+	}
+
+	return c.JSON(http.StatusOK, split)
+}
+
+// GetBillSplit godoc
+// @Summary Get a bill's split
+// @Description Returns who paid a bill and what each participant owes of it
+// @Tags splits
+// @Produce json
+// @Param id path string true "Bill ID"
+// @Success 200 {object} entities.BillSplit
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill or split not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve bill split"
+// @Security BearerAuth
+// @Router /bills/{id}/split [get]
+func (h *SplitHandler) GetBillSplit(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	split, err := h.splitService.GetBillSplit(c.Param("id"), user.UserID)
+	if err != nil {
+		return splitErrorResponse(c, err, "Failed to retrieve bill split")
+	}
+
+	return c.JSON(http.StatusOK, split)
+}
+
+// DeleteBillSplit godoc
+// @Summary Remove a bill's split
+// @Description Removes the split of a bill, its shares no longer count in the balances
+// @Tags splits
+// @Produce json
+// @Param id path string true "Bill ID"
+// @Success 200 {object} map[string]string "Bill split deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this bill"
+// @Failure 404 {object} map[string]string "Bill or split not found"
+// @Failure 500 {object} map[string]string "Failed to delete bill split"
+// @Security BearerAuth
+// @Router /bills/{id}/split [delete]
+func (h *SplitHandler) DeleteBillSplit(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.splitService.DeleteBillSplit(c.Param("id"), user.UserID); err != nil {
+		return splitErrorResponse(c, err, "Failed to delete bill split")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Bill split deleted successfully",
+	})
+}
+
+// GetBalances godoc
+// @Summary Get who owes whom
+// @Description Returns each participant's balance per currency from split bills and settlements, with the fewest payments that settle them. Positive balances are owed to the participant
+// @Tags splits
+// @Produce json
+// @Param walletId query string false "Shared wallet whose ledger to use instead of the personal one"
+// @Success 200 {array} dtos.LedgerBalances
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve balances"
+// @Security BearerAuth
+// @Router /balances [get]
+func (h *SplitHandler) GetBalances(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	balances, err := h.splitService.GetBalances(user.UserID, c.QueryParam("walletId"))
+	if err != nil {
+		return splitErrorResponse(c, err, "Failed to retrieve balances")
+	}
+
+	return c.JSON(http.StatusOK, balances)
+}
+
+// CreateSettlement godoc
+// @Summary Record a settlement
+// @Description Records a payment from one participant to another that pays back what they owe
+// @Tags splits
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateSettlementRequest true "Settlement data"
+// @Success 201 {object} entities.Settlement
+// @Failure 400 {object} map[string]string "Invalid settlement"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to record settlement"
+// @Security BearerAuth
+// @Router /settlements [post]
+func (h *SplitHandler) CreateSettlement(c echo.Context) error {
+	var req handlerdtos.CreateSettlementRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	settlement, err := h.splitService.RecordSettlement(user, mappers.ToCreateSettlementServiceDTO(req))
+	if err != nil {
+		return splitErrorResponse(c, err, "Failed to record settlement")
+	}
+
+	return c.JSON(http.StatusCreated, settlement)
+}
+
+// ListSettlements godoc
+// @Summary List settlements
+// @Description Returns the settlements of the personal ledger or a shared wallet's, the latest first
+// @Tags splits
+// @Produce json
+// @Param walletId query string false "Shared wallet whose ledger to use instead of the personal one"
+// @Success 200 {array} entities.Settlement
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve settlements"
+// @Security BearerAuth
+// @Router /settlements [get]
+func (h *SplitHandler) ListSettlements(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	settlements, err := h.splitService.ListSettlements(user.UserID, c.QueryParam("walletId"))
+	if err != nil {
+		return splitErrorResponse(c, err, "Failed to retrieve settlements")
+	}
+
+	return c.JSON(http.StatusOK, settlements)
+}
+
+// DeleteSettlement godoc
+// @Summary Delete a settlement
+// @Description Deletes a settlement, the debt it paid is owed again
+// @Tags splits
+// @Produce json
+// @Param id path string true "Settlement ID"
+// @Success 200 {object} map[string]string "Settlement deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this settlement"
+// @Failure 404 {object} map[string]string "Settlement not found"
+// @Failure 500 {object} map[string]string "Failed to delete settlement"
+// @Security BearerAuth
+// @Router /settlements/{id} [delete]
+func (h *SplitHandler) DeleteSettlement(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.splitService.DeleteSettlement(c.Param("id"), user.UserID); err != nil {
+		return splitErrorResponse(c, err, "Failed to delete settlement")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Settlement deleted successfully",
+	})
+}
+
+// splitErrorResponse maps bill split and settlement service errors to HTTP responses
+func splitErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidSplit), errors.Is(err, services.ErrInvalidSettlement):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported currency",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this bill",
+		})
+	case errors.Is(err, services.ErrBillNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Bill not found",
+		})
+	case errors.Is(err, services.ErrSplitNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Bill split not found",
+		})
+	case errors.Is(err, services.ErrUnauthorizedSettlement):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this settlement",
+		})
+	case errors.Is(err, services.ErrSettlementNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Settlement not found",
+		})
+	case errors.Is(err, services.ErrUnauthorizedWallet):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this wallet",
+		})
+	case errors.Is(err, services.ErrWalletNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Wallet not found",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
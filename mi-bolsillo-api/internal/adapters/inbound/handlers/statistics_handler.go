@@ -22,7 +22,7 @@ func NewStatisticsHandler(statisticsService *services.StatisticsService, account
 
 // GetDashboardStatistics godoc
 // @Summary Get dashboard statistics
-// @Description Returns comprehensive statistics including monthly, weekly, and category breakdowns
+// @Description Returns comprehensive statistics including monthly, weekly, and category breakdowns, with income, net savings and savings rate
 // @Tags statistics
 // @Produce json
 // @Param months query int false "Number of months to include" default(6)
@@ -1,14 +1,16 @@
 package telegram
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"strings"
 	"time"
 
 	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
 	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
-	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/grok"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
@@ -16,12 +18,28 @@ import (
 	tele "gopkg.in/telebot.v3"
 )
 
+// maxVoiceSeconds limits the voice notes that are transcribed, expenses are short to dictate
+const maxVoiceSeconds = 60
+
+// maxDocumentBytes limits the receipt documents that are downloaded, invoices are a few pages at most
+const maxDocumentBytes = 10 << 20
+
 // BotHandler holds all dependencies for the bot handlers
 type BotHandler struct {
 	intentDetector          ports.IntentDetector
 	billWithExpensesService *services.BillWithExpensesService
 	accountLinkService      *services.AccountLinkService
-	grokClient              *grok.GrokClient
+	exportService           *services.ExportService
+	billDraftService        *services.BillDraftService
+	botSessionService       *services.BotSessionService
+	receiptParsingService   *services.ReceiptParsingService
+	categoryService         *services.CategoryService
+	merchantService         *services.MerchantService
+	walletService           *services.WalletService
+	splitService            *services.SplitService
+	incomeService           *services.IncomeService
+	goalService             *services.GoalService
+	speechToText            ports.SpeechToText
 	messages                *Messages
 }
 
@@ -30,19 +48,43 @@ func NewBotHandler(
 	intentDetector ports.IntentDetector,
 	billWithExpensesService *services.BillWithExpensesService,
 	accountLinkService *services.AccountLinkService,
-	grokClient *grok.GrokClient,
+	exportService *services.ExportService,
+	billDraftService *services.BillDraftService,
+	botSessionService *services.BotSessionService,
+	receiptParsingService *services.ReceiptParsingService,
+	categoryService *services.CategoryService,
+	merchantService *services.MerchantService,
+	walletService *services.WalletService,
+	splitService *services.SplitService,
+	incomeService *services.IncomeService,
+	goalService *services.GoalService,
+	speechToText ports.SpeechToText,
 	messages *Messages,
 ) *BotHandler {
 	return &BotHandler{
 		intentDetector:          intentDetector,
 		billWithExpensesService: billWithExpensesService,
 		accountLinkService:      accountLinkService,
-		grokClient:              grokClient,
+		exportService:           exportService,
+		billDraftService:        billDraftService,
+		botSessionService:       botSessionService,
+		receiptParsingService:   receiptParsingService,
+		categoryService:         categoryService,
+		merchantService:         merchantService,
+		walletService:           walletService,
+		splitService:            splitService,
+		incomeService:           incomeService,
+		goalService:             goalService,
+		speechToText:            speechToText,
 		messages:                messages,
 	}
 }
 
 func (h *BotHandler) HandleStart(c tele.Context) error {
+	// Wallet invite links open the bot with the invite code, e.g. t.me/MiBolsilloBot?start=<code>
+	if code := strings.TrimSpace(c.Message().Payload); code != "" {
+		return h.handleWalletInvite(c, code)
+	}
 	return c.Send(h.messages.Welcome, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
 }
 
@@ -68,6 +110,48 @@ func (h *BotHandler) HandleLink(c tele.Context) error {
 	return c.Send(message, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
 }
 
+// HandleExport sends the user's bills as a CSV or XLSX document, e.g. "/export xlsx". In a group
+// the bills of the group's shared wallet are sent
+func (h *BotHandler) HandleExport(c tele.Context) error {
+	telegramID := c.Sender().ID
+
+	// Get or create user by Telegram ID
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(telegramID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", telegramID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	formatName := "csv"
+	if args := c.Args(); len(args) > 0 {
+		formatName = strings.ToLower(args[0])
+	}
+
+	format, err := h.exportService.GetFormat(formatName)
+	if err != nil {
+		return c.Send(h.messages.ExportInvalidFormat)
+	}
+
+	walletID, err := h.chatWalletID(c, user.UserID)
+	if err != nil {
+		return h.respondWalletError(c, err)
+	}
+
+	var buf bytes.Buffer
+	if err := h.exportService.ExportBills(user.UserID, walletID, format, nil, nil, &buf); err != nil {
+		log.Printf("Failed to export bills for user %s: %v", user.UserID, err)
+		return c.Send(h.messages.ErrorExport)
+	}
+
+	document := &tele.Document{
+		File:     tele.FromReader(&buf),
+		FileName: fmt.Sprintf("mi-bolsillo-bills-%s.%s", time.Now().Format("2006-01-02"), format.Name()),
+		MIME:     format.ContentType(),
+		Caption:  h.messages.ExportCaption,
+	}
+	return c.Send(document)
+}
+
 func (h *BotHandler) HandleText(c tele.Context) error {
 	telegramID := c.Sender().ID
 	text := c.Text()
@@ -81,8 +165,32 @@ func (h *BotHandler) HandleText(c tele.Context) error {
 
 	log.Printf("Received text from user %d: %s", telegramID, text)
 
-	// Detect intent
-	intent, err := h.intentDetector.DetectIntent(text)
+	return h.handleUserText(c, user.UserID, text)
+}
+
+// handleUserText routes a message written or dictated by the user: draft corrections,
+// answers to a follow-up question, or a new request through intent detection
+func (h *BotHandler) handleUserText(c tele.Context, userID string, text string) error {
+	// Replies to a draft edit prompt carry the corrections of that draft
+	if draftID := draftIDFromReply(c.Message()); draftID != "" {
+		return h.handleDraftCorrections(c, userID, draftID, text)
+	}
+
+	// A pending conversation means this message answers the bot's last question to the sender,
+	// members of a group chat each answer their own questions
+	session, err := h.botSessionService.GetSession(c.Chat().ID, c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get bot session of %d in chat %d: %v", c.Sender().ID, c.Chat().ID, err)
+	} else if session != nil {
+		return h.handleSessionAnswer(c, userID, session, text)
+	}
+
+	// Detect intent, the expense category is picked among the user's categories
+	categories, err := h.categoryService.CategoryLabels(userID)
+	if err != nil {
+		log.Printf("Failed to get categories of user %s: %v", userID, err)
+	}
+	intent, err := h.intentDetector.DetectIntent(text, categories)
 	if err != nil {
 		log.Printf("Failed to detect intent: %v", err)
 		return c.Send(h.messages.ErrorUnderstand)
@@ -92,11 +200,13 @@ func (h *BotHandler) HandleText(c tele.Context) error {
 
 	switch intent.Type {
 	case entities.IntentListBills:
-		return h.handleListBills(c, user.UserID, intent)
+		return h.handleListBills(c, userID, intent)
 	case entities.IntentSummaryBills:
-		return h.handleSummaryBills(c, user.UserID, intent)
+		return h.handleSummaryBills(c, userID, intent)
 	case entities.IntentCreateExpense:
-		return h.handleCreateExpense(c, user.UserID, intent)
+		return h.handleCreateExpense(c, userID, intent)
+	case entities.IntentCreateIncome:
+		return h.handleCreateIncome(c, userID, intent)
 	case entities.IntentUnknown:
 		fallthrough
 	default:
@@ -104,6 +214,61 @@ func (h *BotHandler) HandleText(c tele.Context) error {
 	}
 }
 
+// HandleVoice transcribes a voice note and handles it like a text message,
+// e.g. "pagué cuarenta soles de taxi"
+func (h *BotHandler) HandleVoice(c tele.Context) error {
+	telegramID := c.Sender().ID
+
+	// Get or create user by Telegram ID
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(telegramID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", telegramID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	voice := c.Message().Voice
+	if voice == nil {
+		return c.Send(h.messages.ErrorTranscribeVoice)
+	}
+	if voice.Duration > maxVoiceSeconds {
+		return c.Send(h.messages.VoiceTooLong)
+	}
+
+	log.Printf("Received voice note from user %d (%ds)", telegramID, voice.Duration)
+
+	reader, err := c.Bot().File(&voice.File)
+	if err != nil {
+		log.Printf("Failed to download voice note: %v", err)
+		return c.Send(h.messages.ErrorTranscribeVoice)
+	}
+	defer reader.Close()
+
+	audioData, err := io.ReadAll(reader)
+	if err != nil {
+		log.Printf("Failed to read voice note: %v", err)
+		return c.Send(h.messages.ErrorTranscribeVoice)
+	}
+
+	// Telegram voice notes are Opus audio in an OGG container
+	text, err := h.speechToText.Transcribe(audioData, "voice.ogg")
+	if err != nil {
+		log.Printf("Failed to transcribe voice note: %v", err)
+		return c.Send(h.messages.ErrorTranscribeVoice)
+	}
+	if text == "" {
+		return c.Send(h.messages.ErrorTranscribeVoice)
+	}
+
+	log.Printf("Transcribed voice note from user %d in chat %d (%d characters)", telegramID, c.Chat().ID, len(text))
+
+	// Echo the transcript so the user can tell a misheard amount from a wrong answer
+	if err := c.Send(fmt.Sprintf(h.messages.VoiceTranscript, text)); err != nil {
+		log.Printf("Failed to send transcript: %v", err)
+	}
+
+	return h.handleUserText(c, user.UserID, text)
+}
+
 func (h *BotHandler) HandlePhoto(c tele.Context) error {
 	telegramID := c.Sender().ID
 
@@ -148,64 +313,80 @@ func (h *BotHandler) HandlePhoto(c tele.Context) error {
 		return c.Send(h.messages.ErrorReadImage)
 	}
 
-	// Parse the bill using Grok
-	parsedData, err := h.grokClient.ParseBillImage(imageData)
+	return h.createReceiptDraft(c, user.UserID, imageData)
+}
+
+// HandleDocument reads receipts sent as files: PDFs and SUNAT electronic invoices (XML)
+func (h *BotHandler) HandleDocument(c tele.Context) error {
+	telegramID := c.Sender().ID
+
+	// Get or create user by Telegram ID
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(telegramID)
 	if err != nil {
-		log.Printf("Failed to parse bill image: %v", err)
-		return c.Send(h.messages.ErrorParseBill)
+		log.Printf("Failed to get user for Telegram ID %d: %v", telegramID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	document := c.Message().Document
+	if document == nil {
+		return c.Send(h.messages.ErrorRetrieveDocument)
+	}
+
+	log.Printf("Received document %s (%s) from user %d", document.FileName, document.MIME, telegramID)
+
+	if document.FileSize > maxDocumentBytes {
+		return c.Send(h.messages.DocumentTooLarge)
+	}
+
+	// Send processing message
+	if err := c.Send(h.messages.ProcessingDocument); err != nil {
+		log.Printf("Failed to send processing message: %v", err)
 	}
 
-	// Parse date string to time.Time
-	billDate, err := time.Parse("2006-01-02", parsedData.Date)
+	reader, err := c.Bot().File(&document.File)
 	if err != nil {
-		billDate = time.Now()
+		log.Printf("Failed to download document: %v", err)
+		return c.Send(h.messages.ErrorRetrieveDocument)
 	}
+	defer reader.Close()
 
-	// Create bill with expenses
-	// Set exchange rate based on currency
-	exchangeRate := 3.75 // Default PEN to USD exchange rate
-	if parsedData.Currency == "USD" {
-		exchangeRate = 3.75 // USD to PEN
+	documentData, err := io.ReadAll(io.LimitReader(reader, maxDocumentBytes))
+	if err != nil {
+		log.Printf("Failed to read document data: %v", err)
+		return c.Send(h.messages.ErrorRetrieveDocument)
 	}
 
-	handlerDTO := handlerdtos.CreateBillWithExpensesRequest{
-		UserID:       user.UserID,
-		Source:       "telegram",
-		Description:  parsedData.MerchantName,
-		Category:     "General",
-		Currency:     parsedData.Currency,
-		ExchangeRate: exchangeRate,
-		Date:         billDate,
-		Expenses:     make([]handlerdtos.CreateExpenseForBill, len(parsedData.Items)),
-	}
-
-	for i, item := range parsedData.Items {
-		handlerDTO.Expenses[i] = handlerdtos.CreateExpenseForBill{
-			Description: item.Description,
-			Amount:      item.Amount,
-			Category:    item.Category,
-			Date:        parsedData.Date,
+	return h.createReceiptDraft(c, user.UserID, documentData)
+}
+
+// createReceiptDraft parses a receipt photo or document and shows it for review
+func (h *BotHandler) createReceiptDraft(c tele.Context, userID string, data []byte) error {
+	// Receipts sent to a group go to the group's shared wallet
+	walletID, err := h.chatWalletID(c, userID)
+	if err != nil {
+		return h.respondWalletError(c, err)
+	}
+
+	parsedData, err := h.receiptParsingService.ParseReceipt(userID, data)
+	if err != nil {
+		log.Printf("Failed to parse bill: %v", err)
+		if errors.Is(err, services.ErrUnsupportedReceiptFormat) {
+			return c.Send(h.messages.ErrorUnsupportedDocument)
 		}
+		return c.Send(h.messages.ErrorParseBill)
 	}
 
-	serviceDTO := mappers.ToCreateBillWithExpensesServiceDTO(handlerDTO)
-	bill, _, err := h.billWithExpensesService.CreateBillWithExpenses(serviceDTO)
+	// Keep the parsed bill as a draft, it's only saved once the user confirms it
+	draftDTO := mappers.ToCreateBillDraftServiceDTO(parsedData, userID, "telegram", data)
+	draftDTO.WalletID = walletID
+	draft, err := h.billDraftService.CreateDraft(draftDTO)
 	if err != nil {
-		log.Printf("Failed to create bill: %v", err)
+		log.Printf("Failed to create bill draft: %v", err)
 		return c.Send(h.messages.ErrorSaveBill)
 	}
 
-	// Send success message
-	responseMsg := fmt.Sprintf(h.messages.BillSaved,
-		parsedData.MerchantName,
-		parsedData.Currency,
-		parsedData.TotalAmount,
-		parsedData.Date,
-		len(parsedData.Items),
-	)
-
-	log.Printf("Bill created successfully: %s", bill.BillId)
-	return c.Send(responseMsg, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+	log.Printf("Bill draft created successfully: %s", draft.DraftID)
+	return h.sendDraftReview(c, draft)
 }
 
 func (h *BotHandler) handleListBills(c tele.Context, userID string, intent *entities.Intent) error {
@@ -297,10 +478,16 @@ func (h *BotHandler) handleSummaryBills(c tele.Context, userID string, intent *e
 }
 
 func getAmountInCurrency(bill *servicedtos.BillWithExpensesResponse, currency string) float64 {
-	if currency == "PEN" {
+	switch currency {
+	case bill.Currency:
+		return bill.AmountOriginal
+	case "PEN":
 		return bill.AmountPen
+	case "USD":
+		return bill.AmountUsd
+	default:
+		return bill.AmountReporting
 	}
-	return bill.AmountUsd
 }
 
 func filterBillsByPeriod(bills []*servicedtos.BillWithExpensesResponse, period string) []*servicedtos.BillWithExpensesResponse {
@@ -354,12 +541,49 @@ func getPeriodName(period string) string {
 }
 
 func (h *BotHandler) handleCreateExpense(c tele.Context, userID string, intent *entities.Intent) error {
-	// Extract parameters from intent
-	amount, ok := intent.Parameters["amount"].(float64)
-	if !ok || amount <= 0 {
-		return c.Send(h.messages.ErrorMissingAmount)
+	if intent.Parameters == nil {
+		intent.Parameters = map[string]interface{}{}
+	}
+
+	// Expenses logged in a group go to the group's shared wallet
+	walletID, err := h.chatWalletID(c, userID)
+	if err != nil {
+		h.clearSession(c)
+		return h.respondWalletError(c, err)
+	}
+
+	// A category that isn't one of the user's is asked for again
+	if category, ok := intent.Parameters["category"].(string); ok && category != "" {
+		match, err := h.categoryService.FindCategory(userID, category)
+		if err != nil {
+			log.Printf("Failed to resolve category %q: %v", category, err)
+		}
+		if match != nil {
+			intent.Parameters["category"] = match.Name
+		} else {
+			delete(intent.Parameters, "category")
+		}
+	}
+
+	// A merchant the user already filed under a category doesn't need to be asked about
+	if category, _ := intent.Parameters["category"].(string); category == "" {
+		if merchant, ok := intent.Parameters["merchant"].(string); ok && merchant != "" {
+			if name := h.merchantCategory(userID, merchant); name != "" {
+				intent.Parameters["category"] = name
+			}
+		}
+	}
+
+	// Ask for the first missing slot, the answer is merged into this intent
+	if state := missingExpenseSlot(intent); state != "" {
+		return h.askForSlot(c, userID, state, intent)
 	}
 
+	// Extract parameters from intent
+	amount, _ := intent.Parameters["amount"].(float64)
+	category, _ := intent.Parameters["category"].(string)
+	currency, _ := intent.Parameters["currency"].(string)
+
 	// Get description from merchant or description parameter
 	description := "Gasto"
 	if merchant, ok := intent.Parameters["merchant"].(string); ok && merchant != "" {
@@ -368,47 +592,84 @@ func (h *BotHandler) handleCreateExpense(c tele.Context, userID string, intent *
 		description = desc
 	}
 
-	// Get category, default to "Other"
-	category := "Other"
-	if cat, ok := intent.Parameters["category"].(string); ok && cat != "" {
-		category = cat
+	// Get date, default to today
+	date := time.Now()
+	if dateParam, ok := intent.Parameters["date"].(string); ok && dateParam != "" {
+		date, _ = time.Parse("2006-01-02", dateParam)
 	}
 
 	// Create bill with single expense
-	now := time.Now()
 	handlerDTO := handlerdtos.CreateBillWithExpensesRequest{
-		UserID:       userID,
-		Source:       "telegram",
-		Description:  description,
-		Category:     category,
-		Currency:     "PEN", // Default to PEN for Peru
-		ExchangeRate: 3.75,  // Default PEN to USD exchange rate
-		Date:         now,
+		UserID:      userID,
+		WalletID:    walletID,
+		Source:      "telegram",
+		Description: description,
+		Category:    category,
+		Currency:    currency,
+		Date:        date,
 		Expenses: []handlerdtos.CreateExpenseForBill{
 			{
 				Description: description,
 				Amount:      amount,
 				Category:    category,
-				Date:        now.Format("2006-01-02"),
+				Date:        date.Format("2006-01-02"),
 			},
 		},
 	}
 
 	serviceDTO := mappers.ToCreateBillWithExpensesServiceDTO(handlerDTO)
+	// Only a category the user answered is their choice, the detected one is a guess
+	confirmed, _ := intent.Parameters["category_confirmed"].(bool)
+	serviceDTO.CategoryGuessed = !confirmed
+	serviceDTO.CardLastFour, _ = intent.Parameters["card_last_four"].(string)
 	bill, _, err := h.billWithExpensesService.CreateBillWithExpenses(serviceDTO)
 	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedCurrency) {
+			// Ask again instead of dropping what the user already told us
+			delete(intent.Parameters, "currency")
+			if err := c.Send(h.messages.InvalidCurrency); err != nil {
+				log.Printf("Failed to send message: %v", err)
+			}
+			return h.askForSlot(c, userID, entities.SessionAwaitingCurrency, intent)
+		}
 		log.Printf("Failed to create expense: %v", err)
-		return c.Send(h.messages.ErrorSaveExpense)
+		h.clearSession(c)
+		return c.Send(h.messages.ErrorSaveExpense, removeKeyboard)
 	}
+	h.clearSession(c)
 
 	// Send success message
 	responseMsg := fmt.Sprintf(h.messages.ExpenseSaved,
+		bill.Currency,
 		amount,
 		description,
-		category,
-		now.Format("2006-01-02"),
+		bill.Category,
+		date.Format("2006-01-02"),
 	)
 
 	log.Printf("Expense created successfully: %s", bill.BillId)
-	return c.Send(responseMsg, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+	return c.Send(responseMsg, &tele.SendOptions{ParseMode: tele.ModeMarkdown, ReplyMarkup: removeKeyboard})
+}
+
+// merchantCategory returns the name of the category the user last chose for a merchant, or an
+// empty string when there is none
+func (h *BotHandler) merchantCategory(userID string, name string) string {
+	merchant, err := h.merchantService.FindMerchant(userID, name)
+	if err != nil {
+		log.Printf("Failed to find merchant %q: %v", name, err)
+		return ""
+	}
+	if merchant == nil || merchant.CategoryID == "" {
+		return ""
+	}
+
+	category, err := h.categoryService.FindCategory(userID, merchant.CategoryID)
+	if err != nil {
+		log.Printf("Failed to resolve category %q: %v", merchant.CategoryID, err)
+		return ""
+	}
+	if category == nil {
+		return ""
+	}
+	return category.Name
 }
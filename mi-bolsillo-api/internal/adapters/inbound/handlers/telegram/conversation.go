@@ -0,0 +1,215 @@
+package telegram
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+	tele "gopkg.in/telebot.v3"
+)
+
+// removeKeyboard hides the answer buttons once a conversation is over
+var removeKeyboard = &tele.ReplyMarkup{RemoveKeyboard: true}
+
+// cancelWords end the conversation without saving
+var cancelWords = map[string]bool{
+	"cancelar":  true,
+	"/cancelar": true,
+	"cancel":    true,
+	"/cancel":   true,
+}
+
+// missingExpenseSlot returns the state that asks for the first slot the expense still needs,
+// or an empty string when it can be saved. The date defaults to today, so it's only asked
+// when the one given can't be read
+func missingExpenseSlot(intent *entities.Intent) string {
+	if amount, ok := intent.Parameters["amount"].(float64); !ok || amount <= 0 {
+		return entities.SessionAwaitingAmount
+	}
+	if category, ok := intent.Parameters["category"].(string); !ok || category == "" {
+		return entities.SessionAwaitingCategory
+	}
+	if currency, ok := intent.Parameters["currency"].(string); !ok || currency == "" {
+		return entities.SessionAwaitingCurrency
+	}
+	if date, ok := intent.Parameters["date"].(string); ok && date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return entities.SessionAwaitingDate
+		}
+	}
+	return ""
+}
+
+// missingIncomeSlot returns the state that asks for the first slot the income still needs, or an
+// empty string when it can be saved. Incomes have no category, the rest is asked like for expenses
+func missingIncomeSlot(intent *entities.Intent) string {
+	if amount, ok := intent.Parameters["amount"].(float64); !ok || amount <= 0 {
+		return entities.SessionAwaitingAmount
+	}
+	if currency, ok := intent.Parameters["currency"].(string); !ok || currency == "" {
+		return entities.SessionAwaitingCurrency
+	}
+	if date, ok := intent.Parameters["date"].(string); ok && date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return entities.SessionAwaitingDate
+		}
+	}
+	return ""
+}
+
+// askForSlot stores the pending intent and asks the question of state
+func (h *BotHandler) askForSlot(c tele.Context, userID string, state string, intent *entities.Intent) error {
+	if err := h.botSessionService.SaveSession(c.Chat().ID, c.Sender().ID, state, intent); err != nil {
+		log.Printf("Failed to save bot session of %d in chat %d: %v", c.Sender().ID, c.Chat().ID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	markup := &tele.ReplyMarkup{ResizeKeyboard: true, OneTimeKeyboard: true}
+	switch state {
+	case entities.SessionAwaitingAmount:
+		return c.Send(h.messages.AskAmount)
+	case entities.SessionAwaitingCategory:
+		categories, err := h.categoryService.ListCategories(userID)
+		if err != nil {
+			log.Printf("Failed to get categories of user %s: %v", userID, err)
+			return c.Send(h.messages.AskCategory)
+		}
+		rows := make([]tele.Row, 0, len(categories)/2+1)
+		for i := 0; i < len(categories); i += 2 {
+			row := tele.Row{markup.Text(categories[i].Name)}
+			if i+1 < len(categories) {
+				row = append(row, markup.Text(categories[i+1].Name))
+			}
+			rows = append(rows, row)
+		}
+		markup.Reply(rows...)
+		return c.Send(h.messages.AskCategory, markup)
+	case entities.SessionAwaitingCurrency:
+		markup.Reply(markup.Row(markup.Text("PEN"), markup.Text("USD"), markup.Text("EUR")))
+		return c.Send(h.messages.AskCurrency, markup)
+	case entities.SessionAwaitingDate:
+		markup.Reply(markup.Row(markup.Text(h.messages.ButtonToday), markup.Text(h.messages.ButtonYesterday)))
+		return c.Send(h.messages.AskDate, markup)
+	default:
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+}
+
+// handleSessionAnswer merges the user's answer into the pending intent and resumes it
+func (h *BotHandler) handleSessionAnswer(c tele.Context, userID string, session *entities.BotSession, text string) error {
+	answer := strings.TrimSpace(text)
+	if cancelWords[strings.ToLower(answer)] {
+		h.clearSession(c)
+		return c.Send(h.messages.ConversationCancelled, removeKeyboard)
+	}
+
+	intent := session.Intent
+	if intent == nil {
+		intent = &entities.Intent{Type: entities.IntentCreateExpense}
+	}
+	if intent.Parameters == nil {
+		intent.Parameters = map[string]interface{}{}
+	}
+
+	switch session.State {
+	case entities.SessionAwaitingAmount:
+		amount, ok := parseAmountAnswer(answer)
+		if !ok {
+			return c.Send(h.messages.InvalidAmount)
+		}
+		intent.Parameters["amount"] = amount
+	case entities.SessionAwaitingCategory:
+		category, err := h.categoryService.FindCategory(userID, answer)
+		if err != nil {
+			log.Printf("Failed to resolve category %q: %v", answer, err)
+			return c.Send(h.messages.ErrorProcessingMsg)
+		}
+		if category == nil {
+			return c.Send(h.messages.InvalidCategory)
+		}
+		intent.Parameters["category"] = category.Name
+		intent.Parameters["category_confirmed"] = true
+	case entities.SessionAwaitingCurrency:
+		intent.Parameters["currency"] = answer
+	case entities.SessionAwaitingDate:
+		date, ok := h.parseDateAnswer(answer)
+		if !ok {
+			return c.Send(h.messages.InvalidDate)
+		}
+		intent.Parameters["date"] = date
+	default:
+		h.clearSession(c)
+		return c.Send(h.messages.UnknownIntent, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+	}
+
+	if intent.Type == entities.IntentCreateIncome {
+		return h.handleCreateIncome(c, userID, intent)
+	}
+	return h.handleCreateExpense(c, userID, intent)
+}
+
+func (h *BotHandler) clearSession(c tele.Context) {
+	if err := h.botSessionService.ClearSession(c.Chat().ID, c.Sender().ID); err != nil {
+		log.Printf("Failed to clear bot session of %d in chat %d: %v", c.Sender().ID, c.Chat().ID, err)
+	}
+}
+
+// parseAmountAnswer reads the first number of the answer, e.g. "25.50", "S/ 25,50" or "1,234.50"
+func parseAmountAnswer(answer string) (float64, bool) {
+	for _, field := range strings.Fields(answer) {
+		field = strings.Trim(field, "S/$€.")
+		amount, err := strconv.ParseFloat(normalizeAmount(field), 64)
+		if err == nil && amount > 0 {
+			return amount, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeAmount drops thousands separators and turns the decimal separator into a dot. The last
+// "." or "," is the decimal separator when one or two digits follow it, so "1,234.50", "1.234,50"
+// and "25,50" read as expected and "1,234" is a thousand
+func normalizeAmount(value string) string {
+	decimalIndex := strings.LastIndexAny(value, ".,")
+	if decimalIndex == -1 {
+		return value
+	}
+	if decimals := len(value) - decimalIndex - 1; decimals < 1 || decimals > 2 {
+		decimalIndex = -1
+	}
+
+	var normalized strings.Builder
+	for i, r := range value {
+		switch {
+		case i == decimalIndex:
+			normalized.WriteRune('.')
+		case r == '.' || r == ',':
+			// Thousands separator
+		default:
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+// parseDateAnswer reads "hoy", "ayer", "2025-10-10" or "10/10/2025" as YYYY-MM-DD
+func (h *BotHandler) parseDateAnswer(answer string) (string, bool) {
+	now := time.Now()
+	switch strings.ToLower(answer) {
+	case "hoy", strings.ToLower(h.messages.ButtonToday):
+		return now.Format("2006-01-02"), true
+	case "ayer", strings.ToLower(h.messages.ButtonYesterday):
+		return now.AddDate(0, 0, -1).Format("2006-01-02"), true
+	case "anteayer":
+		return now.AddDate(0, 0, -2).Format("2006-01-02"), true
+	}
+
+	for _, layout := range []string{"2006-01-02", "02/01/2006", "2/1/2006"} {
+		if date, err := time.Parse(layout, answer); err == nil {
+			return date.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
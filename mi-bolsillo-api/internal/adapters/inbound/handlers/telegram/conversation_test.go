@@ -0,0 +1,28 @@
+package telegram
+
+import "testing"
+
+func TestParseAmountAnswer(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   float64
+		ok     bool
+	}{
+		{"25.50", 25.50, true},
+		{"S/ 25,50", 25.50, true},
+		{"1,234.50", 1234.50, true},
+		{"1.234,50", 1234.50, true},
+		{"S/1,234", 1234, true},
+		{"1.234.567,8", 1234567.8, true},
+		{"$ 30.", 30, true},
+		{"fueron 12 soles", 12, true},
+		{"nada", 0, false},
+		{"0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAmountAnswer(tt.answer)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseAmountAnswer(%q) = %v, %v, want %v, %v", tt.answer, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	coreentities "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	tele "gopkg.in/telebot.v3"
+)
+
+// HandleDebts sends who owes whom from the split bills, the group's shared wallet in groups and the
+// user's personal ledger otherwise
+func (h *BotHandler) HandleDebts(c tele.Context) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	walletID, err := h.chatWalletID(c, user.UserID)
+	if err != nil {
+		return h.respondWalletError(c, err)
+	}
+
+	ledgers, err := h.splitService.GetBalances(user.UserID, walletID)
+	if err != nil {
+		log.Printf("Failed to get balances for user %s: %v", user.UserID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+	if len(ledgers) == 0 {
+		return c.Send(h.messages.DebtsNone)
+	}
+
+	var message strings.Builder
+	message.WriteString(h.messages.DebtsHeader)
+	for _, ledger := range ledgers {
+		for _, transfer := range ledger.Transfers {
+			message.WriteString(fmt.Sprintf(h.messages.DebtsTransfer, h.participantName(transfer.From), h.participantName(transfer.To), ledger.Currency, transfer.Amount))
+		}
+	}
+	message.WriteString(h.messages.DebtsFooter)
+	return c.Send(message.String(), &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// participantName shows the personal ledger's owner as "you"
+func (h *BotHandler) participantName(participant string) string {
+	if participant == coreentities.SplitParticipantMe {
+		return h.messages.DebtsMe
+	}
+	return participant
+}
@@ -0,0 +1,231 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	coreentities "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	tele "gopkg.in/telebot.v3"
+)
+
+// Inline buttons of the bill draft review, their data is the draft ID
+var (
+	BtnSaveDraft    = tele.Btn{Unique: "draft_save"}
+	BtnEditDraft    = tele.Btn{Unique: "draft_edit"}
+	BtnDiscardDraft = tele.Btn{Unique: "draft_discard"}
+)
+
+// draftReferencePrefix marks the line of the edit prompt that holds the draft ID,
+// so a reply to the prompt can be matched to its draft without keeping state
+const draftReferencePrefix = "Ref: "
+
+// HandleSaveDraft confirms a reviewed draft and saves it as a bill
+func (h *BotHandler) HandleSaveDraft(c tele.Context) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg})
+	}
+
+	draft, err := h.billDraftService.GetDraft(c.Data(), user.UserID)
+	if err != nil {
+		return h.respondDraftError(c, err)
+	}
+
+	bill, expenses, err := h.billDraftService.ConfirmDraft(draft.DraftID, user.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnsupportedCurrency):
+			return c.Respond(&tele.CallbackResponse{Text: fmt.Sprintf(h.messages.DraftWarningUnknownCurrency, draft.Currency), ShowAlert: true})
+		case errors.Is(err, services.ErrDraftItemsExceedTotal):
+			return c.Respond(&tele.CallbackResponse{Text: h.messages.DraftItemsExceedTotal, ShowAlert: true})
+		case errors.Is(err, services.ErrEmptyBillDraft):
+			return c.Respond(&tele.CallbackResponse{Text: h.messages.DraftEmpty, ShowAlert: true})
+		}
+		return h.respondDraftError(c, err)
+	}
+
+	log.Printf("Bill created successfully from draft %s: %s", draft.DraftID, bill.BillId)
+	_ = c.Respond()
+
+	responseMsg := fmt.Sprintf(h.messages.BillSaved,
+		bill.Description,
+		bill.Currency,
+		bill.AmountOriginal,
+		bill.Date.Format("2006-01-02"),
+		len(expenses),
+	)
+	return c.Edit(responseMsg, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// HandleEditDraft asks the user to reply with the corrections of a draft
+func (h *BotHandler) HandleEditDraft(c tele.Context) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg})
+	}
+
+	draft, err := h.billDraftService.GetDraft(c.Data(), user.UserID)
+	if err != nil {
+		return h.respondDraftError(c, err)
+	}
+
+	_ = c.Respond()
+	prompt := h.messages.DraftEditPrompt + "\n\n" + draftReferencePrefix + draft.DraftID
+	return c.Send(prompt, &tele.ReplyMarkup{ForceReply: true})
+}
+
+// HandleDiscardDraft deletes a draft without saving it
+func (h *BotHandler) HandleDiscardDraft(c tele.Context) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg})
+	}
+
+	if err := h.billDraftService.DiscardDraft(c.Data(), user.UserID); err != nil {
+		return h.respondDraftError(c, err)
+	}
+
+	_ = c.Respond()
+	return c.Edit(h.messages.DraftDiscarded)
+}
+
+// handleDraftCorrections applies the "field: value" lines of a reply to the edit prompt
+// and shows the corrected draft for review again
+func (h *BotHandler) handleDraftCorrections(c tele.Context, userID string, draftID string, text string) error {
+	dto, ok := parseDraftCorrections(text)
+	if !ok {
+		return c.Send(h.messages.DraftEditInvalid)
+	}
+
+	draft, err := h.billDraftService.UpdateDraft(draftID, userID, dto)
+	if err != nil {
+		if errors.Is(err, services.ErrBillDraftNotFound) || errors.Is(err, services.ErrUnauthorized) {
+			return c.Send(h.messages.DraftNotFound)
+		}
+		log.Printf("Failed to update bill draft %s: %v", draftID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	return h.sendDraftReview(c, draft)
+}
+
+// sendDraftReview shows a draft with its warnings and the Save / Edit / Discard buttons
+func (h *BotHandler) sendDraftReview(c tele.Context, draft *coreentities.BillDraft) error {
+	date := draft.Date
+	if date == "" {
+		date = "-"
+	}
+
+	responseMsg := fmt.Sprintf(h.messages.BillDraft,
+		draft.Description,
+		draft.Currency,
+		draft.TotalAmount,
+		date,
+	)
+
+	var itemsTotal float64
+	for _, item := range draft.Items {
+		itemsTotal += item.Amount
+		responseMsg += fmt.Sprintf("   • %s: %.2f\n", item.Description, item.Amount)
+	}
+
+	if draft.InvoiceNumber != "" {
+		responseMsg += fmt.Sprintf(h.messages.DraftInvoice, draft.InvoiceNumber, draft.SupplierRUC)
+	}
+
+	if len(draft.Warnings) > 0 {
+		responseMsg += "\n"
+	}
+	for _, warning := range draft.Warnings {
+		switch warning {
+		case coreentities.DraftWarningTotalMismatch:
+			responseMsg += fmt.Sprintf(h.messages.DraftWarningTotalMismatch, draft.TotalAmount, itemsTotal)
+		case coreentities.DraftWarningMissingDate:
+			responseMsg += h.messages.DraftWarningMissingDate
+		case coreentities.DraftWarningUnknownCurrency:
+			responseMsg += fmt.Sprintf(h.messages.DraftWarningUnknownCurrency, draft.Currency)
+		case coreentities.DraftWarningNoItems:
+			responseMsg += h.messages.DraftWarningNoItems
+		}
+		responseMsg += "\n"
+	}
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(
+		markup.Data(h.messages.ButtonSave, BtnSaveDraft.Unique, draft.DraftID),
+		markup.Data(h.messages.ButtonEdit, BtnEditDraft.Unique, draft.DraftID),
+		markup.Data(h.messages.ButtonDiscard, BtnDiscardDraft.Unique, draft.DraftID),
+	))
+
+	return c.Send(responseMsg, markup, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// respondDraftError answers a draft button press that failed
+func (h *BotHandler) respondDraftError(c tele.Context, err error) error {
+	if errors.Is(err, services.ErrBillDraftNotFound) || errors.Is(err, services.ErrUnauthorized) {
+		_ = c.Respond(&tele.CallbackResponse{Text: h.messages.DraftNotFound})
+		return c.Edit(h.messages.DraftNotFound)
+	}
+	log.Printf("Failed to process bill draft %s: %v", c.Data(), err)
+	return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg, ShowAlert: true})
+}
+
+// draftIDFromReply returns the draft ID of the edit prompt a message replies to, if any
+func draftIDFromReply(message *tele.Message) string {
+	if message == nil || message.ReplyTo == nil {
+		return ""
+	}
+	for _, line := range strings.Split(message.ReplyTo.Text, "\n") {
+		if strings.HasPrefix(line, draftReferencePrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, draftReferencePrefix))
+		}
+	}
+	return ""
+}
+
+// parseDraftCorrections reads lines such as "total: 45.80" or "fecha: 2025-10-10",
+// field names are accepted in Spanish and English
+func parseDraftCorrections(text string) (servicedtos.UpdateBillDraftDTO, bool) {
+	var dto servicedtos.UpdateBillDraftDTO
+	found := false
+
+	for _, line := range strings.Split(text, "\n") {
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		switch field {
+		case "comercio", "comerciante", "merchant", "descripcion", "descripción":
+			dto.Description = &value
+		case "moneda", "currency":
+			dto.Currency = &value
+		case "fecha", "date":
+			dto.Date = &value
+		case "total":
+			amount, err := strconv.ParseFloat(normalizeAmount(value), 64)
+			if err != nil || amount < 0 {
+				return dto, false
+			}
+			dto.TotalAmount = &amount
+		default:
+			continue
+		}
+		found = true
+	}
+
+	return dto, found
+}
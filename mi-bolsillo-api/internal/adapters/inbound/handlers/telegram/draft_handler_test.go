@@ -0,0 +1,27 @@
+package telegram
+
+import "testing"
+
+func TestParseDraftCorrectionsTotal(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+		ok   bool
+	}{
+		{"total: 45.80", 45.80, true},
+		{"total: 45,80", 45.80, true},
+		{"total: 1,234.50", 1234.50, true},
+		{"Total: 1.234,50", 1234.50, true},
+		{"total: mucho", 0, false},
+	}
+	for _, tt := range tests {
+		dto, ok := parseDraftCorrections(tt.text)
+		if ok != tt.ok {
+			t.Errorf("parseDraftCorrections(%q) ok = %v, want %v", tt.text, ok, tt.ok)
+			continue
+		}
+		if ok && (dto.TotalAmount == nil || *dto.TotalAmount != tt.want) {
+			t.Errorf("parseDraftCorrections(%q) total = %v, want %v", tt.text, dto.TotalAmount, tt.want)
+		}
+	}
+}
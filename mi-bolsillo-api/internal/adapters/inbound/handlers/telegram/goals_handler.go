@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	coreentities "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	tele "gopkg.in/telebot.v3"
+)
+
+// progressBarBlocks is how many blocks the goal progress bar is drawn with
+const progressBarBlocks = 10
+
+// HandleGoals sends the user's savings goals with their progress and whether they're on track.
+// Goals are personal, so group chats are pointed to a private chat
+func (h *BotHandler) HandleGoals(c tele.Context) error {
+	if isGroupChat(c.Chat()) {
+		return c.Send(h.messages.GoalsPrivate)
+	}
+
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	goals, err := h.goalService.ListGoals(user.UserID)
+	if err != nil {
+		log.Printf("Failed to get goals for user %s: %v", user.UserID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+	if len(goals) == 0 {
+		return c.Send(h.messages.GoalsNone)
+	}
+
+	var message strings.Builder
+	message.WriteString(h.messages.GoalsHeader)
+	for _, progress := range goals {
+		goal := progress.Goal
+		message.WriteString(fmt.Sprintf(h.messages.GoalsItem,
+			goal.Name,
+			progressBar(progress.Percentage),
+			progress.Percentage,
+			goal.Currency, goal.SavedAmount,
+			goal.Currency, goal.TargetAmount,
+			goal.Deadline.Format("2006-01-02"),
+			h.goalStatusLine(progress)))
+	}
+	message.WriteString(h.messages.GoalsFooter)
+	return c.Send(message.String(), &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// goalStatusLine describes whether the user's savings pace meets the goal
+func (h *BotHandler) goalStatusLine(progress dtos.GoalProgress) string {
+	currency := progress.Goal.Currency
+	switch progress.Status {
+	case coreentities.GoalStatusAchieved:
+		return h.messages.GoalsAchieved
+	case coreentities.GoalStatusOverdue:
+		return fmt.Sprintf(h.messages.GoalsOverdue, currency, progress.RemainingAmount)
+	case coreentities.GoalStatusOnTrack:
+		return fmt.Sprintf(h.messages.GoalsOnTrack, currency, progress.MonthlySavings, currency, progress.RequiredMonthly)
+	default:
+		return fmt.Sprintf(h.messages.GoalsBehind, currency, math.Max(progress.MonthlySavings, 0), currency, progress.RequiredMonthly)
+	}
+}
+
+// progressBar draws a percentage as filled and empty blocks
+func progressBar(percentage float64) string {
+	filled := int(math.Round(math.Min(math.Max(percentage, 0), 100) / 100 * progressBarBlocks))
+	return strings.Repeat("▓", filled) + strings.Repeat("░", progressBarBlocks-filled)
+}
@@ -0,0 +1,92 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	tele "gopkg.in/telebot.v3"
+)
+
+// handleCreateIncome records money the user received, e.g. "me pagaron 3000 soles". Incomes are
+// personal, also when told in a group
+func (h *BotHandler) handleCreateIncome(c tele.Context, userID string, intent *entities.Intent) error {
+	if intent.Parameters == nil {
+		intent.Parameters = map[string]interface{}{}
+	}
+
+	// Ask for the first missing slot, the answer is merged into this intent
+	if state := missingIncomeSlot(intent); state != "" {
+		return h.askForSlot(c, userID, state, intent)
+	}
+
+	amount, _ := intent.Parameters["amount"].(float64)
+	currency, _ := intent.Parameters["currency"].(string)
+	incomeType, _ := intent.Parameters["income_type"].(string)
+	description, _ := intent.Parameters["description"].(string)
+
+	// Get date, default to today
+	date := time.Now()
+	if dateParam, ok := intent.Parameters["date"].(string); ok && dateParam != "" {
+		date, _ = time.Parse("2006-01-02", dateParam)
+	}
+
+	income, err := h.incomeService.CreateIncome(servicedtos.IncomeDTO{
+		UserID:      userID,
+		Source:      "telegram",
+		Type:        incomeType,
+		Description: description,
+		Amount:      amount,
+		Currency:    currency,
+		Date:        date,
+	})
+	if errors.Is(err, services.ErrInvalidIncome) {
+		// An income type the detector made up is recorded as other
+		delete(intent.Parameters, "income_type")
+		income, err = h.incomeService.CreateIncome(servicedtos.IncomeDTO{
+			UserID:      userID,
+			Source:      "telegram",
+			Description: description,
+			Amount:      amount,
+			Currency:    currency,
+			Date:        date,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedCurrency) {
+			// Ask again instead of dropping what the user already told us
+			delete(intent.Parameters, "currency")
+			if err := c.Send(h.messages.InvalidCurrency); err != nil {
+				log.Printf("Failed to send message: %v", err)
+			}
+			return h.askForSlot(c, userID, entities.SessionAwaitingCurrency, intent)
+		}
+		log.Printf("Failed to create income: %v", err)
+		h.clearSession(c)
+		return c.Send(h.messages.ErrorSaveIncome, removeKeyboard)
+	}
+	h.clearSession(c)
+
+	typeLabel := h.messages.IncomeTypeLabels[income.Type]
+	if typeLabel == "" {
+		typeLabel = income.Type
+	}
+	if description == "" {
+		description = typeLabel
+	}
+
+	responseMsg := fmt.Sprintf(h.messages.IncomeSaved,
+		income.Currency,
+		income.AmountOriginal,
+		description,
+		typeLabel,
+		income.Date.Format("2006-01-02"),
+	)
+
+	log.Printf("Income created successfully: %s", income.IncomeID)
+	return c.Send(responseMsg, &tele.SendOptions{ParseMode: tele.ModeMarkdown, ReplyMarkup: removeKeyboard})
+}
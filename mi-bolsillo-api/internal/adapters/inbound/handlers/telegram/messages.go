@@ -8,28 +8,110 @@ import (
 
 // Messages holds all bot message templates
 type Messages struct {
-	Welcome            string `json:"welcome"`
-	ProcessingImage    string `json:"processing_image"`
-	BillSaved          string `json:"bill_saved"`
-	ExpenseSaved       string `json:"expense_saved"`
-	NoBills            string `json:"no_bills"`
-	NoBillsSummary     string `json:"no_bills_summary"`
-	NoBillsForPeriod   string `json:"no_bills_for_period"`
-	BillsListHeader    string `json:"bills_list_header"`
-	SummaryHeader      string `json:"summary_header"`
-	UnknownIntent      string `json:"unknown_intent"`
-	LinkAccountOTP     string `json:"link_account_otp"`
-	LinkAccountError   string `json:"link_account_error"`
-	ErrorUnderstand    string `json:"error_understand"`
-	ErrorRetrieveImage string `json:"error_retrieve_image"`
-	ErrorDownloadImage string `json:"error_download_image"`
-	ErrorReadImage     string `json:"error_read_image"`
-	ErrorParseBill     string `json:"error_parse_bill"`
-	ErrorSaveBill      string `json:"error_save_bill"`
-	ErrorSaveExpense   string `json:"error_save_expense"`
-	ErrorRetrieveBills string `json:"error_retrieve_bills"`
-	ErrorProcessingMsg string `json:"error_processing_message"`
-	ErrorMissingAmount string `json:"error_missing_amount"`
+	Welcome             string `json:"welcome"`
+	ProcessingImage     string `json:"processing_image"`
+	BillSaved           string `json:"bill_saved"`
+	ExpenseSaved        string `json:"expense_saved"`
+	NoBills             string `json:"no_bills"`
+	NoBillsSummary      string `json:"no_bills_summary"`
+	NoBillsForPeriod    string `json:"no_bills_for_period"`
+	BillsListHeader     string `json:"bills_list_header"`
+	SummaryHeader       string `json:"summary_header"`
+	UnknownIntent       string `json:"unknown_intent"`
+	LinkAccountOTP      string `json:"link_account_otp"`
+	LinkAccountError    string `json:"link_account_error"`
+	ErrorUnderstand     string `json:"error_understand"`
+	ErrorRetrieveImage  string `json:"error_retrieve_image"`
+	ErrorDownloadImage  string `json:"error_download_image"`
+	ErrorReadImage      string `json:"error_read_image"`
+	ErrorParseBill      string `json:"error_parse_bill"`
+	ErrorSaveBill       string `json:"error_save_bill"`
+	ErrorSaveExpense    string `json:"error_save_expense"`
+	ErrorRetrieveBills  string `json:"error_retrieve_bills"`
+	ErrorProcessingMsg  string `json:"error_processing_message"`
+	ExportCaption       string `json:"export_caption"`
+	ExportInvalidFormat string `json:"export_invalid_format"`
+	ErrorExport         string `json:"error_export"`
+	// Bill draft review
+	BillDraft                   string `json:"bill_draft"`
+	DraftWarningTotalMismatch   string `json:"draft_warning_total_mismatch"`
+	DraftWarningMissingDate     string `json:"draft_warning_missing_date"`
+	DraftWarningUnknownCurrency string `json:"draft_warning_unknown_currency"`
+	DraftWarningNoItems         string `json:"draft_warning_no_items"`
+	ButtonSave                  string `json:"button_save"`
+	ButtonEdit                  string `json:"button_edit"`
+	ButtonDiscard               string `json:"button_discard"`
+	DraftDiscarded              string `json:"draft_discarded"`
+	DraftNotFound               string `json:"draft_not_found"`
+	DraftEditPrompt             string `json:"draft_edit_prompt"`
+	DraftEditInvalid            string `json:"draft_edit_invalid"`
+	DraftItemsExceedTotal       string `json:"draft_items_exceed_total"`
+	DraftEmpty                  string `json:"draft_empty"`
+	// Recent bills editing
+	RecentBillsHeader   string `json:"recent_bills_header"`
+	BillCard            string `json:"bill_card"`
+	BillItemsHeader     string `json:"bill_items_header"`
+	ChooseBillCategory  string `json:"choose_bill_category"`
+	ChooseBillDate      string `json:"choose_bill_date"`
+	ConfirmDeleteBill   string `json:"confirm_delete_bill"`
+	BillUpdated         string `json:"bill_updated"`
+	BillDeleted         string `json:"bill_deleted"`
+	BillNotFound        string `json:"bill_not_found"`
+	ErrorUpdateBill     string `json:"error_update_bill"`
+	ButtonBillItems     string `json:"button_bill_items"`
+	ButtonBillCategory  string `json:"button_bill_category"`
+	ButtonBillDate      string `json:"button_bill_date"`
+	ButtonBillDelete    string `json:"button_bill_delete"`
+	ButtonConfirmDelete string `json:"button_confirm_delete"`
+	ButtonBack          string `json:"button_back"`
+	ButtonToday         string `json:"button_today"`
+	ButtonYesterday     string `json:"button_yesterday"`
+	// Follow-up questions for missing expense details
+	AskAmount             string `json:"ask_amount"`
+	AskCategory           string `json:"ask_category"`
+	AskCurrency           string `json:"ask_currency"`
+	AskDate               string `json:"ask_date"`
+	InvalidAmount         string `json:"invalid_amount"`
+	InvalidCategory       string `json:"invalid_category"`
+	InvalidCurrency       string `json:"invalid_currency"`
+	InvalidDate           string `json:"invalid_date"`
+	ConversationCancelled string `json:"conversation_cancelled"`
+	// Voice notes
+	VoiceTranscript      string `json:"voice_transcript"`
+	VoiceTooLong         string `json:"voice_too_long"`
+	ErrorTranscribeVoice string `json:"error_transcribe_voice"`
+	// Receipt documents (PDF and electronic invoices)
+	ProcessingDocument       string `json:"processing_document"`
+	DocumentTooLarge         string `json:"document_too_large"`
+	ErrorRetrieveDocument    string `json:"error_retrieve_document"`
+	ErrorUnsupportedDocument string `json:"error_unsupported_document"`
+	DraftInvoice             string `json:"draft_invoice"`
+	// Shared wallets
+	WalletJoined         string `json:"wallet_joined"`
+	WalletGroupLinked    string `json:"wallet_group_linked"`
+	WalletGroupNotLinked string `json:"wallet_group_not_linked"`
+	WalletInviteInvalid  string `json:"wallet_invite_invalid"`
+	WalletNoAccess       string `json:"wallet_no_access"`
+	// Split bills
+	DebtsHeader   string `json:"debts_header"`
+	DebtsTransfer string `json:"debts_transfer"`
+	DebtsFooter   string `json:"debts_footer"`
+	DebtsNone     string `json:"debts_none"`
+	DebtsMe       string `json:"debts_me"`
+	// Incomes
+	IncomeSaved      string            `json:"income_saved"`
+	ErrorSaveIncome  string            `json:"error_save_income"`
+	IncomeTypeLabels map[string]string `json:"income_type_labels"`
+	// Savings goals
+	GoalsHeader   string `json:"goals_header"`
+	GoalsItem     string `json:"goals_item"`
+	GoalsAchieved string `json:"goals_achieved"`
+	GoalsOnTrack  string `json:"goals_on_track"`
+	GoalsBehind   string `json:"goals_behind"`
+	GoalsOverdue  string `json:"goals_overdue"`
+	GoalsFooter   string `json:"goals_footer"`
+	GoalsNone     string `json:"goals_none"`
+	GoalsPrivate  string `json:"goals_private"`
 }
 
 // LoadMessages loads bot messages from a JSON file
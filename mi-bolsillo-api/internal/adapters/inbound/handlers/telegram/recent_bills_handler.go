@@ -0,0 +1,302 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	coreentities "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	servicedtos "github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	tele "gopkg.in/telebot.v3"
+)
+
+// recentBillsLimit is how many bills /ultimas shows
+const recentBillsLimit = 5
+
+// dateChoiceDays is how many past days are offered when changing a bill's date
+const dateChoiceDays = 7
+
+// Inline buttons of the recent bills cards, their data starts with the bill ID
+var (
+	BtnBillItems         = tele.Btn{Unique: "bill_items"}
+	BtnBillCategory      = tele.Btn{Unique: "bill_cat"}
+	BtnBillSetCategory   = tele.Btn{Unique: "bill_setcat"}
+	BtnBillDate          = tele.Btn{Unique: "bill_date"}
+	BtnBillSetDate       = tele.Btn{Unique: "bill_setdate"}
+	BtnBillDelete        = tele.Btn{Unique: "bill_del"}
+	BtnBillConfirmDelete = tele.Btn{Unique: "bill_delok"}
+	BtnBillBack          = tele.Btn{Unique: "bill_back"}
+)
+
+// HandleRecentBills sends the user's latest bills, each with buttons to view or edit it
+func (h *BotHandler) HandleRecentBills(c tele.Context) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	result, err := h.billWithExpensesService.SearchBills(servicedtos.BillSearchDTO{
+		UserID: user.UserID,
+		Limit:  recentBillsLimit,
+	})
+	if err != nil {
+		log.Printf("Failed to list recent bills: %v", err)
+		return c.Send(h.messages.ErrorRetrieveBills)
+	}
+
+	if len(result.Bills) == 0 {
+		return c.Send(h.messages.NoBills)
+	}
+
+	if err := c.Send(h.messages.RecentBillsHeader, &tele.SendOptions{ParseMode: tele.ModeMarkdown}); err != nil {
+		return err
+	}
+	for _, bill := range result.Bills {
+		card := h.billCard(bill.Description, bill.Currency, bill.AmountOriginal, bill.Date, bill.Category, len(bill.Expenses))
+		if err := c.Send(card, h.billCardMarkup(bill.BillId), &tele.SendOptions{ParseMode: tele.ModeMarkdown}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleBillItems sends the expenses of a bill
+func (h *BotHandler) HandleBillItems(c tele.Context) error {
+	bill, expenses, err := h.callbackBill(c)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	responseMsg := fmt.Sprintf(h.messages.BillItemsHeader, bill.Description)
+	for _, expense := range expenses {
+		responseMsg += fmt.Sprintf("   • %s: %s %.2f (%s)\n", expense.Description, expense.Currency, expense.AmountOriginal, expense.Category)
+	}
+
+	_ = c.Respond()
+	return c.Send(responseMsg, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// HandleBillCategory shows the categories a bill can be moved to
+func (h *BotHandler) HandleBillCategory(c tele.Context) error {
+	bill, _, err := h.callbackBill(c)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	categories, err := h.categoryService.ListCategories(bill.UserID)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	// Buttons carry the category's position in the user's list, a category ID and the bill ID
+	// together don't fit in Telegram's 64 byte callback data
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(categories)/2+2)
+	for i := 0; i < len(categories); i += 2 {
+		row := tele.Row{markup.Data(categoryButtonLabel(categories[i]), BtnBillSetCategory.Unique, bill.BillId, strconv.Itoa(i))}
+		if i+1 < len(categories) {
+			row = append(row, markup.Data(categoryButtonLabel(categories[i+1]), BtnBillSetCategory.Unique, bill.BillId, strconv.Itoa(i+1)))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, markup.Row(markup.Data(h.messages.ButtonBack, BtnBillBack.Unique, bill.BillId)))
+	markup.Inline(rows...)
+
+	_ = c.Respond()
+	return c.Edit(fmt.Sprintf(h.messages.ChooseBillCategory, bill.Description), markup, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// HandleBillSetCategory moves a bill to the chosen category
+func (h *BotHandler) HandleBillSetCategory(c tele.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Respond()
+	}
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg})
+	}
+	categories, err := h.categoryService.ListCategories(user.UserID)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	index, err := strconv.Atoi(args[1])
+	if err != nil || index < 0 || index >= len(categories) {
+		return c.Respond()
+	}
+
+	return h.updateBillFromCallback(c, servicedtos.UpdateBillDTO{Category: categories[index].CategoryID})
+}
+
+// HandleBillDate shows the last days a bill's date can be moved to
+func (h *BotHandler) HandleBillDate(c tele.Context) error {
+	bill, _, err := h.callbackBill(c)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	markup := &tele.ReplyMarkup{}
+	today := time.Now()
+	rows := make([]tele.Row, 0, dateChoiceDays/2+2)
+	var row tele.Row
+	for i := 0; i < dateChoiceDays; i++ {
+		day := today.AddDate(0, 0, -i).Format("2006-01-02")
+		label := day
+		switch i {
+		case 0:
+			label = h.messages.ButtonToday
+		case 1:
+			label = h.messages.ButtonYesterday
+		}
+		row = append(row, markup.Data(label, BtnBillSetDate.Unique, bill.BillId, day))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, markup.Row(markup.Data(h.messages.ButtonBack, BtnBillBack.Unique, bill.BillId)))
+	markup.Inline(rows...)
+
+	_ = c.Respond()
+	return c.Edit(fmt.Sprintf(h.messages.ChooseBillDate, bill.Description, bill.Date.Format("2006-01-02")), markup, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// HandleBillSetDate moves a bill to the chosen date, its amounts are reconverted for that day
+func (h *BotHandler) HandleBillSetDate(c tele.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Respond()
+	}
+	date, err := time.Parse("2006-01-02", args[1])
+	if err != nil {
+		return c.Respond()
+	}
+
+	return h.updateBillFromCallback(c, servicedtos.UpdateBillDTO{Date: date})
+}
+
+// HandleBillDelete asks for confirmation before deleting a bill
+func (h *BotHandler) HandleBillDelete(c tele.Context) error {
+	bill, _, err := h.callbackBill(c)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(
+		markup.Data(h.messages.ButtonConfirmDelete, BtnBillConfirmDelete.Unique, bill.BillId),
+		markup.Data(h.messages.ButtonBack, BtnBillBack.Unique, bill.BillId),
+	))
+
+	_ = c.Respond()
+	return c.Edit(fmt.Sprintf(h.messages.ConfirmDeleteBill, bill.Description), markup, &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// HandleBillConfirmDelete deletes a bill and its expenses
+func (h *BotHandler) HandleBillConfirmDelete(c tele.Context) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg})
+	}
+
+	if err := h.billWithExpensesService.DeleteBillWithExpenses(callbackBillID(c), user.UserID); err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	_ = c.Respond()
+	return c.Edit(h.messages.BillDeleted)
+}
+
+// HandleBillBack shows the bill card again with its actions
+func (h *BotHandler) HandleBillBack(c tele.Context) error {
+	bill, expenses, err := h.callbackBill(c)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	_ = c.Respond()
+	card := h.billCard(bill.Description, bill.Currency, bill.AmountOriginal, bill.Date, bill.Category, len(expenses))
+	return c.Edit(card, h.billCardMarkup(bill.BillId), &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// updateBillFromCallback applies a change picked on the keyboard and shows the updated card
+func (h *BotHandler) updateBillFromCallback(c tele.Context, dto servicedtos.UpdateBillDTO) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorProcessingMsg})
+	}
+
+	bill, expenses, err := h.billWithExpensesService.UpdateBill(callbackBillID(c), user.UserID, dto)
+	if err != nil {
+		return h.respondBillError(c, err)
+	}
+
+	_ = c.Respond(&tele.CallbackResponse{Text: h.messages.BillUpdated})
+	card := h.billCard(bill.Description, bill.Currency, bill.AmountOriginal, bill.Date, bill.Category, len(expenses))
+	return c.Edit(card, h.billCardMarkup(bill.BillId), &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// callbackBill loads the bill of a button press, checking it belongs to the sender
+func (h *BotHandler) callbackBill(c tele.Context) (*coreentities.Bill, []*coreentities.Expense, error) {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user for Telegram ID %d: %w", c.Sender().ID, err)
+	}
+	return h.billWithExpensesService.GetBillWithExpenses(callbackBillID(c), user.UserID)
+}
+
+// respondBillError answers a bill button press that failed
+func (h *BotHandler) respondBillError(c tele.Context, err error) error {
+	if errors.Is(err, services.ErrBillNotFound) || errors.Is(err, services.ErrUnauthorized) {
+		_ = c.Respond(&tele.CallbackResponse{Text: h.messages.BillNotFound})
+		return c.Edit(h.messages.BillNotFound)
+	}
+	log.Printf("Failed to process bill %s: %v", callbackBillID(c), err)
+	return c.Respond(&tele.CallbackResponse{Text: h.messages.ErrorUpdateBill, ShowAlert: true})
+}
+
+func (h *BotHandler) billCard(description string, currency string, amount float64, date time.Time, category string, items int) string {
+	return fmt.Sprintf(h.messages.BillCard, description, currency, amount, date.Format("2006-01-02"), category, items)
+}
+
+// categoryButtonLabel shows a category with its icon
+func categoryButtonLabel(category *coreentities.Category) string {
+	if category.Icon == "" {
+		return category.Name
+	}
+	return category.Icon + " " + category.Name
+}
+
+func (h *BotHandler) billCardMarkup(billID string) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(
+		markup.Row(
+			markup.Data(h.messages.ButtonBillItems, BtnBillItems.Unique, billID),
+			markup.Data(h.messages.ButtonBillCategory, BtnBillCategory.Unique, billID),
+		),
+		markup.Row(
+			markup.Data(h.messages.ButtonBillDate, BtnBillDate.Unique, billID),
+			markup.Data(h.messages.ButtonBillDelete, BtnBillDelete.Unique, billID),
+		),
+	)
+	return markup
+}
+
+// callbackBillID returns the bill ID, the first value of a bill button's data
+func callbackBillID(c tele.Context) string {
+	if args := c.Args(); len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
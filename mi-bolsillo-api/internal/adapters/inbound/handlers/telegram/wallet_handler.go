@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	tele "gopkg.in/telebot.v3"
+)
+
+// errGroupWithoutWallet is returned for group chats that don't post into a shared wallet
+var errGroupWithoutWallet = errors.New("group chat has no shared wallet")
+
+// handleWalletInvite joins the wallet of an invite opened from its t.me link. Opened in a group,
+// the group then posts its expenses and receipts into the wallet
+func (h *BotHandler) handleWalletInvite(c tele.Context, code string) error {
+	user, err := h.accountLinkService.GetOrCreateUserByTelegramID(c.Sender().ID)
+	if err != nil {
+		log.Printf("Failed to get user for Telegram ID %d: %v", c.Sender().ID, err)
+		return c.Send(h.messages.ErrorProcessingMsg)
+	}
+
+	wallet, err := h.walletService.JoinWallet(code, user.UserID)
+	if err != nil {
+		return h.respondWalletError(c, err)
+	}
+
+	if !isGroupChat(c.Chat()) {
+		return c.Send(fmt.Sprintf(h.messages.WalletJoined, wallet.Name), &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+	}
+
+	wallet, err = h.walletService.LinkTelegramChat(wallet.WalletID, user.UserID, c.Chat().ID)
+	if err != nil {
+		return h.respondWalletError(c, err)
+	}
+	return c.Send(fmt.Sprintf(h.messages.WalletGroupLinked, wallet.Name), &tele.SendOptions{ParseMode: tele.ModeMarkdown})
+}
+
+// chatWalletID returns the shared wallet the chat posts into, empty for private chats
+func (h *BotHandler) chatWalletID(c tele.Context, userID string) (string, error) {
+	if !isGroupChat(c.Chat()) {
+		return "", nil
+	}
+
+	wallet, err := h.walletService.FindChatWallet(c.Chat().ID, userID)
+	if err != nil {
+		return "", err
+	}
+	if wallet == nil {
+		return "", errGroupWithoutWallet
+	}
+	return wallet.WalletID, nil
+}
+
+// respondWalletError tells the user why the chat's wallet can't be used
+func (h *BotHandler) respondWalletError(c tele.Context, err error) error {
+	switch {
+	case errors.Is(err, errGroupWithoutWallet):
+		return c.Send(h.messages.WalletGroupNotLinked)
+	case errors.Is(err, services.ErrWalletInviteNotFound), errors.Is(err, services.ErrWalletInviteExpired):
+		return c.Send(h.messages.WalletInviteInvalid)
+	case errors.Is(err, services.ErrUnauthorizedWallet), errors.Is(err, services.ErrWalletNotFound):
+		return c.Send(h.messages.WalletNoAccess)
+	}
+	log.Printf("Failed to use shared wallet of chat %d: %v", c.Chat().ID, err)
+	return c.Send(h.messages.ErrorProcessingMsg)
+}
+
+func isGroupChat(chat *tele.Chat) bool {
+	return chat != nil && (chat.Type == tele.ChatGroup || chat.Type == tele.ChatSuperGroup)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type UserHandler struct {
+	userPreferencesService *services.UserPreferencesService
+	accountLinkService     *services.AccountLinkService
+}
+
+func NewUserHandler(userPreferencesService *services.UserPreferencesService, accountLinkService *services.AccountLinkService) *UserHandler {
+	return &UserHandler{
+		userPreferencesService: userPreferencesService,
+		accountLinkService:     accountLinkService,
+	}
+}
+
+type PreferencesResponse struct {
+	ReportingCurrency string `json:"reportingCurrency" example:"PEN"`
+}
+
+type UpdatePreferencesRequest struct {
+	ReportingCurrency string `json:"reportingCurrency" example:"USD"`
+}
+
+// GetPreferences godoc
+// @Summary Get user preferences
+// @Description Returns the preferences of the authenticated user, such as the reporting currency
+// @Tags users
+// @Produce json
+// @Success 200 {object} PreferencesResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /users/me/preferences [get]
+func (h *UserHandler) GetPreferences(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	return c.JSON(http.StatusOK, PreferencesResponse{
+		ReportingCurrency: user.ReportingCurrency,
+	})
+}
+
+// UpdatePreferences godoc
+// @Summary Update user preferences
+// @Description Updates the reporting currency of the authenticated user and converts existing bills into it
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body UpdatePreferencesRequest true "Preferences"
+// @Success 200 {object} PreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /users/me/preferences [put]
+func (h *UserHandler) UpdatePreferences(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	var req UpdatePreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.userPreferencesService.UpdateReportingCurrency(user, req.ReportingCurrency); err != nil {
+		if errors.Is(err, services.ErrUnsupportedCurrency) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Unsupported currency",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update preferences",
+		})
+	}
+
+	return c.JSON(http.StatusOK, PreferencesResponse{
+		ReportingCurrency: user.ReportingCurrency,
+	})
+}
@@ -0,0 +1,444 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	handlerdtos "github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/dtos"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/inbound/handlers/mappers"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services"
+	"github.com/labstack/echo/v4"
+)
+
+type WalletHandler struct {
+	walletService      *services.WalletService
+	accountLinkService *services.AccountLinkService
+	botUsername        string
+}
+
+func NewWalletHandler(walletService *services.WalletService, accountLinkService *services.AccountLinkService, botUsername string) *WalletHandler {
+	return &WalletHandler{
+		walletService:      walletService,
+		accountLinkService: accountLinkService,
+		botUsername:        botUsername,
+	}
+}
+
+// ListWallets godoc
+// @Summary List shared wallets
+// @Description Returns the shared wallets the authenticated user is a member of
+// @Tags wallets
+// @Produce json
+// @Success 200 {array} entities.Wallet
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to retrieve wallets"
+// @Security BearerAuth
+// @Router /wallets [get]
+func (h *WalletHandler) ListWallets(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	wallets, err := h.walletService.ListWallets(user.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve wallets",
+		})
+	}
+
+	return c.JSON(http.StatusOK, wallets)
+}
+
+// CreateWallet godoc
+// @Summary Create a shared wallet
+// @Description Creates a wallet owned by the authenticated user, e.g. for a household's joint expenses
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param request body dtos.WalletRequest true "Wallet data"
+// @Success 201 {object} entities.Wallet
+// @Failure 400 {object} map[string]string "Invalid wallet"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 500 {object} map[string]string "Failed to create wallet"
+// @Security BearerAuth
+// @Router /wallets [post]
+func (h *WalletHandler) CreateWallet(c echo.Context) error {
+	var req handlerdtos.WalletRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	wallet, err := h.walletService.CreateWallet(user.UserID, req.Name)
+	if err != nil {
+		return walletErrorResponse(c, err, "Failed to create wallet")
+	}
+
+	return c.JSON(http.StatusCreated, wallet)
+}
+
+// GetWallet godoc
+// @Summary Get a shared wallet
+// @Description Returns a wallet the authenticated user is a member of, with its members and the user's role.
+// @Description Its bills are listed with GET /bills?walletId=
+// @Tags wallets
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} dtos.WalletResponse
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to retrieve wallet"
+// @Security BearerAuth
+// @Router /wallets/{id} [get]
+func (h *WalletHandler) GetWallet(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	wallet, err := h.walletService.GetWallet(c.Param("id"), user.UserID)
+	if err != nil {
+		return walletErrorResponse(c, err, "Failed to retrieve wallet")
+	}
+
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// UpdateWallet godoc
+// @Summary Rename a shared wallet
+// @Description Renames a wallet, only its owner can
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param request body dtos.WalletRequest true "Wallet data"
+// @Success 200 {object} entities.Wallet
+// @Failure 400 {object} map[string]string "Invalid wallet"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to update wallet"
+// @Security BearerAuth
+// @Router /wallets/{id} [put]
+func (h *WalletHandler) UpdateWallet(c echo.Context) error {
+	var req handlerdtos.WalletRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	wallet, err := h.walletService.RenameWallet(c.Param("id"), user.UserID, req.Name)
+	if err != nil {
+		return walletErrorResponse(c, err, "Failed to update wallet")
+	}
+
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// DeleteWallet godoc
+// @Summary Delete a shared wallet
+// @Description Deletes a wallet, only its owner can. Its bills go back to the members who created them
+// @Tags wallets
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} map[string]string "Wallet deleted successfully"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to delete wallet"
+// @Security BearerAuth
+// @Router /wallets/{id} [delete]
+func (h *WalletHandler) DeleteWallet(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.walletService.DeleteWallet(c.Param("id"), user.UserID); err != nil {
+		return walletErrorResponse(c, err, "Failed to delete wallet")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Wallet deleted successfully",
+	})
+}
+
+// CreateInvite godoc
+// @Summary Invite someone to a shared wallet
+// @Description Creates an invite code to join the wallet as an editor or a viewer, only the owner can invite.
+// @Description The Telegram links join from the bot or add the bot to a group that posts into the wallet
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param request body dtos.WalletInviteRequest false "Invite data"
+// @Success 201 {object} dtos.WalletInviteResponse
+// @Failure 400 {object} map[string]string "Invalid role"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet not found"
+// @Failure 500 {object} map[string]string "Failed to create invite"
+// @Security BearerAuth
+// @Router /wallets/{id}/invites [post]
+func (h *WalletHandler) CreateInvite(c echo.Context) error {
+	var req handlerdtos.WalletInviteRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	invite, err := h.walletService.CreateInvite(c.Param("id"), user.UserID, req.Role)
+	if err != nil {
+		return walletErrorResponse(c, err, "Failed to create invite")
+	}
+
+	return c.JSON(http.StatusCreated, mappers.ToWalletInviteResponse(invite, h.botUsername))
+}
+
+// JoinWallet godoc
+// @Summary Join a shared wallet
+// @Description Joins the wallet of an invite code with the invite's role, members keep their current role
+// @Tags wallets
+// @Produce json
+// @Param code path string true "Invite code"
+// @Success 200 {object} entities.Wallet
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 404 {object} map[string]string "Invite not found"
+// @Failure 410 {object} map[string]string "Invite has expired"
+// @Failure 500 {object} map[string]string "Failed to join wallet"
+// @Security BearerAuth
+// @Router /wallets/join/{code} [post]
+func (h *WalletHandler) JoinWallet(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	wallet, err := h.walletService.JoinWallet(c.Param("code"), user.UserID)
+	if err != nil {
+		return walletErrorResponse(c, err, "Failed to join wallet")
+	}
+
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// UpdateMember godoc
+// @Summary Change the role of a wallet member
+// @Description Makes a member an editor or a viewer, only the owner can
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param userId path string true "User ID of the member"
+// @Param request body dtos.WalletMemberRequest true "Member data"
+// @Success 200 {object} entities.WalletMember
+// @Failure 400 {object} map[string]string "Invalid role"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet or member not found"
+// @Failure 500 {object} map[string]string "Failed to update member"
+// @Security BearerAuth
+// @Router /wallets/{id}/members/{userId} [put]
+func (h *WalletHandler) UpdateMember(c echo.Context) error {
+	var req handlerdtos.WalletMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	member, err := h.walletService.UpdateMemberRole(c.Param("id"), user.UserID, c.Param("userId"), req.Role)
+	if err != nil {
+		return walletErrorResponse(c, err, "Failed to update member")
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from a wallet
+// @Description The owner removes a member, other members can only remove themselves to leave the wallet
+// @Tags wallets
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param userId path string true "User ID of the member"
+// @Success 200 {object} map[string]string "Member removed successfully"
+// @Failure 400 {object} map[string]string "The owner can't leave the wallet"
+// @Failure 401 {object} map[string]string "User ID not found in context"
+// @Failure 403 {object} map[string]string "Access denied to this wallet"
+// @Failure 404 {object} map[string]string "Wallet or member not found"
+// @Failure 500 {object} map[string]string "Failed to remove member"
+// @Security BearerAuth
+// @Router /wallets/{id}/members/{userId} [delete]
+func (h *WalletHandler) RemoveMember(c echo.Context) error {
+	// Get Clerk ID from context (set by Clerk auth middleware)
+	clerkID, ok := c.Get("userID").(string)
+	if !ok || clerkID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	// Get or create user by Clerk ID
+	user, err := h.accountLinkService.GetOrCreateUserByClerkID(clerkID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get user information",
+		})
+	}
+
+	if err := h.walletService.RemoveMember(c.Param("id"), user.UserID, c.Param("userId")); err != nil {
+		return walletErrorResponse(c, err, "Failed to remove member")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Member removed successfully",
+	})
+}
+
+// walletErrorResponse maps wallet service errors to HTTP responses
+func walletErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidWallet):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrUnauthorizedWallet):
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Access denied to this wallet",
+		})
+	case errors.Is(err, services.ErrWalletNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Wallet not found",
+		})
+	case errors.Is(err, services.ErrWalletMemberNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Member not found",
+		})
+	case errors.Is(err, services.ErrWalletInviteNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Invite not found",
+		})
+	case errors.Is(err, services.ErrWalletInviteExpired):
+		return c.JSON(http.StatusGone, map[string]string{
+			"error": "Invite has expired",
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// newTestS3Store connects to the S3-compatible service at S3_TEST_ENDPOINT, e.g. the local MinIO
+// started with `make start-minio`, creating the bucket when needed. Tests are skipped without it
+func newTestS3Store(t *testing.T) *S3Store {
+	t.Helper()
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set, run `make start-minio` and then `make test-s3` to test against a local MinIO")
+	}
+
+	store := NewS3Store(endpoint, testEnv("S3_TEST_REGION", "us-east-1"), testEnv("S3_TEST_BUCKET", "mi-bolsillo-test"),
+		testEnv("S3_TEST_ACCESS_KEY_ID", "minioadmin"), testEnv("S3_TEST_SECRET_ACCESS_KEY", "minioadmin"))
+
+	req, err := http.NewRequest(http.MethodPut, store.endpoint+"/"+store.bucket, nil)
+	if err != nil {
+		t.Fatalf("failed to create bucket request: %v", err)
+	}
+	store.sign(req, nil)
+	resp, err := store.httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+	defer resp.Body.Close()
+	// 409 means the bucket already exists
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		t.Fatalf("failed to create bucket: %v", store.responseError(resp))
+	}
+
+	return store
+}
+
+func testEnv(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func TestS3StoreRoundTrip(t *testing.T) {
+	store := newTestS3Store(t)
+	key := "receipts/test user/" + time.Now().Format("20060102150405.000000000") + "+1.jpg"
+	data := []byte("receipt photo")
+
+	if err := store.Put(key, data, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	t.Cleanup(func() { store.Delete(key) })
+
+	body, contentType, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer body.Close()
+	got, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read blob: %v", err)
+	}
+	if string(got) != string(data) || contentType != "image/jpeg" {
+		t.Errorf("Get() = %q %s, want %q image/jpeg", got, contentType, data)
+	}
+
+	if err := store.Delete(key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := store.Get(key); !errors.Is(err, ports.ErrBlobNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrBlobNotFound", err)
+	}
+}
+
+func TestS3StoreDeleteMissing(t *testing.T) {
+	store := newTestS3Store(t)
+
+	if err := store.Delete("receipts/missing.jpg"); err != nil {
+		t.Errorf("Delete() of a missing blob error = %v", err)
+	}
+}
+
+func TestEscapeKey(t *testing.T) {
+	if got := escapeKey("receipts/user 1/a+b.jpg"); got != "receipts/user%201/a%2Bb.jpg" {
+		t.Errorf("escapeKey() = %s", got)
+	}
+}
@@ -0,0 +1,72 @@
+package exchangerate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// FileFetcher serves rates from a local JSON file, useful offline and as a test stand-in.
+// The file is a list of daily rates:
+//
+//	[{"date": "2025-10-10", "baseCurrency": "USD", "quoteCurrency": "PEN", "rate": 3.75}]
+//
+// When the exact date is missing, the most recent earlier rate for the pair (or its inverse) is returned
+// with its own date, so callers can tell it apart from that day's rate
+type FileFetcher struct {
+	path string
+}
+
+func NewFileFetcher(path string) *FileFetcher {
+	return &FileFetcher{path: path}
+}
+
+type fileRate struct {
+	Date          string  `json:"date"`
+	BaseCurrency  string  `json:"baseCurrency"`
+	QuoteCurrency string  `json:"quoteCurrency"`
+	Rate          float64 `json:"rate"`
+}
+
+func (f *FileFetcher) Name() string {
+	return "file"
+}
+
+func (f *FileFetcher) FetchRate(baseCurrency string, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates file: %w", err)
+	}
+
+	var rates []fileRate
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exchange rates file: %w", err)
+	}
+
+	day := date.Format("2006-01-02")
+	if rate := findLatestRate(rates, baseCurrency, quoteCurrency, day); rate != nil {
+		return &entities.ExchangeRate{Date: rate.Date, BaseCurrency: baseCurrency, QuoteCurrency: quoteCurrency, Rate: rate.Rate}, nil
+	}
+	if inverse := findLatestRate(rates, quoteCurrency, baseCurrency, day); inverse != nil {
+		return &entities.ExchangeRate{Date: inverse.Date, BaseCurrency: baseCurrency, QuoteCurrency: quoteCurrency, Rate: 1 / inverse.Rate}, nil
+	}
+
+	return nil, fmt.Errorf("no %s/%s rate on or before %s in %s", baseCurrency, quoteCurrency, day, f.path)
+}
+
+func findLatestRate(rates []fileRate, baseCurrency string, quoteCurrency string, day string) *fileRate {
+	var best *fileRate
+	for i := range rates {
+		rate := &rates[i]
+		if rate.BaseCurrency != baseCurrency || rate.QuoteCurrency != quoteCurrency || rate.Date > day || rate.Rate <= 0 {
+			continue
+		}
+		if best == nil || rate.Date > best.Date {
+			best = rate
+		}
+	}
+	return best
+}
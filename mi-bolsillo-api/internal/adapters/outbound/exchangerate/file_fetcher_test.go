@@ -0,0 +1,57 @@
+package exchangerate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeRatesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "exchange_rates.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write rates file: %v", err)
+	}
+	return path
+}
+
+func TestFileFetcherFetchRate(t *testing.T) {
+	path := writeRatesFile(t, `[
+		{"date": "2025-10-01", "baseCurrency": "USD", "quoteCurrency": "PEN", "rate": 3.5},
+		{"date": "2025-10-10", "baseCurrency": "USD", "quoteCurrency": "PEN", "rate": 3.75}
+	]`)
+	fetcher := NewFileFetcher(path)
+
+	tests := []struct {
+		name     string
+		base     string
+		quote    string
+		date     time.Time
+		wantDate string
+		wantRate float64
+	}{
+		{"exact date", "USD", "PEN", time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC), "2025-10-10", 3.75},
+		{"gap uses the latest earlier rate with its own date", "USD", "PEN", time.Date(2025, 10, 5, 0, 0, 0, 0, time.UTC), "2025-10-01", 3.5},
+		{"inverse pair", "PEN", "USD", time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC), "2025-10-10", 1 / 3.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := fetcher.FetchRate(tt.base, tt.quote, tt.date)
+			if err != nil {
+				t.Fatalf("FetchRate() error = %v", err)
+			}
+			if rate.Date != tt.wantDate || rate.Rate != tt.wantRate {
+				t.Errorf("FetchRate() = %s %v, want %s %v", rate.Date, rate.Rate, tt.wantDate, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestFileFetcherFetchRateBeforeFirstRate(t *testing.T) {
+	path := writeRatesFile(t, `[{"date": "2025-10-10", "baseCurrency": "USD", "quoteCurrency": "PEN", "rate": 3.75}]`)
+
+	if _, err := NewFileFetcher(path).FetchRate("USD", "PEN", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("FetchRate() expected an error before the first rate")
+	}
+}
@@ -0,0 +1,86 @@
+package exchangerate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// HTTPFetcher retrieves historical rates from a currency-api compatible source
+// (https://github.com/fawazahmed0/exchange-api), which covers PEN, CLP and COP among ~200 currencies.
+// urlTemplate holds {date} and {base} placeholders:
+//
+//	GET .../currency-api@2025-10-10/v1/currencies/usd.json -> {"date": "2025-10-10", "usd": {"pen": 3.75}}
+//
+// Pointing urlTemplate at a local server makes it usable as a stand-in during development and tests
+type HTTPFetcher struct {
+	urlTemplate string
+	httpClient  *http.Client
+}
+
+func NewHTTPFetcher(urlTemplate string) *HTTPFetcher {
+	return &HTTPFetcher{
+		urlTemplate: urlTemplate,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (f *HTTPFetcher) Name() string {
+	return "http"
+}
+
+// FetchRate returns how many units of quoteCurrency one unit of baseCurrency was worth on date
+func (f *HTTPFetcher) FetchRate(baseCurrency string, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error) {
+	base := strings.ToLower(baseCurrency)
+	reqURL := strings.NewReplacer("{date}", date.Format("2006-01-02"), "{base}", base).Replace(f.urlTemplate)
+
+	resp, err := f.httpClient.Get(reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	// The rates are keyed by the lowercase base currency next to the publication date
+	var ratesResp map[string]json.RawMessage
+	if err := json.Unmarshal(body, &ratesResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exchange rate response: %w", err)
+	}
+
+	var rateDate string
+	if err := json.Unmarshal(ratesResp["date"], &rateDate); err != nil {
+		return nil, fmt.Errorf("failed to read exchange rate date: %w", err)
+	}
+
+	var rates map[string]float64
+	if err := json.Unmarshal(ratesResp[base], &rates); err != nil {
+		return nil, fmt.Errorf("failed to read %s rates: %w", baseCurrency, err)
+	}
+
+	rate, ok := rates[strings.ToLower(quoteCurrency)]
+	if !ok || rate <= 0 {
+		return nil, fmt.Errorf("no %s/%s rate in exchange rate response", baseCurrency, quoteCurrency)
+	}
+
+	return &entities.ExchangeRate{
+		Date:          rateDate,
+		BaseCurrency:  baseCurrency,
+		QuoteCurrency: quoteCurrency,
+		Rate:          rate,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package exchangerate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newRatesServer stands in for the rates API, serving the USD rates of a single day
+func newRatesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2025-10-10/usd.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"date": "2025-10-10", "usd": {"pen": 3.75, "clp": 940.5, "cop": 3900}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPFetcherFetchRate(t *testing.T) {
+	server := newRatesServer(t)
+	fetcher := NewHTTPFetcher(server.URL + "/{date}/{base}.json")
+
+	rate, err := fetcher.FetchRate("USD", "PEN", time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FetchRate() error = %v", err)
+	}
+	if rate.Date != "2025-10-10" || rate.Rate != 3.75 || rate.BaseCurrency != "USD" || rate.QuoteCurrency != "PEN" {
+		t.Errorf("FetchRate() = %+v, want USD/PEN 3.75 on 2025-10-10", rate)
+	}
+}
+
+func TestHTTPFetcherFetchRateErrors(t *testing.T) {
+	server := newRatesServer(t)
+	fetcher := NewHTTPFetcher(server.URL + "/{date}/{base}.json")
+
+	tests := []struct {
+		name  string
+		base  string
+		quote string
+		date  time.Time
+	}{
+		{"missing day", "USD", "PEN", time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC)},
+		{"missing quote currency", "USD", "EUR", time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := fetcher.FetchRate(tt.base, tt.quote, tt.date); err == nil {
+				t.Error("FetchRate() expected an error")
+			}
+		})
+	}
+}
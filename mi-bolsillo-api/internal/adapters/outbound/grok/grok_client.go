@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/llm"
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
 )
 
@@ -26,20 +27,6 @@ func NewGrokClient(apiKey string) *GrokClient {
 	}
 }
 
-type BillItem struct {
-	Description string  `json:"description"`
-	Amount      float64 `json:"amount"`
-	Category    string  `json:"category"`
-}
-
-type ParsedBillData struct {
-	Items        []BillItem `json:"items"`
-	TotalAmount  float64    `json:"total_amount"`
-	Currency     string     `json:"currency"`
-	Date         string     `json:"date"`
-	MerchantName string     `json:"merchant_name"`
-}
-
 type grokRequest struct {
 	Messages []grokMessage `json:"messages"`
 	Model    string        `json:"model"`
@@ -74,7 +61,9 @@ type grokResponseMessage struct {
 	Content string `json:"content"`
 }
 
-func (c *GrokClient) ParseBillImage(imageData []byte) (*ParsedBillData, error) {
+// ParseBillImage extracts the items of a receipt photo
+// Implements the ports.ReceiptParser interface
+func (c *GrokClient) ParseBillImage(imageData []byte, categories []string) (*entities.ParsedBill, error) {
 	// Encode image to base64
 	base64Image := base64.StdEncoding.EncodeToString(imageData)
 	dataURL := fmt.Sprintf("data:image/jpeg;base64,%s", base64Image)
@@ -95,33 +84,40 @@ func (c *GrokClient) ParseBillImage(imageData []byte) (*ParsedBillData, error) {
 					},
 					{
 						Type: "text",
-						Text: `Analyze this bill/receipt image and extract the following information in JSON format:
-{
-  "items": [
-    {
-      "description": "item name",
-      "amount": numeric_amount,
-      "category": "Food|Transportation|Entertainment|Shopping|Utilities|Healthcare|Other"
-    }
-  ],
-  "total_amount": numeric_total,
-  "currency": "USD|PEN|EUR|etc",
-  "date": "YYYY-MM-DD",
-  "merchant_name": "store/restaurant name"
+						Text: llm.ReceiptPrompt(categories),
+					},
+				},
+			},
+		},
+	}
+
+	return c.requestBill(reqBody)
 }
 
-Rules:
-- Extract ALL line items from the receipt
-- Categorize each item appropriately
-- Use the currency symbol or text to determine the currency (default to USD if unclear)
-- Extract the date in YYYY-MM-DD format (use today's date if not visible)
-- Return ONLY valid JSON, no additional text or explanation`,
+// ParseBillText extracts the items of a receipt from text read out of a document such as a PDF
+// Implements the ports.ReceiptParser interface
+func (c *GrokClient) ParseBillText(text string, categories []string) (*entities.ParsedBill, error) {
+	reqBody := grokRequest{
+		Model:  "grok-4-fast-non-reasoning",
+		Stream: false,
+		Messages: []grokMessage{
+			{
+				Role: "user",
+				Content: []grokContent{
+					{
+						Type: "text",
+						Text: llm.ReceiptTextPrompt(categories) + text,
 					},
 				},
 			},
 		},
 	}
 
+	return c.requestBill(reqBody)
+}
+
+// requestBill sends a receipt parsing request and decodes the bill in the response
+func (c *GrokClient) requestBill(reqBody grokRequest) (*entities.ParsedBill, error) {
 	// Marshal request to JSON
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -168,8 +164,8 @@ Rules:
 	content := grokResp.Choices[0].Message.Content
 
 	// Parse the bill data from the content
-	var parsedData ParsedBillData
-	if err := json.Unmarshal([]byte(content), &parsedData); err != nil {
+	var parsedData entities.ParsedBill
+	if err := json.Unmarshal([]byte(llm.ExtractJSON(content)), &parsedData); err != nil {
 		return nil, fmt.Errorf("failed to parse bill data from response: %w", err)
 	}
 
@@ -190,19 +186,23 @@ type grokTextMessage struct {
 
 // DetectIntent analyzes user text and determines their intent
 // Implements the ports.IntentDetector interface
-func (c *GrokClient) DetectIntent(userText string) (*entities.Intent, error) {
+func (c *GrokClient) DetectIntent(userText string, categories []string) (*entities.Intent, error) {
 	systemPrompt := `Eres un clasificador de intención para una aplicación de gestión de gastos y facturas.
 Analiza el mensaje del usuario y determina su intención. Devuelve SOLO un objeto JSON válido con esta estructura:
 {
-  "type": "list_bills|summary_bills|upload_bill|create_expense|unknown",
+  "type": "list_bills|summary_bills|upload_bill|create_expense|create_income|unknown",
   "confidence": 0.0-1.0,
   "parameters": {
     "period": "last_month|this_month|last_week|all_time" (para summary_bills),
     "limit": número (para list_bills, cuántas facturas mostrar),
-    "amount": número (para create_expense, el monto gastado),
-    "description": "texto" (para create_expense, descripción del gasto),
-    "category": "Food|Transportation|Entertainment|Shopping|Utilities|Healthcare|Other" (para create_expense),
-    "merchant": "texto" (para create_expense, nombre del lugar opcional)
+    "amount": número (para create_expense, el monto gastado; para create_income, el monto recibido),
+    "description": "texto" (para create_expense, descripción del gasto; para create_income, de quién o por qué lo recibió),
+    "category": "` + llm.CategoryList(categories) + `" (para create_expense),
+    "currency": "código ISO 4217, ej. PEN, USD, EUR" (para create_expense y create_income, solo si el usuario menciona la moneda),
+    "merchant": "texto" (para create_expense, nombre del lugar opcional),
+    "date": "YYYY-MM-DD" (para create_expense y create_income, solo si el usuario menciona cuándo fue, ej. "ayer"),
+    "income_type": "salary|freelance|refund|other" (para create_income),
+    "card_last_four": "4 dígitos" (para create_expense, solo si el usuario menciona los últimos 4 dígitos de la tarjeta con la que pagó)
   }
 }
 
@@ -211,15 +211,21 @@ Tipos de intención:
 - summary_bills: El usuario quiere un resumen/total de facturas por un período (ej: "cuánto gasté el mes pasado", "resumen de este mes", "gastos totales")
 - upload_bill: El usuario quiere subir/agregar una factura (esto se detecta cuando envían una imagen, no texto)
 - create_expense: El usuario quiere registrar un gasto individual (ej: "gasté 100 soles en wong", "pagué 50 soles de taxi", "compré 25 soles de comida"). Extrae el monto, descripción/comerciante y categoriza apropiadamente.
+- create_income: El usuario quiere registrar dinero que recibió (ej: "me pagaron 3000 soles", "cobré 500 dólares de un freelance", "me devolvieron 80 soles"). Extrae el monto y el tipo: salary para sueldo o quincena, freelance para trabajos independientes o proyectos, refund para devoluciones o reembolsos, other para lo demás.
 - unknown: No se puede determinar la intención o está preguntando otra cosa
 
 Reglas para create_expense:
 - Extrae el monto numérico del mensaje
+- Si el usuario menciona la moneda (soles, dólares, euros, pesos chilenos, etc.) devuélvela como código ISO 4217
 - Identifica el comerciante o descripción del gasto
-- Categoriza según el contexto: Food (comida, restaurante, supermercado), Transportation (taxi, bus, transporte), Shopping (compras), Entertainment (entretenimiento), Utilities (servicios), Healthcare (salud), Other (otro)
+- Categoriza según el contexto usando exactamente una de las categorías del usuario listadas arriba, tal como está escrita; prefiere la subcategoría más específica ("Padre > Hija") que encaje
 - Detecta patrones como "gasté X en Y", "pagué X de Y", "compré X en Y"
+- Si el usuario dice con qué tarjeta pagó y menciona sus últimos 4 dígitos (ej: "con mi visa 4321", "con la tarjeta terminada en 0987"), devuélvelos en card_last_four
+- Si falta el monto o la categoría no es clara, omite ese parámetro en lugar de inventarlo
+
+Devuelve SOLO JSON válido, sin texto adicional.
 
-Devuelve SOLO JSON válido, sin texto adicional.`
+La fecha de hoy es ` + time.Now().Format("2006-01-02")
 
 	reqBody := grokTextRequest{
 		Model:  "grok-4-fast-non-reasoning",
@@ -0,0 +1,205 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+)
+
+const (
+	// igvSchemeID is the SUNAT catalogue 05 code of the IGV tax
+	igvSchemeID = "1000"
+	// serviceChargeReasonCode is the SUNAT catalogue 53 code of the "recargo al consumo y/o propinas" charge
+	serviceChargeReasonCode = "46"
+)
+
+// ublInvoice maps the parts of a UBL 2.1 Invoice used by SUNAT (facturas and boletas) that a bill needs.
+// Elements are matched by local name, so the cbc/cac namespace prefixes don't matter
+type ublInvoice struct {
+	XMLName       xml.Name      `xml:"Invoice"`
+	ID            string        `xml:"ID"`
+	IssueDate     string        `xml:"IssueDate"`
+	Currency      string        `xml:"DocumentCurrencyCode"`
+	Supplier      ublParty      `xml:"AccountingSupplierParty"`
+	Charges       []ublCharge   `xml:"AllowanceCharge"`
+	TaxTotals     []ublTaxTotal `xml:"TaxTotal"`
+	Subtotal      string        `xml:"LegalMonetaryTotal>LineExtensionAmount"`
+	PayableAmount string        `xml:"LegalMonetaryTotal>PayableAmount"`
+	Lines         []ublLine     `xml:"InvoiceLine"`
+}
+
+type ublCharge struct {
+	ChargeIndicator string `xml:"ChargeIndicator"`
+	ReasonCode      string `xml:"AllowanceChargeReasonCode"`
+	Amount          string `xml:"Amount"`
+}
+
+type ublParty struct {
+	// UBL 2.0 invoices carry the RUC in CustomerAssignedAccountID instead of PartyIdentification
+	AccountID        string `xml:"CustomerAssignedAccountID"`
+	Identification   string `xml:"Party>PartyIdentification>ID"`
+	RegistrationName string `xml:"Party>PartyLegalEntity>RegistrationName"`
+	Name             string `xml:"Party>PartyName>Name"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount string           `xml:"TaxAmount"`
+	Subtotals []ublTaxSubtotal `xml:"TaxSubtotal"`
+}
+
+type ublTaxSubtotal struct {
+	TaxAmount  string `xml:"TaxAmount"`
+	SchemeID   string `xml:"TaxCategory>TaxScheme>ID"`
+	SchemeName string `xml:"TaxCategory>TaxScheme>Name"`
+}
+
+type ublLine struct {
+	LineExtensionAmount string        `xml:"LineExtensionAmount"`
+	TaxTotals           []ublTaxTotal `xml:"TaxTotal"`
+	Descriptions        []string      `xml:"Item>Description"`
+}
+
+// UBLParser reads SUNAT electronic invoices (UBL 2.1 XML) deterministically, without an LLM
+type UBLParser struct{}
+
+func NewUBLParser() *UBLParser {
+	return &UBLParser{}
+}
+
+// ParseInvoice extracts the issuer, serie-número, IGV and line items of a UBL invoice
+// Implements the ports.InvoiceParser interface
+func (p *UBLParser) ParseInvoice(data []byte) (*entities.ParsedBill, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+
+	var invoice ublInvoice
+	if err := decoder.Decode(&invoice); err != nil {
+		return nil, fmt.Errorf("failed to decode UBL invoice: %w", err)
+	}
+
+	total, err := parseAmount(invoice.PayableAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice total %q", invoice.PayableAmount)
+	}
+
+	subtotal, err := parseAmount(invoice.Subtotal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice subtotal %q", invoice.Subtotal)
+	}
+
+	merchant := strings.TrimSpace(invoice.Supplier.RegistrationName)
+	if merchant == "" {
+		merchant = strings.TrimSpace(invoice.Supplier.Name)
+	}
+	ruc := strings.TrimSpace(invoice.Supplier.Identification)
+	if ruc == "" {
+		ruc = strings.TrimSpace(invoice.Supplier.AccountID)
+	}
+
+	parsed := &entities.ParsedBill{
+		TotalAmount:   total,
+		Currency:      strings.ToUpper(strings.TrimSpace(invoice.Currency)),
+		Date:          strings.TrimSpace(invoice.IssueDate),
+		MerchantName:  merchant,
+		SupplierRUC:   ruc,
+		InvoiceNumber: strings.TrimSpace(invoice.ID),
+		Subtotal:      subtotal,
+		TaxAmount:     igvAmount(invoice.TaxTotals),
+		TipAmount:     serviceCharge(invoice.Charges),
+		Items:         make([]entities.ParsedBillItem, 0, len(invoice.Lines)),
+	}
+
+	for i, line := range invoice.Lines {
+		// Line amounts are before tax, the expense is what was actually paid for the line
+		amount, err := parseAmount(line.LineExtensionAmount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %q in invoice line %d", line.LineExtensionAmount, i+1)
+		}
+		for _, taxTotal := range line.TaxTotals {
+			tax, _ := parseAmount(taxTotal.TaxAmount)
+			amount += tax
+		}
+
+		description := strings.TrimSpace(strings.Join(line.Descriptions, " "))
+		if description == "" {
+			description = merchant
+		}
+
+		parsed.Items = append(parsed.Items, entities.ParsedBillItem{
+			Description: description,
+			Amount:      math.Round(amount*100) / 100,
+			Category:    "Other",
+		})
+	}
+
+	return parsed, nil
+}
+
+// igvAmount returns the IGV of an invoice, falling back to the whole tax total when
+// the subtotals don't identify it
+func igvAmount(taxTotals []ublTaxTotal) float64 {
+	var igv, total float64
+	found := false
+	for _, taxTotal := range taxTotals {
+		amount, _ := parseAmount(taxTotal.TaxAmount)
+		total += amount
+		for _, subtotal := range taxTotal.Subtotals {
+			if subtotal.SchemeID != igvSchemeID && !strings.EqualFold(subtotal.SchemeName, "IGV") {
+				continue
+			}
+			amount, _ := parseAmount(subtotal.TaxAmount)
+			igv += amount
+			found = true
+		}
+	}
+	if !found {
+		return total
+	}
+	return igv
+}
+
+// serviceCharge returns the invoice's service charge, tips show up as a document level charge
+func serviceCharge(charges []ublCharge) float64 {
+	var total float64
+	for _, charge := range charges {
+		if strings.TrimSpace(charge.ChargeIndicator) != "true" || strings.TrimSpace(charge.ReasonCode) != serviceChargeReasonCode {
+			continue
+		}
+		amount, _ := parseAmount(charge.Amount)
+		total += amount
+	}
+	return total
+}
+
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// charsetReader decodes the legacy single-byte encodings some SUNAT providers still declare.
+// encoding/xml only understands UTF-8 on its own
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1", "windows-1252", "cp1252":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	}
+	return nil, errors.New("unsupported XML encoding " + charset)
+}
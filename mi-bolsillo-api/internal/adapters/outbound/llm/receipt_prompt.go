@@ -0,0 +1,70 @@
+package llm
+
+import "strings"
+
+// DefaultCategories are offered to the model when the user's categories aren't known
+var DefaultCategories = []string{"General", "Food", "Restaurants", "Hotels", "Rent", "Transportation", "Entertainment", "Shopping", "Utilities", "Healthcare", "Other"}
+
+// ReceiptPrompt asks a vision model to extract a receipt as JSON matching entities.ParsedBill,
+// filing each item under one of the user's categories
+func ReceiptPrompt(categories []string) string {
+	return "Analyze this bill/receipt image and extract the following information in JSON format:\n" + receiptFormat(categories)
+}
+
+// ReceiptTextPrompt asks a model to extract a receipt from the text of a document, the text is appended after it
+func ReceiptTextPrompt(categories []string) string {
+	return "Analyze this bill/receipt text extracted from a PDF (the layout may be lost) and extract the following information in JSON format:\n" +
+		receiptFormat(categories) + "\n\nReceipt text:\n"
+}
+
+// CategoryList joins the categories as the alternatives of a JSON field, e.g. "Food|Other".
+// Subcategories come as "Parent > Child"
+func CategoryList(categories []string) string {
+	if len(categories) == 0 {
+		categories = DefaultCategories
+	}
+	return strings.Join(categories, "|")
+}
+
+func receiptFormat(categories []string) string {
+	return `{
+  "items": [
+    {
+      "description": "item name",
+      "amount": numeric_amount,
+      "category": "` + CategoryList(categories) + `"
+    }
+  ],
+  "total_amount": numeric_total,
+  "currency": "USD|PEN|EUR|etc",
+  "date": "YYYY-MM-DD",
+  "merchant_name": "store/restaurant name",
+  "subtotal": numeric_amount_before_tax,
+  "tax_amount": numeric_igv_or_vat,
+  "tip_amount": numeric_tip_or_service_charge,
+  "supplier_ruc": "11-digit RUC of the issuer",
+  "invoice_number": "serie-número, e.g. F001-00012345 or B001-123",
+  "card_last_four": "last 4 digits of the card used to pay"
+}
+
+Rules:
+- Extract ALL line items from the receipt
+- Categorize each item with exactly one of the categories listed above, written as listed; prefer the most specific subcategory ("Parent > Child") that fits
+- Use the currency symbol or text to determine the currency as an ISO 4217 code, e.g. S/ is PEN, € is EUR (default to USD if unclear)
+- Extract the date in YYYY-MM-DD format (use today's date if not visible)
+- subtotal is the amount before taxes (OP. GRAVADA), tax_amount the IGV or VAT and tip_amount the tip, propina or service charge (recargo al consumo); use 0 when not shown
+- supplier_ruc and invoice_number are printed on Peruvian electronic receipts (boleta/factura electrónica); use "" when not shown
+- card_last_four is read from the masked card number of a card payment, e.g. ************4321 or VISA XXXX4321 gives "4321"; use "" when paid in cash, with Yape or Plin, or when not shown
+- Return ONLY valid JSON, no additional text or explanation`
+}
+
+// ExtractJSON returns the JSON object in a model response, dropping the markdown code fences
+// or surrounding text that smaller local models tend to add
+func ExtractJSON(content string) string {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end < start {
+		return content
+	}
+	return content[start : end+1]
+}
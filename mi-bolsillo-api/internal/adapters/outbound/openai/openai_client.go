@@ -0,0 +1,165 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/llm"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+)
+
+// OpenAIClient talks to any server exposing the OpenAI chat completions API, such as OpenAI itself,
+// a local llama.cpp server or Ollama (http://localhost:11434/v1), so receipts can be parsed offline
+type OpenAIClient struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+func NewOpenAIClient(baseURL string, apiKey string, model string) *OpenAIClient {
+	return &OpenAIClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		httpClient: &http.Client{
+			// Local models on CPU are much slower than hosted ones
+			Timeout: 120 * time.Second,
+		},
+	}
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+type chatMessage struct {
+	Role    string        `json:"role"`
+	Content []chatContent `json:"content"`
+}
+
+type chatContent struct {
+	Type     string        `json:"type"`
+	Text     string        `json:"text,omitempty"`
+	ImageURL *chatImageURL `json:"image_url,omitempty"`
+}
+
+type chatImageURL struct {
+	URL string `json:"url"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// ParseBillImage extracts the items of a receipt photo
+// Implements the ports.ReceiptParser interface
+func (c *OpenAIClient) ParseBillImage(imageData []byte, categories []string) (*entities.ParsedBill, error) {
+	dataURL := fmt.Sprintf("data:%s;base64,%s", http.DetectContentType(imageData), base64.StdEncoding.EncodeToString(imageData))
+
+	reqBody := chatRequest{
+		Model:  c.model,
+		Stream: false,
+		Messages: []chatMessage{
+			{
+				Role: "user",
+				Content: []chatContent{
+					{
+						Type:     "image_url",
+						ImageURL: &chatImageURL{URL: dataURL},
+					},
+					{
+						Type: "text",
+						Text: llm.ReceiptPrompt(categories),
+					},
+				},
+			},
+		},
+	}
+
+	return c.requestBill(reqBody)
+}
+
+// ParseBillText extracts the items of a receipt from text read out of a document such as a PDF
+// Implements the ports.ReceiptParser interface
+func (c *OpenAIClient) ParseBillText(text string, categories []string) (*entities.ParsedBill, error) {
+	reqBody := chatRequest{
+		Model:  c.model,
+		Stream: false,
+		Messages: []chatMessage{
+			{
+				Role: "user",
+				Content: []chatContent{
+					{
+						Type: "text",
+						Text: llm.ReceiptTextPrompt(categories) + text,
+					},
+				},
+			},
+		},
+	}
+
+	return c.requestBill(reqBody)
+}
+
+// requestBill sends a receipt parsing request and decodes the bill in the response
+func (c *OpenAIClient) requestBill(reqBody chatRequest) (*entities.ParsedBill, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	// Local servers usually don't require a key
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chat completions API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chat completions response: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in chat completions response")
+	}
+
+	var parsedData entities.ParsedBill
+	if err := json.Unmarshal([]byte(llm.ExtractJSON(chatResp.Choices[0].Message.Content)), &parsedData); err != nil {
+		return nil, fmt.Errorf("failed to parse bill data from response: %w", err)
+	}
+
+	return &parsedData, nil
+}
@@ -0,0 +1,568 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// minTextCharacters is how many letters and digits a PDF needs before its text is worth parsing,
+// scans often only carry a few characters of OCR noise or a watermark
+const minTextCharacters = 20
+
+var (
+	filterPattern      = regexp.MustCompile(`/Filter\s*(\[[^\]]*\]|/[A-Za-z0-9]+)`)
+	namePattern        = regexp.MustCompile(`/([A-Za-z0-9]+)`)
+	imagePattern       = regexp.MustCompile(`/Subtype\s*/Image`)
+	fontFilePattern    = regexp.MustCompile(`/Length[123]\s`)
+	bfCharPattern      = regexp.MustCompile(`(?s)beginbfchar(.*?)endbfchar`)
+	bfRangePattern     = regexp.MustCompile(`(?s)beginbfrange(.*?)endbfrange`)
+	cmapTokenPattern   = regexp.MustCompile(`<[0-9A-Fa-f\s]*>|\[|\]`)
+	errEncryptedPDF    = errors.New("encrypted PDFs are not supported")
+	errUnsupportedData = errors.New("unsupported stream filter")
+)
+
+// Reader pulls the text and scanned images out of PDFs using only the standard library.
+// It covers what invoicing systems and phone scanners produce for receipts rather than every PDF:
+// encrypted files are rejected and streams with filters other than FlateDecode are skipped
+type Reader struct{}
+
+func NewReader() *Reader {
+	return &Reader{}
+}
+
+type stream struct {
+	dict string
+	raw  []byte
+}
+
+// ExtractText returns the text drawn on the pages of a PDF, one line per text row
+// Implements the ports.PDFReader interface
+func (r *Reader) ExtractText(data []byte) (string, error) {
+	streams, err := readStreams(data)
+	if err != nil {
+		return "", err
+	}
+
+	// Fonts with custom encodings need their ToUnicode maps. They are merged instead of resolved
+	// per font, which is enough to read a receipt
+	cmap := newToUnicode()
+	var contents [][]byte
+	for _, s := range streams {
+		if imagePattern.MatchString(s.dict) || fontFilePattern.MatchString(s.dict) {
+			continue
+		}
+		decoded, err := s.decode()
+		if err != nil {
+			continue
+		}
+		if bytes.Contains(decoded, []byte("begincmap")) {
+			cmap.parse(string(decoded))
+			continue
+		}
+		if bytes.Contains(decoded, []byte("BT")) {
+			contents = append(contents, decoded)
+		}
+	}
+
+	var text strings.Builder
+	for _, content := range contents {
+		extractContentText(content, cmap, &text)
+		text.WriteString("\n")
+	}
+
+	result := cleanText(text.String())
+	characters := 0
+	for _, r := range result {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			characters++
+		}
+	}
+	if characters < minTextCharacters {
+		return "", nil
+	}
+	return result, nil
+}
+
+// ExtractImages returns the JPEG images embedded in a PDF, largest first. Scanned receipts are
+// usually a single JPEG per page
+// Implements the ports.PDFReader interface
+func (r *Reader) ExtractImages(data []byte) ([][]byte, error) {
+	streams, err := readStreams(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var images [][]byte
+	for _, s := range streams {
+		filters := s.filters()
+		if !imagePattern.MatchString(s.dict) || len(filters) != 1 || filters[0] != "DCTDecode" {
+			continue
+		}
+		images = append(images, s.raw)
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return len(images[i]) > len(images[j])
+	})
+	return images, nil
+}
+
+// readStreams finds every stream object in a PDF by scanning for the stream keyword, which
+// also works on files with a broken cross-reference table
+func readStreams(data []byte) ([]stream, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF")) {
+		return nil, fmt.Errorf("not a PDF file")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return nil, errEncryptedPDF
+	}
+
+	var streams []stream
+	pos := 0
+	for {
+		index := bytes.Index(data[pos:], []byte("stream"))
+		if index == -1 {
+			break
+		}
+		start := pos + index
+		pos = start + len("stream")
+
+		// Skip endstream and stream keywords that don't follow an object dictionary
+		if bytes.HasSuffix(data[:start], []byte("end")) {
+			continue
+		}
+		dictEnd := bytes.LastIndex(data[:start], []byte(">>"))
+		if dictEnd == -1 || len(bytes.TrimSpace(data[dictEnd+2:start])) != 0 {
+			continue
+		}
+		dictStart := bytes.LastIndex(data[:dictEnd], []byte("obj"))
+		if dictStart == -1 {
+			continue
+		}
+
+		body := pos
+		if body < len(data) && data[body] == '\r' {
+			body++
+		}
+		if body < len(data) && data[body] == '\n' {
+			body++
+		}
+		end := bytes.Index(data[body:], []byte("endstream"))
+		if end == -1 {
+			break
+		}
+
+		streams = append(streams, stream{
+			dict: string(data[dictStart+len("obj") : dictEnd+2]),
+			raw:  bytes.TrimRight(data[body:body+end], "\r\n"),
+		})
+		pos = body + end + len("endstream")
+	}
+	return streams, nil
+}
+
+func (s stream) filters() []string {
+	match := filterPattern.FindStringSubmatch(s.dict)
+	if match == nil {
+		return nil
+	}
+	var filters []string
+	for _, name := range namePattern.FindAllStringSubmatch(match[1], -1) {
+		filters = append(filters, name[1])
+	}
+	return filters
+}
+
+func (s stream) decode() ([]byte, error) {
+	filters := s.filters()
+	switch {
+	case len(filters) == 0:
+		return s.raw, nil
+	case len(filters) == 1 && filters[0] == "FlateDecode":
+		reader, err := zlib.NewReader(bytes.NewReader(s.raw))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		decoded, err := io.ReadAll(reader)
+		// Producers often get the stream length slightly wrong, what was inflated is still usable
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+		return decoded, nil
+	default:
+		return nil, errUnsupportedData
+	}
+}
+
+// operand is a value pushed before a content stream operator
+type operand struct {
+	text   []byte
+	number float64
+	isText bool
+	array  []operand
+}
+
+// extractContentText runs the text operators of a page content stream and writes what they draw
+func extractContentText(content []byte, cmap *toUnicode, out *strings.Builder) {
+	var operands []operand
+	var array []operand
+	inArray := false
+	lastY := 0.0
+
+	push := func(op operand) {
+		if inArray {
+			array = append(array, op)
+		} else {
+			operands = append(operands, op)
+		}
+	}
+	lastText := func() []byte {
+		for i := len(operands) - 1; i >= 0; i-- {
+			if operands[i].isText {
+				return operands[i].text
+			}
+		}
+		return nil
+	}
+	number := func(fromEnd int) float64 {
+		if len(operands) < fromEnd {
+			return 0
+		}
+		return operands[len(operands)-fromEnd].number
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case isWhitespace(c):
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			text, next := readLiteralString(content, i)
+			push(operand{text: text, isText: true})
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			// Dictionaries only appear in marked content operators, which carry no text
+			i += 2
+		case c == '>':
+			i++
+		case c == '<':
+			text, next := readHexString(content, i)
+			push(operand{text: text, isText: true})
+			i = next
+		case c == '[':
+			inArray = true
+			array = nil
+			i++
+		case c == ']':
+			inArray = false
+			operands = append(operands, operand{array: array})
+			i++
+		case c == '/':
+			i++
+			for i < len(content) && !isWhitespace(content[i]) && !isDelimiter(content[i]) {
+				i++
+			}
+			push(operand{})
+		case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			start := i
+			i++
+			for i < len(content) && (content[i] == '.' || (content[i] >= '0' && content[i] <= '9')) {
+				i++
+			}
+			value, _ := strconv.ParseFloat(string(content[start:i]), 64)
+			push(operand{number: value})
+		default:
+			start := i
+			i++
+			for i < len(content) && !isWhitespace(content[i]) && !isDelimiter(content[i]) {
+				i++
+			}
+			switch string(content[start:i]) {
+			case "Tj":
+				out.WriteString(cmap.decode(lastText()))
+			case "'", "\"":
+				out.WriteString("\n")
+				out.WriteString(cmap.decode(lastText()))
+			case "TJ":
+				if len(operands) > 0 {
+					for _, part := range operands[len(operands)-1].array {
+						if part.isText {
+							out.WriteString(cmap.decode(part.text))
+						} else if part.number < -200 {
+							// Large negative kerning is how some producers draw the gap between words
+							out.WriteString(" ")
+						}
+					}
+				}
+			case "Td", "TD":
+				if number(1) != 0 {
+					out.WriteString("\n")
+				} else {
+					out.WriteString(" ")
+				}
+			case "Tm":
+				if y := number(1); y != lastY {
+					out.WriteString("\n")
+					lastY = y
+				} else {
+					out.WriteString(" ")
+				}
+			case "T*":
+				out.WriteString("\n")
+			case "ET":
+				out.WriteString(" ")
+			case "BI":
+				// Inline image data is binary, skip to its end marker
+				if end := bytes.Index(content[i:], []byte("EI")); end != -1 {
+					i += end + len("EI")
+				} else {
+					i = len(content)
+				}
+			}
+			operands = operands[:0]
+		}
+	}
+}
+
+func readLiteralString(content []byte, start int) ([]byte, int) {
+	var text []byte
+	depth := 0
+	i := start + 1
+	for i < len(content) {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			switch escaped := content[i]; escaped {
+			case 'n':
+				text = append(text, '\n')
+			case 'r':
+				text = append(text, '\r')
+			case 't':
+				text = append(text, '\t')
+			case 'b':
+				text = append(text, '\b')
+			case 'f':
+				text = append(text, '\f')
+			case '\r', '\n':
+				// Line continuation
+				if escaped == '\r' && i+1 < len(content) && content[i+1] == '\n' {
+					i++
+				}
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					value := 0
+					digits := 0
+					for digits < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7' {
+						value = value*8 + int(content[i]-'0')
+						i++
+						digits++
+					}
+					text = append(text, byte(value))
+					continue
+				}
+				text = append(text, escaped)
+			}
+		case c == '(':
+			depth++
+			text = append(text, c)
+		case c == ')':
+			if depth == 0 {
+				return text, i + 1
+			}
+			depth--
+			text = append(text, c)
+		default:
+			text = append(text, c)
+		}
+		i++
+	}
+	return text, i
+}
+
+func readHexString(content []byte, start int) ([]byte, int) {
+	end := bytes.IndexByte(content[start:], '>')
+	if end == -1 {
+		return nil, len(content)
+	}
+	return decodeHex(string(content[start+1 : start+end])), start + end + 1
+}
+
+func decodeHex(value string) []byte {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, value)
+	if len(value)%2 == 1 {
+		value += "0"
+	}
+	decoded := make([]byte, 0, len(value)/2)
+	for i := 0; i+1 < len(value); i += 2 {
+		b, err := strconv.ParseUint(value[i:i+2], 16, 8)
+		if err != nil {
+			return decoded
+		}
+		decoded = append(decoded, byte(b))
+	}
+	return decoded
+}
+
+func isWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) != -1
+}
+
+// toUnicode maps character codes of embedded fonts to text, keyed by code length in bytes
+type toUnicode struct {
+	codes map[int]map[uint32]string
+}
+
+func newToUnicode() *toUnicode {
+	return &toUnicode{codes: map[int]map[uint32]string{}}
+}
+
+// parse reads the bfchar and bfrange sections of a ToUnicode CMap
+func (t *toUnicode) parse(cmap string) {
+	for _, block := range bfCharPattern.FindAllStringSubmatch(cmap, -1) {
+		tokens := cmapTokenPattern.FindAllString(block[1], -1)
+		for i := 0; i+1 < len(tokens); i += 2 {
+			t.add(decodeHex(strings.Trim(tokens[i], "<>")), utf16Text(decodeHex(strings.Trim(tokens[i+1], "<>"))))
+		}
+	}
+
+	for _, block := range bfRangePattern.FindAllStringSubmatch(cmap, -1) {
+		tokens := cmapTokenPattern.FindAllString(block[1], -1)
+		for i := 0; i+2 < len(tokens); {
+			low := decodeHex(strings.Trim(tokens[i], "<>"))
+			high := decodeHex(strings.Trim(tokens[i+1], "<>"))
+			i += 2
+			lowCode, highCode := codeValue(low), codeValue(high)
+			if highCode < lowCode || highCode-lowCode > 0xFFFF {
+				break
+			}
+
+			if tokens[i] == "[" {
+				i++
+				for code := lowCode; i < len(tokens) && tokens[i] != "]"; code++ {
+					t.addCode(len(low), code, utf16Text(decodeHex(strings.Trim(tokens[i], "<>"))))
+					i++
+				}
+				i++
+				continue
+			}
+
+			destination := decodeHex(strings.Trim(tokens[i], "<>"))
+			i++
+			units := utf16Units(destination)
+			if len(units) == 0 {
+				continue
+			}
+			for code := lowCode; code <= highCode; code++ {
+				t.addCode(len(low), code, string(utf16.Decode(units)))
+				units[len(units)-1]++
+			}
+		}
+	}
+}
+
+func (t *toUnicode) add(code []byte, text string) {
+	t.addCode(len(code), codeValue(code), text)
+}
+
+func (t *toUnicode) addCode(length int, code uint32, text string) {
+	if length != 1 && length != 2 {
+		return
+	}
+	if t.codes[length] == nil {
+		t.codes[length] = map[uint32]string{}
+	}
+	t.codes[length][code] = text
+}
+
+// decode turns a shown string into text. Two-byte codes are only used when every code of the
+// string is mapped, otherwise it's read byte by byte as WinAnsi, which is close enough to Latin-1
+func (t *toUnicode) decode(text []byte) string {
+	if wide := t.codes[2]; wide != nil && len(text)%2 == 0 && len(text) > 0 {
+		var decoded strings.Builder
+		complete := true
+		for i := 0; i < len(text); i += 2 {
+			value, ok := wide[uint32(text[i])<<8|uint32(text[i+1])]
+			if !ok {
+				complete = false
+				break
+			}
+			decoded.WriteString(value)
+		}
+		if complete {
+			return decoded.String()
+		}
+	}
+
+	narrow := t.codes[1]
+	var decoded strings.Builder
+	for _, b := range text {
+		if value, ok := narrow[uint32(b)]; ok {
+			decoded.WriteString(value)
+			continue
+		}
+		decoded.WriteRune(rune(b))
+	}
+	return decoded.String()
+}
+
+func codeValue(code []byte) uint32 {
+	var value uint32
+	for _, b := range code {
+		value = value<<8 | uint32(b)
+	}
+	return value
+}
+
+func utf16Units(data []byte) []uint16 {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+	}
+	return units
+}
+
+func utf16Text(data []byte) string {
+	return string(utf16.Decode(utf16Units(data)))
+}
+
+// cleanText drops control characters and collapses the blank space left between text runs
+func cleanText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return ' '
+			}
+			return r
+		}, line)
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
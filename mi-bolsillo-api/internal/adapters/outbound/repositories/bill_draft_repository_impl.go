@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type BillDraftRepositoryImpl struct {
+	db queryer
+}
+
+func NewBillDraftRepository(db *sqlx.DB) *BillDraftRepositoryImpl {
+	return &BillDraftRepositoryImpl{db: db}
+}
+
+// billDraftRow is a bill_drafts row, items and warnings are stored as JSON
+type billDraftRow struct {
+	entities.BillDraft
+	ItemsJSON    string `db:"items"`
+	WarningsJSON string `db:"warnings"`
+}
+
+func newBillDraftRow(draft *entities.BillDraft) (*billDraftRow, error) {
+	items, err := json.Marshal(draft.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal draft items: %w", err)
+	}
+	warnings, err := json.Marshal(draft.Warnings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal draft warnings: %w", err)
+	}
+	return &billDraftRow{BillDraft: *draft, ItemsJSON: string(items), WarningsJSON: string(warnings)}, nil
+}
+
+func (r *BillDraftRepositoryImpl) Create(draft *entities.BillDraft) error {
+	row, err := newBillDraftRow(draft)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO bill_drafts (draft_id, user_id, wallet_id, source, description, currency, date, total_amount, items, warnings, receipt_key, supplier_ruc, invoice_number, subtotal, tax_amount, tip_amount, payment_method_id, created_at, updated_at)
+		VALUES (:draft_id, :user_id, :wallet_id, :source, :description, :currency, :date, :total_amount, :items, :warnings, :receipt_key, :supplier_ruc, :invoice_number, :subtotal, :tax_amount, :tip_amount, :payment_method_id, :created_at, :updated_at)
+	`
+	_, err = r.db.NamedExec(query, row)
+	return err
+}
+
+func (r *BillDraftRepositoryImpl) FindByID(draftID string) (*entities.BillDraft, error) {
+	var row billDraftRow
+	query := `SELECT * FROM bill_drafts WHERE draft_id = ?`
+	err := r.db.Get(&row, query, draftID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	draft := row.BillDraft
+	if err := json.Unmarshal([]byte(row.ItemsJSON), &draft.Items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draft items: %w", err)
+	}
+	if err := json.Unmarshal([]byte(row.WarningsJSON), &draft.Warnings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draft warnings: %w", err)
+	}
+	return &draft, nil
+}
+
+func (r *BillDraftRepositoryImpl) Update(draft *entities.BillDraft) error {
+	row, err := newBillDraftRow(draft)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE bill_drafts
+		SET description = :description, currency = :currency, date = :date, total_amount = :total_amount,
+			items = :items, warnings = :warnings, payment_method_id = :payment_method_id, updated_at = :updated_at
+		WHERE draft_id = :draft_id
+	`
+	_, err = r.db.NamedExec(query, row)
+	return err
+}
+
+func (r *BillDraftRepositoryImpl) Delete(draftID string) (bool, error) {
+	query := `DELETE FROM bill_drafts WHERE draft_id = ?`
+	result, err := r.db.Exec(query, draftID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -1,12 +1,19 @@
 package repositories
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
 	"github.com/jmoiron/sqlx"
 )
 
 type BillRepositoryImpl struct {
-	db *sqlx.DB
+	db queryer
 }
 
 func NewBillRepository(db *sqlx.DB) *BillRepositoryImpl {
@@ -15,8 +22,8 @@ func NewBillRepository(db *sqlx.DB) *BillRepositoryImpl {
 
 func (r *BillRepositoryImpl) Create(bill *entities.Bill) error {
 	query := `
-		INSERT INTO bills (bill_id, amount_pen, amount_usd, description, category, currency, user_id, source, date, created_at, updated_at)
-		VALUES (:bill_id, :amount_pen, :amount_usd, :description, :category, :currency, :user_id, :source, :date, :created_at, :updated_at)
+		INSERT INTO bills (bill_id, amount_pen, amount_usd, amount_original, reporting_currency, amount_reporting, description, category, category_id, currency, user_id, wallet_id, source, date, receipt_key, supplier_ruc, invoice_number, subtotal, tax_amount, tip_amount, payment_method_id, statement_reference, created_at, updated_at)
+		VALUES (:bill_id, :amount_pen, :amount_usd, :amount_original, :reporting_currency, :amount_reporting, :description, :category, :category_id, :currency, :user_id, :wallet_id, :source, :date, :receipt_key, :supplier_ruc, :invoice_number, :subtotal, :tax_amount, :tip_amount, :payment_method_id, :statement_reference, :created_at, :updated_at)
 	`
 	_, err := r.db.NamedExec(query, bill)
 	return err
@@ -27,6 +34,22 @@ func (r *BillRepositoryImpl) FindByID(billID string) (*entities.Bill, error) {
 	query := `SELECT * FROM bills WHERE bill_id = ?`
 	err := r.db.Get(&bill, query, billID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &bill, nil
+}
+
+func (r *BillRepositoryImpl) FindByStatementReference(userID string, reference string) (*entities.Bill, error) {
+	var bill entities.Bill
+	query := `SELECT * FROM bills WHERE user_id = ? AND statement_reference = ? LIMIT 1`
+	err := r.db.Get(&bill, query, userID, reference)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return &bill, nil
@@ -42,6 +65,115 @@ func (r *BillRepositoryImpl) FindByUserID(userID string) ([]*entities.Bill, erro
 	return bills, nil
 }
 
+// billSortColumns maps the sort fields of ports.BillSearchCriteria to columns
+var billSortColumns = map[string]string{
+	ports.BillSortDate:      "date",
+	ports.BillSortAmount:    "amount_reporting",
+	ports.BillSortCreatedAt: "created_at",
+}
+
+// Search returns a page of the user's bills using keyset pagination on (sort column, bill_id)
+func (r *BillRepositoryImpl) Search(criteria ports.BillSearchCriteria) ([]*entities.Bill, error) {
+	conditions := []string{"user_id = ?"}
+	args := []interface{}{criteria.UserID}
+	if criteria.WalletID != "" {
+		conditions = []string{"wallet_id = ?"}
+		args = []interface{}{criteria.WalletID}
+	}
+
+	if criteria.From != nil {
+		conditions = append(conditions, "date >= ?")
+		args = append(args, *criteria.From)
+	}
+	if criteria.To != nil {
+		conditions = append(conditions, "date < ?")
+		args = append(args, *criteria.To)
+	}
+	if criteria.Category != "" {
+		conditions = append(conditions, "(category_id = ? OR LOWER(category) = LOWER(?))")
+		args = append(args, criteria.Category, criteria.Category)
+	}
+	if criteria.Currency != "" {
+		conditions = append(conditions, "currency = ?")
+		args = append(args, criteria.Currency)
+	}
+	if criteria.PaymentMethodID != "" {
+		conditions = append(conditions, "payment_method_id = ?")
+		args = append(args, criteria.PaymentMethodID)
+	}
+	if criteria.Source != "" {
+		conditions = append(conditions, "source = ?")
+		args = append(args, criteria.Source)
+	}
+	if criteria.MinAmount != nil {
+		conditions = append(conditions, "amount_reporting >= ?")
+		args = append(args, *criteria.MinAmount)
+	}
+	if criteria.MaxAmount != nil {
+		conditions = append(conditions, "amount_reporting <= ?")
+		args = append(args, *criteria.MaxAmount)
+	}
+	if criteria.Description != "" {
+		conditions = append(conditions, "LOWER(description) LIKE ?")
+		args = append(args, "%"+strings.ToLower(criteria.Description)+"%")
+	}
+
+	column, ok := billSortColumns[criteria.SortBy]
+	if !ok {
+		column = billSortColumns[ports.BillSortDate]
+	}
+	direction, comparison := "DESC", "<"
+	if criteria.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	if criteria.AfterID != "" {
+		conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND bill_id %[2]s ?))", column, comparison))
+		args = append(args, criteria.AfterValue, criteria.AfterValue, criteria.AfterID)
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM bills WHERE %s ORDER BY %s %s, bill_id %s`,
+		strings.Join(conditions, " AND "), column, direction, direction)
+	if criteria.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, criteria.Limit)
+	}
+
+	var bills []*entities.Bill
+	err := r.db.Select(&bills, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return bills, nil
+}
+
+func (r *BillRepositoryImpl) Update(bill *entities.Bill) error {
+	query := `
+		UPDATE bills
+		SET description = :description, category = :category, category_id = :category_id, currency = :currency, date = :date,
+			supplier_ruc = :supplier_ruc, subtotal = :subtotal, tax_amount = :tax_amount, tip_amount = :tip_amount, payment_method_id = :payment_method_id,
+			updated_at = :updated_at
+		WHERE bill_id = :bill_id
+	`
+	_, err := r.db.NamedExec(query, bill)
+	return err
+}
+
+// RecalculateTotals sets the bill's amounts to the sum of its expense rows in a single statement
+func (r *BillRepositoryImpl) RecalculateTotals(billID string, updatedAt time.Time) error {
+	query := `
+		UPDATE bills
+		SET amount_pen = (SELECT COALESCE(SUM(amount_pen), 0) FROM expenses WHERE bill_id = bills.bill_id),
+			amount_usd = (SELECT COALESCE(SUM(amount_usd), 0) FROM expenses WHERE bill_id = bills.bill_id),
+			amount_original = (SELECT COALESCE(SUM(amount_original), 0) FROM expenses WHERE bill_id = bills.bill_id),
+			amount_reporting = (SELECT COALESCE(SUM(amount_reporting), 0) FROM expenses WHERE bill_id = bills.bill_id),
+			updated_at = ?
+		WHERE bill_id = ?
+	`
+	_, err := r.db.Exec(query, updatedAt, billID)
+	return err
+}
+
 func (r *BillRepositoryImpl) Delete(billID string) error {
 	query := `DELETE FROM bills WHERE bill_id = ?`
 	_, err := r.db.Exec(query, billID)
@@ -53,3 +185,25 @@ func (r *BillRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) er
 	_, err := r.db.Exec(query, newUserID, oldUserID)
 	return err
 }
+
+// ReassignCategory moves the user's bills of a category, matched by ID or by name, to another category
+func (r *BillRepositoryImpl) ReassignCategory(userID string, fromID string, fromName string, categoryID string, name string) error {
+	return reassignCategory(r.db, "bills", userID, fromID, fromName, categoryID, name)
+}
+
+// FindCategoryNames returns the distinct category names of the user's bills
+func (r *BillRepositoryImpl) FindCategoryNames(userID string) ([]string, error) {
+	var names []string
+	query := `SELECT DISTINCT category FROM bills WHERE user_id = ? AND category IS NOT NULL AND category <> ''`
+	err := r.db.Select(&names, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func (r *BillRepositoryImpl) UpdateReportingAmount(billID string, reportingCurrency string, amountReporting float64) error {
+	query := `UPDATE bills SET reporting_currency = ?, amount_reporting = ? WHERE bill_id = ?`
+	_, err := r.db.Exec(query, reportingCurrency, amountReporting, billID)
+	return err
+}
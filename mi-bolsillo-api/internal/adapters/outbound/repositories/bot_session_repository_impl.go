@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type BotSessionRepositoryImpl struct {
+	db queryer
+}
+
+func NewBotSessionRepository(db *sqlx.DB) *BotSessionRepositoryImpl {
+	return &BotSessionRepositoryImpl{db: db}
+}
+
+// botSessionRow is a bot_sessions row, the pending intent is stored as JSON
+type botSessionRow struct {
+	ChatID     int64     `db:"chat_id"`
+	TelegramID int64     `db:"telegram_id"`
+	State      string    `db:"state"`
+	IntentJSON string    `db:"intent"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	ExpiresAt  time.Time `db:"expires_at"`
+}
+
+func (r *BotSessionRepositoryImpl) Find(chatID int64, telegramID int64) (*entities.BotSession, error) {
+	var row botSessionRow
+	query := `SELECT * FROM bot_sessions WHERE chat_id = ? AND telegram_id = ?`
+	err := r.db.Get(&row, query, chatID, telegramID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	session := &entities.BotSession{
+		ChatID:     row.ChatID,
+		TelegramID: row.TelegramID,
+		State:      row.State,
+		UpdatedAt:  row.UpdatedAt,
+		ExpiresAt:  row.ExpiresAt,
+	}
+	if err := json.Unmarshal([]byte(row.IntentJSON), &session.Intent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session intent: %w", err)
+	}
+	return session, nil
+}
+
+func (r *BotSessionRepositoryImpl) Save(session *entities.BotSession) error {
+	intent, err := json.Marshal(session.Intent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session intent: %w", err)
+	}
+
+	row := botSessionRow{
+		ChatID:     session.ChatID,
+		TelegramID: session.TelegramID,
+		State:      session.State,
+		IntentJSON: string(intent),
+		UpdatedAt:  session.UpdatedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+	query := `
+		INSERT INTO bot_sessions (chat_id, telegram_id, state, intent, updated_at, expires_at)
+		VALUES (:chat_id, :telegram_id, :state, :intent, :updated_at, :expires_at)
+		ON CONFLICT (chat_id, telegram_id) DO UPDATE SET
+			state = excluded.state,
+			intent = excluded.intent,
+			updated_at = excluded.updated_at,
+			expires_at = excluded.expires_at
+	`
+	_, err = r.db.NamedExec(query, row)
+	return err
+}
+
+func (r *BotSessionRepositoryImpl) Delete(chatID int64, telegramID int64) error {
+	query := `DELETE FROM bot_sessions WHERE chat_id = ? AND telegram_id = ?`
+	_, err := r.db.Exec(query, chatID, telegramID)
+	return err
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type BudgetRepositoryImpl struct {
+	db queryer
+}
+
+func NewBudgetRepository(db *sqlx.DB) *BudgetRepositoryImpl {
+	return &BudgetRepositoryImpl{db: db}
+}
+
+func (r *BudgetRepositoryImpl) Create(budget *entities.Budget) error {
+	query := `
+		INSERT INTO budgets (budget_id, user_id, category, amount_limit, currency, created_at, updated_at)
+		VALUES (:budget_id, :user_id, :category, :amount_limit, :currency, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, budget)
+	return err
+}
+
+func (r *BudgetRepositoryImpl) FindByID(budgetID string) (*entities.Budget, error) {
+	var budget entities.Budget
+	query := `SELECT * FROM budgets WHERE budget_id = ?`
+	err := r.db.Get(&budget, query, budgetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &budget, nil
+}
+
+func (r *BudgetRepositoryImpl) FindByUserID(userID string) ([]*entities.Budget, error) {
+	var budgets []*entities.Budget
+	query := `SELECT * FROM budgets WHERE user_id = ? ORDER BY category`
+	err := r.db.Select(&budgets, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+func (r *BudgetRepositoryImpl) Update(budget *entities.Budget) error {
+	query := `
+		UPDATE budgets
+		SET category = :category, amount_limit = :amount_limit, currency = :currency, updated_at = :updated_at
+		WHERE budget_id = :budget_id
+	`
+	_, err := r.db.NamedExec(query, budget)
+	return err
+}
+
+func (r *BudgetRepositoryImpl) Delete(budgetID string) error {
+	query := `DELETE FROM budgets WHERE budget_id = ?`
+	_, err := r.db.Exec(query, budgetID)
+	return err
+}
+
+func (r *BudgetRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	query := `
+		UPDATE budgets SET user_id = ?
+		WHERE user_id = ? AND LOWER(category) NOT IN (SELECT LOWER(category) FROM budgets WHERE user_id = ?)
+	`
+	if _, err := r.db.Exec(query, newUserID, oldUserID, newUserID); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`DELETE FROM budgets WHERE user_id = ?`, oldUserID)
+	return err
+}
+
+func (r *BudgetRepositoryImpl) RenameCategory(userID string, from string, to string) error {
+	query := `UPDATE budgets SET category = ? WHERE user_id = ? AND LOWER(category) = LOWER(?)`
+	_, err := r.db.Exec(query, to, userID, from)
+	return err
+}
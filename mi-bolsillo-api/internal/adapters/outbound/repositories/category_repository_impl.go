@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type CategoryRepositoryImpl struct {
+	db queryer
+}
+
+func NewCategoryRepository(db *sqlx.DB) *CategoryRepositoryImpl {
+	return &CategoryRepositoryImpl{db: db}
+}
+
+func (r *CategoryRepositoryImpl) Create(category *entities.Category) error {
+	query := `
+		INSERT INTO categories (category_id, user_id, parent_id, name, icon, color, created_at, updated_at)
+		VALUES (:category_id, :user_id, :parent_id, :name, :icon, :color, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, category)
+	return err
+}
+
+func (r *CategoryRepositoryImpl) FindByID(categoryID string) (*entities.Category, error) {
+	var category entities.Category
+	query := `SELECT * FROM categories WHERE category_id = ?`
+	err := r.db.Get(&category, query, categoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepositoryImpl) FindByUserID(userID string) ([]*entities.Category, error) {
+	var categories []*entities.Category
+	query := `SELECT * FROM categories WHERE user_id = ? ORDER BY created_at, name`
+	err := r.db.Select(&categories, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *CategoryRepositoryImpl) Update(category *entities.Category) error {
+	query := `
+		UPDATE categories
+		SET parent_id = :parent_id, name = :name, icon = :icon, color = :color, updated_at = :updated_at
+		WHERE category_id = :category_id
+	`
+	_, err := r.db.NamedExec(query, category)
+	return err
+}
+
+func (r *CategoryRepositoryImpl) Delete(categoryID string) error {
+	query := `DELETE FROM categories WHERE category_id = ?`
+	_, err := r.db.Exec(query, categoryID)
+	return err
+}
+
+// UpdateUserID merges the old user's categories into the new user's. Names the new user already
+// has are kept as they are, and the bills, expenses and rules of the old duplicates are relinked to them
+func (r *CategoryRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	query := `
+		UPDATE categories SET user_id = ?
+		WHERE user_id = ? AND LOWER(name) NOT IN (SELECT LOWER(name) FROM categories WHERE user_id = ?)
+	`
+	if _, err := r.db.Exec(query, newUserID, oldUserID, newUserID); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"bills", "expenses"} {
+		query := fmt.Sprintf(`
+			UPDATE %[1]s
+			SET category_id = COALESCE((SELECT c.category_id FROM categories c WHERE c.user_id = ? AND LOWER(c.name) = LOWER(%[1]s.category)), ''),
+				category = COALESCE((SELECT c.name FROM categories c WHERE c.user_id = ? AND LOWER(c.name) = LOWER(%[1]s.category)), category)
+			WHERE category_id IN (SELECT category_id FROM categories WHERE user_id = ?)
+		`, table)
+		if _, err := r.db.Exec(query, newUserID, newUserID, oldUserID); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range []string{"category_rules", "merchants"} {
+		query := fmt.Sprintf(`
+			UPDATE %[1]s
+			SET category_id = COALESCE((
+				SELECT n.category_id FROM categories o
+				JOIN categories n ON n.user_id = ? AND LOWER(n.name) = LOWER(o.name)
+				WHERE o.category_id = %[1]s.category_id
+			), category_id)
+			WHERE category_id IN (SELECT category_id FROM categories WHERE user_id = ?)
+		`, table)
+		if _, err := r.db.Exec(query, newUserID, oldUserID); err != nil {
+			return err
+		}
+	}
+
+	// Moved subcategories of a duplicate go under the new user's category of the same name
+	query = `
+		UPDATE categories
+		SET parent_id = COALESCE((
+			SELECT n.category_id FROM categories o
+			JOIN categories n ON n.user_id = ? AND LOWER(n.name) = LOWER(o.name)
+			WHERE o.category_id = categories.parent_id
+		), parent_id)
+		WHERE user_id = ? AND parent_id IN (SELECT category_id FROM categories WHERE user_id = ?)
+	`
+	if _, err := r.db.Exec(query, newUserID, newUserID, oldUserID); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`DELETE FROM categories WHERE user_id = ?`, oldUserID)
+	return err
+}
+
+// reassignCategory sets the category of the user's rows in table that point to fromID or are named
+// fromName (ignoring case). Empty values don't match, so legacy rows without a category ID are only
+// matched by name
+func reassignCategory(db queryer, table string, userID string, fromID string, fromName string, categoryID string, name string) error {
+	var conditions []string
+	args := []interface{}{categoryID, name, userID}
+	if fromID != "" {
+		conditions = append(conditions, "category_id = ?")
+		args = append(args, fromID)
+	}
+	if fromName != "" {
+		conditions = append(conditions, "LOWER(TRIM(category)) = LOWER(?)")
+		args = append(args, strings.TrimSpace(fromName))
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET category_id = ?, category = ? WHERE user_id = ? AND (%s)`,
+		table, strings.Join(conditions, " OR "))
+	_, err := db.Exec(query, args...)
+	return err
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type CategoryRuleRepositoryImpl struct {
+	db queryer
+}
+
+func NewCategoryRuleRepository(db *sqlx.DB) *CategoryRuleRepositoryImpl {
+	return &CategoryRuleRepositoryImpl{db: db}
+}
+
+func (r *CategoryRuleRepositoryImpl) Create(rule *entities.CategoryRule) error {
+	query := `
+		INSERT INTO category_rules (rule_id, user_id, field, operator, pattern, category_id, priority, created_at, updated_at)
+		VALUES (:rule_id, :user_id, :field, :operator, :pattern, :category_id, :priority, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, rule)
+	return err
+}
+
+func (r *CategoryRuleRepositoryImpl) FindByID(ruleID string) (*entities.CategoryRule, error) {
+	var rule entities.CategoryRule
+	query := `SELECT * FROM category_rules WHERE rule_id = ?`
+	err := r.db.Get(&rule, query, ruleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *CategoryRuleRepositoryImpl) FindByUserID(userID string) ([]*entities.CategoryRule, error) {
+	var rules []*entities.CategoryRule
+	query := `SELECT * FROM category_rules WHERE user_id = ? ORDER BY priority, created_at`
+	err := r.db.Select(&rules, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *CategoryRuleRepositoryImpl) Update(rule *entities.CategoryRule) error {
+	query := `
+		UPDATE category_rules
+		SET field = :field, operator = :operator, pattern = :pattern, category_id = :category_id,
+			priority = :priority, updated_at = :updated_at
+		WHERE rule_id = :rule_id
+	`
+	_, err := r.db.NamedExec(query, rule)
+	return err
+}
+
+func (r *CategoryRuleRepositoryImpl) Delete(ruleID string) error {
+	query := `DELETE FROM category_rules WHERE rule_id = ?`
+	_, err := r.db.Exec(query, ruleID)
+	return err
+}
+
+func (r *CategoryRuleRepositoryImpl) ReassignCategory(fromCategoryID string, toCategoryID string) error {
+	query := `UPDATE category_rules SET category_id = ? WHERE category_id = ?`
+	_, err := r.db.Exec(query, toCategoryID, fromCategoryID)
+	return err
+}
+
+func (r *CategoryRuleRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	query := `UPDATE category_rules SET user_id = ? WHERE user_id = ?`
+	_, err := r.db.Exec(query, newUserID, oldUserID)
+	return err
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type ExchangeRateRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(db *sqlx.DB) *ExchangeRateRepositoryImpl {
+	return &ExchangeRateRepositoryImpl{db: db}
+}
+
+func (r *ExchangeRateRepositoryImpl) Upsert(rate *entities.ExchangeRate) error {
+	query := `
+		INSERT INTO exchange_rates (date, base_currency, quote_currency, rate, source, created_at)
+		VALUES (:date, :base_currency, :quote_currency, :rate, :source, :created_at)
+		ON CONFLICT (date, base_currency, quote_currency)
+		DO UPDATE SET rate = excluded.rate, source = excluded.source
+	`
+	_, err := r.db.NamedExec(query, rate)
+	return err
+}
+
+func (r *ExchangeRateRepositoryImpl) FindByDate(baseCurrency string, quoteCurrency string, date string) (*entities.ExchangeRate, error) {
+	var rate entities.ExchangeRate
+	query := `SELECT * FROM exchange_rates WHERE base_currency = ? AND quote_currency = ? AND date = ?`
+	err := r.db.Get(&rate, query, baseCurrency, quoteCurrency, date)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *ExchangeRateRepositoryImpl) FindLatestOnOrBefore(baseCurrency string, quoteCurrency string, date string) (*entities.ExchangeRate, error) {
+	var rate entities.ExchangeRate
+	query := `
+		SELECT * FROM exchange_rates
+		WHERE base_currency = ? AND quote_currency = ? AND date <= ?
+		ORDER BY date DESC
+		LIMIT 1
+	`
+	err := r.db.Get(&rate, query, baseCurrency, quoteCurrency, date)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
@@ -1,12 +1,16 @@
 package repositories
 
 import (
+	"database/sql"
+	"errors"
+	"strings"
+
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
 	"github.com/jmoiron/sqlx"
 )
 
 type ExpenseRepositoryImpl struct {
-	db *sqlx.DB
+	db queryer
 }
 
 func NewExpenseRepository(db *sqlx.DB) *ExpenseRepositoryImpl {
@@ -15,8 +19,8 @@ func NewExpenseRepository(db *sqlx.DB) *ExpenseRepositoryImpl {
 
 func (r *ExpenseRepositoryImpl) Create(expense *entities.Expense) error {
 	query := `
-		INSERT INTO expenses (expense_id, amount_pen, amount_usd, exchange_rate, currency, description, category, date, bill_id, user_id, source, created_at, updated_at)
-		VALUES (:expense_id, :amount_pen, :amount_usd, :exchange_rate, :currency, :description, :category, :date, :bill_id, :user_id, :source, :created_at, :updated_at)
+		INSERT INTO expenses (expense_id, amount_pen, amount_usd, amount_original, reporting_currency, amount_reporting, exchange_rate, currency, description, category, category_id, date, bill_id, user_id, source, created_at, updated_at)
+		VALUES (:expense_id, :amount_pen, :amount_usd, :amount_original, :reporting_currency, :amount_reporting, :exchange_rate, :currency, :description, :category, :category_id, :date, :bill_id, :user_id, :source, :created_at, :updated_at)
 	`
 	_, err := r.db.NamedExec(query, expense)
 	return err
@@ -24,11 +28,22 @@ func (r *ExpenseRepositoryImpl) Create(expense *entities.Expense) error {
 
 func (r *ExpenseRepositoryImpl) CreateBatch(expenses []*entities.Expense) error {
 	query := `
-		INSERT INTO expenses (expense_id, amount_pen, amount_usd, exchange_rate, currency, description, category, date, bill_id, user_id, source, created_at, updated_at)
-		VALUES (:expense_id, :amount_pen, :amount_usd, :exchange_rate, :currency, :description, :category, :date, :bill_id, :user_id, :source, :created_at, :updated_at)
+		INSERT INTO expenses (expense_id, amount_pen, amount_usd, amount_original, reporting_currency, amount_reporting, exchange_rate, currency, description, category, category_id, date, bill_id, user_id, source, created_at, updated_at)
+		VALUES (:expense_id, :amount_pen, :amount_usd, :amount_original, :reporting_currency, :amount_reporting, :exchange_rate, :currency, :description, :category, :category_id, :date, :bill_id, :user_id, :source, :created_at, :updated_at)
 	`
 
-	tx, err := r.db.Beginx()
+	// Inside a unit of work the caller's transaction already covers the batch
+	db, ok := r.db.(*sqlx.DB)
+	if !ok {
+		for _, expense := range expenses {
+			if _, err := r.db.NamedExec(query, expense); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tx, err := db.Beginx()
 	if err != nil {
 		return err
 	}
@@ -43,6 +58,19 @@ func (r *ExpenseRepositoryImpl) CreateBatch(expenses []*entities.Expense) error
 	return tx.Commit()
 }
 
+func (r *ExpenseRepositoryImpl) FindByID(expenseID string) (*entities.Expense, error) {
+	var expense entities.Expense
+	query := `SELECT * FROM expenses WHERE expense_id = ?`
+	err := r.db.Get(&expense, query, expenseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &expense, nil
+}
+
 func (r *ExpenseRepositoryImpl) FindByBillID(billID string) ([]*entities.Expense, error) {
 	var expenses []*entities.Expense
 	query := `SELECT * FROM expenses WHERE bill_id = ?`
@@ -53,6 +81,55 @@ func (r *ExpenseRepositoryImpl) FindByBillID(billID string) ([]*entities.Expense
 	return expenses, nil
 }
 
+// FindByBillIDs loads the expenses of several bills in a single query
+func (r *ExpenseRepositoryImpl) FindByBillIDs(billIDs []string) ([]*entities.Expense, error) {
+	if len(billIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(billIDs)), ", ")
+	args := make([]interface{}, len(billIDs))
+	for i, billID := range billIDs {
+		args[i] = billID
+	}
+
+	var expenses []*entities.Expense
+	query := `SELECT * FROM expenses WHERE bill_id IN (` + placeholders + `)`
+	err := r.db.Select(&expenses, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *ExpenseRepositoryImpl) FindByUserIDAndDateRange(userID string, from string, to string) ([]*entities.Expense, error) {
+	var expenses []*entities.Expense
+	query := `SELECT * FROM expenses WHERE user_id = ? AND date >= ? AND date <= ? ORDER BY date`
+	err := r.db.Select(&expenses, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *ExpenseRepositoryImpl) Update(expense *entities.Expense) error {
+	query := `
+		UPDATE expenses
+		SET amount_pen = :amount_pen, amount_usd = :amount_usd, amount_original = :amount_original,
+			reporting_currency = :reporting_currency, amount_reporting = :amount_reporting, exchange_rate = :exchange_rate,
+			currency = :currency, description = :description, category = :category, category_id = :category_id, date = :date, updated_at = :updated_at
+		WHERE expense_id = :expense_id
+	`
+	_, err := r.db.NamedExec(query, expense)
+	return err
+}
+
+func (r *ExpenseRepositoryImpl) Delete(expenseID string) error {
+	query := `DELETE FROM expenses WHERE expense_id = ?`
+	_, err := r.db.Exec(query, expenseID)
+	return err
+}
+
 func (r *ExpenseRepositoryImpl) DeleteByBillID(billID string) error {
 	query := `DELETE FROM expenses WHERE bill_id = ?`
 	_, err := r.db.Exec(query, billID)
@@ -64,3 +141,25 @@ func (r *ExpenseRepositoryImpl) UpdateUserID(oldUserID string, newUserID string)
 	_, err := r.db.Exec(query, newUserID, oldUserID)
 	return err
 }
+
+// ReassignCategory moves the user's expenses of a category, matched by ID or by name, to another category
+func (r *ExpenseRepositoryImpl) ReassignCategory(userID string, fromID string, fromName string, categoryID string, name string) error {
+	return reassignCategory(r.db, "expenses", userID, fromID, fromName, categoryID, name)
+}
+
+// FindCategoryNames returns the distinct category names of the user's expenses
+func (r *ExpenseRepositoryImpl) FindCategoryNames(userID string) ([]string, error) {
+	var names []string
+	query := `SELECT DISTINCT category FROM expenses WHERE user_id = ? AND category IS NOT NULL AND category <> ''`
+	err := r.db.Select(&names, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func (r *ExpenseRepositoryImpl) UpdateReportingAmount(expenseID string, reportingCurrency string, amountReporting float64) error {
+	query := `UPDATE expenses SET reporting_currency = ?, amount_reporting = ? WHERE expense_id = ?`
+	_, err := r.db.Exec(query, reportingCurrency, amountReporting, expenseID)
+	return err
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+// goalColumns selects a goal along with the sum of its contributions
+const goalColumns = `g.*, COALESCE((SELECT SUM(c.amount) FROM goal_contributions c WHERE c.goal_id = g.goal_id), 0) AS saved_amount`
+
+type GoalRepositoryImpl struct {
+	db queryer
+}
+
+func NewGoalRepository(db *sqlx.DB) *GoalRepositoryImpl {
+	return &GoalRepositoryImpl{db: db}
+}
+
+func (r *GoalRepositoryImpl) Create(goal *entities.Goal) error {
+	query := `
+		INSERT INTO goals (goal_id, user_id, name, target_amount, currency, deadline, created_at, updated_at)
+		VALUES (:goal_id, :user_id, :name, :target_amount, :currency, :deadline, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, goal)
+	return err
+}
+
+func (r *GoalRepositoryImpl) FindByID(goalID string) (*entities.Goal, error) {
+	var goal entities.Goal
+	query := `SELECT ` + goalColumns + ` FROM goals g WHERE g.goal_id = ?`
+	err := r.db.Get(&goal, query, goalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (r *GoalRepositoryImpl) FindByUserID(userID string) ([]*entities.Goal, error) {
+	var goals []*entities.Goal
+	query := `SELECT ` + goalColumns + ` FROM goals g WHERE g.user_id = ? ORDER BY g.deadline ASC, g.created_at ASC`
+	err := r.db.Select(&goals, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *GoalRepositoryImpl) Update(goal *entities.Goal) error {
+	query := `
+		UPDATE goals
+		SET name = :name, target_amount = :target_amount, currency = :currency, deadline = :deadline, updated_at = :updated_at
+		WHERE goal_id = :goal_id
+	`
+	_, err := r.db.NamedExec(query, goal)
+	return err
+}
+
+func (r *GoalRepositoryImpl) Delete(goalID string) error {
+	queries := []string{
+		`DELETE FROM goal_contributions WHERE goal_id = ?`,
+		`DELETE FROM goals WHERE goal_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query, goalID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GoalRepositoryImpl) CreateContribution(contribution *entities.GoalContribution) error {
+	query := `
+		INSERT INTO goal_contributions (contribution_id, goal_id, user_id, amount, note, date, created_at)
+		VALUES (:contribution_id, :goal_id, :user_id, :amount, :note, :date, :created_at)
+	`
+	_, err := r.db.NamedExec(query, contribution)
+	return err
+}
+
+func (r *GoalRepositoryImpl) FindContributionByID(contributionID string) (*entities.GoalContribution, error) {
+	var contribution entities.GoalContribution
+	err := r.db.Get(&contribution, `SELECT * FROM goal_contributions WHERE contribution_id = ?`, contributionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &contribution, nil
+}
+
+func (r *GoalRepositoryImpl) FindContributionsByGoalID(goalID string) ([]*entities.GoalContribution, error) {
+	var contributions []*entities.GoalContribution
+	query := `SELECT * FROM goal_contributions WHERE goal_id = ? ORDER BY date DESC, created_at DESC`
+	err := r.db.Select(&contributions, query, goalID)
+	if err != nil {
+		return nil, err
+	}
+	return contributions, nil
+}
+
+func (r *GoalRepositoryImpl) DeleteContribution(contributionID string) error {
+	_, err := r.db.Exec(`DELETE FROM goal_contributions WHERE contribution_id = ?`, contributionID)
+	return err
+}
+
+func (r *GoalRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	for _, table := range []string{"goals", "goal_contributions"} {
+		if _, err := r.db.Exec(`UPDATE `+table+` SET user_id = ? WHERE user_id = ?`, newUserID, oldUserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type IncomeRepositoryImpl struct {
+	db queryer
+}
+
+func NewIncomeRepository(db *sqlx.DB) *IncomeRepositoryImpl {
+	return &IncomeRepositoryImpl{db: db}
+}
+
+func (r *IncomeRepositoryImpl) Create(income *entities.Income) error {
+	query := `
+		INSERT INTO incomes (income_id, user_id, type, description, amount_pen, amount_usd, amount_original, reporting_currency, amount_reporting, exchange_rate, currency, source, date, created_at, updated_at)
+		VALUES (:income_id, :user_id, :type, :description, :amount_pen, :amount_usd, :amount_original, :reporting_currency, :amount_reporting, :exchange_rate, :currency, :source, :date, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, income)
+	return err
+}
+
+func (r *IncomeRepositoryImpl) FindByID(incomeID string) (*entities.Income, error) {
+	var income entities.Income
+	query := `SELECT * FROM incomes WHERE income_id = ?`
+	err := r.db.Get(&income, query, incomeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &income, nil
+}
+
+func (r *IncomeRepositoryImpl) FindByUserID(userID string, from *time.Time, to *time.Time) ([]*entities.Income, error) {
+	query := `SELECT * FROM incomes WHERE user_id = ?`
+	args := []interface{}{userID}
+	if from != nil {
+		query += ` AND date >= ?`
+		args = append(args, *from)
+	}
+	if to != nil {
+		query += ` AND date < ?`
+		args = append(args, *to)
+	}
+	query += ` ORDER BY date DESC, created_at DESC`
+
+	var incomes []*entities.Income
+	if err := r.db.Select(&incomes, query, args...); err != nil {
+		return nil, err
+	}
+	return incomes, nil
+}
+
+func (r *IncomeRepositoryImpl) Update(income *entities.Income) error {
+	query := `
+		UPDATE incomes
+		SET type = :type, description = :description, amount_pen = :amount_pen, amount_usd = :amount_usd,
+			amount_original = :amount_original, reporting_currency = :reporting_currency, amount_reporting = :amount_reporting,
+			exchange_rate = :exchange_rate, currency = :currency, date = :date, updated_at = :updated_at
+		WHERE income_id = :income_id
+	`
+	_, err := r.db.NamedExec(query, income)
+	return err
+}
+
+func (r *IncomeRepositoryImpl) Delete(incomeID string) error {
+	query := `DELETE FROM incomes WHERE income_id = ?`
+	_, err := r.db.Exec(query, incomeID)
+	return err
+}
+
+func (r *IncomeRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	query := `UPDATE incomes SET user_id = ? WHERE user_id = ?`
+	_, err := r.db.Exec(query, newUserID, oldUserID)
+	return err
+}
+
+func (r *IncomeRepositoryImpl) UpdateReportingAmount(incomeID string, reportingCurrency string, amountReporting float64) error {
+	query := `UPDATE incomes SET reporting_currency = ?, amount_reporting = ? WHERE income_id = ?`
+	_, err := r.db.Exec(query, reportingCurrency, amountReporting, incomeID)
+	return err
+}
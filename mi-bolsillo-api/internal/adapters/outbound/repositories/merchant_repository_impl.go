@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type MerchantRepositoryImpl struct {
+	db queryer
+}
+
+func NewMerchantRepository(db *sqlx.DB) *MerchantRepositoryImpl {
+	return &MerchantRepositoryImpl{db: db}
+}
+
+func (r *MerchantRepositoryImpl) Create(merchant *entities.Merchant) error {
+	query := `
+		INSERT INTO merchants (merchant_id, user_id, name, merchant_key, category_id, created_at, updated_at)
+		VALUES (:merchant_id, :user_id, :name, :merchant_key, :category_id, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, merchant)
+	return err
+}
+
+func (r *MerchantRepositoryImpl) FindByUserID(userID string) ([]*entities.Merchant, error) {
+	var merchants []*entities.Merchant
+	query := `SELECT * FROM merchants WHERE user_id = ? ORDER BY created_at`
+	err := r.db.Select(&merchants, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
+
+func (r *MerchantRepositoryImpl) Update(merchant *entities.Merchant) error {
+	query := `
+		UPDATE merchants
+		SET name = :name, merchant_key = :merchant_key, category_id = :category_id, updated_at = :updated_at
+		WHERE merchant_id = :merchant_id
+	`
+	_, err := r.db.NamedExec(query, merchant)
+	return err
+}
+
+func (r *MerchantRepositoryImpl) CreateAlias(alias *entities.MerchantAlias) error {
+	query := `
+		INSERT OR IGNORE INTO merchant_aliases (user_id, alias, merchant_id, created_at)
+		VALUES (:user_id, :alias, :merchant_id, :created_at)
+	`
+	_, err := r.db.NamedExec(query, alias)
+	return err
+}
+
+func (r *MerchantRepositoryImpl) FindAliasesByUserID(userID string) ([]*entities.MerchantAlias, error) {
+	var aliases []*entities.MerchantAlias
+	query := `SELECT * FROM merchant_aliases WHERE user_id = ?`
+	err := r.db.Select(&aliases, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func (r *MerchantRepositoryImpl) ReassignCategory(fromCategoryID string, toCategoryID string) error {
+	query := `UPDATE merchants SET category_id = ? WHERE category_id = ?`
+	_, err := r.db.Exec(query, toCategoryID, fromCategoryID)
+	return err
+}
+
+// UpdateUserID moves the merchants to the new user. An alias both users have keeps pointing to the
+// new user's merchant
+func (r *MerchantRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	// Merchants both users have keep the new user's row, taking the old category if it had none
+	duplicate := `
+		SELECT o.merchant_id FROM merchants o
+		JOIN merchants n ON n.user_id = ? AND n.merchant_key = o.merchant_key
+		WHERE o.user_id = ?
+	`
+	query := `
+		UPDATE merchant_aliases
+		SET merchant_id = (
+			SELECT n.merchant_id FROM merchants o
+			JOIN merchants n ON n.user_id = ? AND n.merchant_key = o.merchant_key
+			WHERE o.merchant_id = merchant_aliases.merchant_id
+		)
+		WHERE user_id = ? AND merchant_id IN (` + duplicate + `)
+	`
+	if _, err := r.db.Exec(query, newUserID, oldUserID, newUserID, oldUserID); err != nil {
+		return err
+	}
+	query = `
+		UPDATE merchants
+		SET category_id = (
+			SELECT o.category_id FROM merchants o
+			WHERE o.user_id = ? AND o.merchant_key = merchants.merchant_key
+		)
+		WHERE user_id = ? AND category_id = '' AND merchant_key IN (
+			SELECT merchant_key FROM merchants WHERE user_id = ? AND category_id != ''
+		)
+	`
+	if _, err := r.db.Exec(query, oldUserID, newUserID, oldUserID); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE merchants SET user_id = ?
+		WHERE user_id = ? AND merchant_key NOT IN (SELECT merchant_key FROM merchants WHERE user_id = ?)
+	`
+	if _, err := r.db.Exec(query, newUserID, oldUserID, newUserID); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`DELETE FROM merchants WHERE user_id = ?`, oldUserID); err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(`UPDATE OR IGNORE merchant_aliases SET user_id = ? WHERE user_id = ?`, newUserID, oldUserID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(`DELETE FROM merchant_aliases WHERE user_id = ?`, oldUserID)
+	return err
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type OTPRepositoryImpl struct {
-	db *sqlx.DB
+	db queryer
 }
 
 func NewOTPRepository(db *sqlx.DB) *OTPRepositoryImpl {
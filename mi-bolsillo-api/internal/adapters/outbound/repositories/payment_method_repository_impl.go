@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type PaymentMethodRepositoryImpl struct {
+	db queryer
+}
+
+func NewPaymentMethodRepository(db *sqlx.DB) *PaymentMethodRepositoryImpl {
+	return &PaymentMethodRepositoryImpl{db: db}
+}
+
+func (r *PaymentMethodRepositoryImpl) Create(paymentMethod *entities.PaymentMethod) error {
+	query := `
+		INSERT INTO payment_methods (payment_method_id, user_id, name, type, currency, last_four, credit_limit, statement_closing_day, created_at, updated_at)
+		VALUES (:payment_method_id, :user_id, :name, :type, :currency, :last_four, :credit_limit, :statement_closing_day, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, paymentMethod)
+	return err
+}
+
+func (r *PaymentMethodRepositoryImpl) FindByID(paymentMethodID string) (*entities.PaymentMethod, error) {
+	var paymentMethod entities.PaymentMethod
+	query := `SELECT * FROM payment_methods WHERE payment_method_id = ?`
+	err := r.db.Get(&paymentMethod, query, paymentMethodID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &paymentMethod, nil
+}
+
+func (r *PaymentMethodRepositoryImpl) FindByUserID(userID string) ([]*entities.PaymentMethod, error) {
+	var paymentMethods []*entities.PaymentMethod
+	query := `SELECT * FROM payment_methods WHERE user_id = ? ORDER BY name ASC`
+	err := r.db.Select(&paymentMethods, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return paymentMethods, nil
+}
+
+func (r *PaymentMethodRepositoryImpl) Update(paymentMethod *entities.PaymentMethod) error {
+	query := `
+		UPDATE payment_methods
+		SET name = :name, type = :type, currency = :currency, last_four = :last_four, credit_limit = :credit_limit,
+			statement_closing_day = :statement_closing_day, updated_at = :updated_at
+		WHERE payment_method_id = :payment_method_id
+	`
+	_, err := r.db.NamedExec(query, paymentMethod)
+	return err
+}
+
+func (r *PaymentMethodRepositoryImpl) Delete(paymentMethodID string) error {
+	queries := []string{
+		`UPDATE bills SET payment_method_id = '' WHERE payment_method_id = ?`,
+		`UPDATE bill_drafts SET payment_method_id = '' WHERE payment_method_id = ?`,
+		`DELETE FROM payment_methods WHERE payment_method_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query, paymentMethodID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PaymentMethodRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	query := `UPDATE payment_methods SET user_id = ? WHERE user_id = ?`
+	_, err := r.db.Exec(query, newUserID, oldUserID)
+	return err
+}
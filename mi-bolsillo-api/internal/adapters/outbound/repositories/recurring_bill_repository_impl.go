@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type RecurringBillRepositoryImpl struct {
+	db queryer
+}
+
+func NewRecurringBillRepository(db *sqlx.DB) *RecurringBillRepositoryImpl {
+	return &RecurringBillRepositoryImpl{db: db}
+}
+
+func (r *RecurringBillRepositoryImpl) Create(recurringBill *entities.RecurringBill) error {
+	query := `
+		INSERT INTO recurring_bills (recurring_bill_id, user_id, description, category, amount, currency, frequency, interval_count, day_of_month, day_of_week, month_of_year, start_date, end_date, next_occurrence, active, created_at, updated_at)
+		VALUES (:recurring_bill_id, :user_id, :description, :category, :amount, :currency, :frequency, :interval_count, :day_of_month, :day_of_week, :month_of_year, :start_date, :end_date, :next_occurrence, :active, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, recurringBill)
+	return err
+}
+
+func (r *RecurringBillRepositoryImpl) FindByID(recurringBillID string) (*entities.RecurringBill, error) {
+	var recurringBill entities.RecurringBill
+	query := `SELECT * FROM recurring_bills WHERE recurring_bill_id = ?`
+	err := r.db.Get(&recurringBill, query, recurringBillID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &recurringBill, nil
+}
+
+func (r *RecurringBillRepositoryImpl) FindByUserID(userID string) ([]*entities.RecurringBill, error) {
+	var recurringBills []*entities.RecurringBill
+	query := `SELECT * FROM recurring_bills WHERE user_id = ? ORDER BY next_occurrence`
+	err := r.db.Select(&recurringBills, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return recurringBills, nil
+}
+
+func (r *RecurringBillRepositoryImpl) FindDue(date string) ([]*entities.RecurringBill, error) {
+	var recurringBills []*entities.RecurringBill
+	query := `SELECT * FROM recurring_bills WHERE active = 1 AND next_occurrence <= ? ORDER BY next_occurrence`
+	err := r.db.Select(&recurringBills, query, date)
+	if err != nil {
+		return nil, err
+	}
+	return recurringBills, nil
+}
+
+func (r *RecurringBillRepositoryImpl) Update(recurringBill *entities.RecurringBill) error {
+	query := `
+		UPDATE recurring_bills
+		SET description = :description, category = :category, amount = :amount, currency = :currency,
+			frequency = :frequency, interval_count = :interval_count, day_of_month = :day_of_month,
+			day_of_week = :day_of_week, month_of_year = :month_of_year, start_date = :start_date,
+			end_date = :end_date, next_occurrence = :next_occurrence, active = :active, updated_at = :updated_at
+		WHERE recurring_bill_id = :recurring_bill_id
+	`
+	_, err := r.db.NamedExec(query, recurringBill)
+	return err
+}
+
+func (r *RecurringBillRepositoryImpl) Delete(recurringBillID string) error {
+	query := `DELETE FROM recurring_bills WHERE recurring_bill_id = ?`
+	_, err := r.db.Exec(query, recurringBillID)
+	return err
+}
+
+func (r *RecurringBillRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	query := `UPDATE recurring_bills SET user_id = ? WHERE user_id = ?`
+	_, err := r.db.Exec(query, newUserID, oldUserID)
+	return err
+}
+
+func (r *RecurringBillRepositoryImpl) RenameCategory(userID string, from string, to string) error {
+	query := `UPDATE recurring_bills SET category = ? WHERE user_id = ? AND LOWER(category) = LOWER(?)`
+	_, err := r.db.Exec(query, to, userID, from)
+	return err
+}
+
+func (r *RecurringBillRepositoryImpl) ClaimOccurrence(recurringBillID string, date string, billID string) (bool, error) {
+	query := `
+		INSERT OR IGNORE INTO recurring_bill_occurrences (recurring_bill_id, occurrence_date, bill_id, created_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+	`
+	result, err := r.db.Exec(query, recurringBillID, date, billID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type SplitRepositoryImpl struct {
+	db queryer
+}
+
+func NewSplitRepository(db *sqlx.DB) *SplitRepositoryImpl {
+	return &SplitRepositoryImpl{db: db}
+}
+
+// Save replaces the bill's split in several statements, callers run it in a unit of work
+func (r *SplitRepositoryImpl) Save(split *entities.BillSplit, items []*entities.BillSplitItem) error {
+	if err := r.DeleteByBillID(split.BillID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO bill_splits (bill_id, user_id, wallet_id, paid_by, amount, currency, created_at, updated_at)
+		VALUES (:bill_id, :user_id, :wallet_id, :paid_by, :amount, :currency, :created_at, :updated_at)
+	`
+	if _, err := r.db.NamedExec(query, split); err != nil {
+		return err
+	}
+
+	for i := range split.Shares {
+		share := &split.Shares[i]
+		share.BillID = split.BillID
+		query := `
+			INSERT INTO bill_split_shares (bill_id, participant, amount, percentage)
+			VALUES (:bill_id, :participant, :amount, :percentage)
+		`
+		if _, err := r.db.NamedExec(query, share); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		query := `
+			INSERT INTO bill_split_items (bill_id, expense_id, participant)
+			VALUES (:bill_id, :expense_id, :participant)
+		`
+		if _, err := r.db.NamedExec(query, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SplitRepositoryImpl) FindByBillID(billID string) (*entities.BillSplit, error) {
+	var split entities.BillSplit
+	err := r.db.Get(&split, `SELECT * FROM bill_splits WHERE bill_id = ?`, billID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := r.db.Select(&split.Shares, `SELECT * FROM bill_split_shares WHERE bill_id = ? ORDER BY participant`, billID); err != nil {
+		return nil, err
+	}
+
+	var items []*entities.BillSplitItem
+	if err := r.db.Select(&items, `SELECT * FROM bill_split_items WHERE bill_id = ? ORDER BY expense_id`, billID); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		for i := range split.Shares {
+			if split.Shares[i].Participant == item.Participant {
+				split.Shares[i].ExpenseIDs = append(split.Shares[i].ExpenseIDs, item.ExpenseID)
+			}
+		}
+	}
+	return &split, nil
+}
+
+func (r *SplitRepositoryImpl) FindByLedger(userID string, walletID string) ([]*entities.BillSplit, error) {
+	where, args := ledgerCondition("b", userID, walletID)
+
+	var splits []*entities.BillSplit
+	if err := r.db.Select(&splits, `SELECT b.* FROM bill_splits b WHERE `+where+` ORDER BY b.created_at`, args...); err != nil {
+		return nil, err
+	}
+
+	var shares []entities.BillSplitShare
+	query := `SELECT s.* FROM bill_split_shares s JOIN bill_splits b ON b.bill_id = s.bill_id WHERE ` + where
+	if err := r.db.Select(&shares, query, args...); err != nil {
+		return nil, err
+	}
+
+	byBill := make(map[string]*entities.BillSplit, len(splits))
+	for _, split := range splits {
+		byBill[split.BillID] = split
+	}
+	for _, share := range shares {
+		if split := byBill[share.BillID]; split != nil {
+			split.Shares = append(split.Shares, share)
+		}
+	}
+	return splits, nil
+}
+
+func (r *SplitRepositoryImpl) DeleteByBillID(billID string) error {
+	queries := []string{
+		`DELETE FROM bill_split_items WHERE bill_id = ?`,
+		`DELETE FROM bill_split_shares WHERE bill_id = ?`,
+		`DELETE FROM bill_splits WHERE bill_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query, billID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SplitRepositoryImpl) CreateSettlement(settlement *entities.Settlement) error {
+	query := `
+		INSERT INTO settlements (settlement_id, user_id, wallet_id, from_participant, to_participant, amount, currency, note, date, created_at)
+		VALUES (:settlement_id, :user_id, :wallet_id, :from_participant, :to_participant, :amount, :currency, :note, :date, :created_at)
+	`
+	_, err := r.db.NamedExec(query, settlement)
+	return err
+}
+
+func (r *SplitRepositoryImpl) FindSettlementByID(settlementID string) (*entities.Settlement, error) {
+	var settlement entities.Settlement
+	err := r.db.Get(&settlement, `SELECT * FROM settlements WHERE settlement_id = ?`, settlementID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+func (r *SplitRepositoryImpl) FindSettlementsByLedger(userID string, walletID string) ([]*entities.Settlement, error) {
+	where, args := ledgerCondition("s", userID, walletID)
+
+	var settlements []*entities.Settlement
+	query := `SELECT s.* FROM settlements s WHERE ` + where + ` ORDER BY s.date DESC, s.created_at DESC`
+	if err := r.db.Select(&settlements, query, args...); err != nil {
+		return nil, err
+	}
+	return settlements, nil
+}
+
+func (r *SplitRepositoryImpl) DeleteSettlement(settlementID string) error {
+	_, err := r.db.Exec(`DELETE FROM settlements WHERE settlement_id = ?`, settlementID)
+	return err
+}
+
+func (r *SplitRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	for _, table := range []string{"bill_splits", "settlements"} {
+		if _, err := r.db.Exec(`UPDATE `+table+` SET user_id = ? WHERE user_id = ?`, newUserID, oldUserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ledgerCondition filters the rows of a table alias to a wallet's ledger, or the user's personal one
+func ledgerCondition(alias string, userID string, walletID string) (string, []interface{}) {
+	if walletID != "" {
+		return alias + ".wallet_id = ?", []interface{}{walletID}
+	}
+	return alias + ".user_id = ? AND " + alias + ".wallet_id = ''", []interface{}{userID}
+}
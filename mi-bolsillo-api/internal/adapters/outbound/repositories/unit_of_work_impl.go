@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/jmoiron/sqlx"
+)
+
+// queryer is implemented by both *sqlx.DB and *sqlx.Tx, so repositories can run inside a unit of work
+type queryer interface {
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	NamedExec(query string, arg interface{}) (sql.Result, error)
+}
+
+type UnitOfWorkImpl struct {
+	db *sqlx.DB
+}
+
+func NewUnitOfWork(db *sqlx.DB) *UnitOfWorkImpl {
+	return &UnitOfWorkImpl{db: db}
+}
+
+func (u *UnitOfWorkImpl) Do(fn func(repos ports.TxRepositories) error) error {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	repos := ports.TxRepositories{
+		Bills:          &BillRepositoryImpl{db: tx},
+		Expenses:       &ExpenseRepositoryImpl{db: tx},
+		Budgets:        &BudgetRepositoryImpl{db: tx},
+		RecurringBills: &RecurringBillRepositoryImpl{db: tx},
+		Categories:     &CategoryRepositoryImpl{db: tx},
+		CategoryRules:  &CategoryRuleRepositoryImpl{db: tx},
+		Merchants:      &MerchantRepositoryImpl{db: tx},
+		Wallets:        &WalletRepositoryImpl{db: tx},
+		Splits:         &SplitRepositoryImpl{db: tx},
+		Incomes:        &IncomeRepositoryImpl{db: tx},
+		PaymentMethods: &PaymentMethodRepositoryImpl{db: tx},
+		Goals:          &GoalRepositoryImpl{db: tx},
+		Users:          &UserRepositoryImpl{db: tx},
+		OTPs:           &OTPRepositoryImpl{db: tx},
+	}
+
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
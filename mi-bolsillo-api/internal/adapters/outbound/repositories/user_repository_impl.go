@@ -9,7 +9,7 @@ import (
 )
 
 type UserRepositoryImpl struct {
-	db *sqlx.DB
+	db queryer
 }
 
 func NewUserRepository(db *sqlx.DB) *UserRepositoryImpl {
@@ -18,8 +18,8 @@ func NewUserRepository(db *sqlx.DB) *UserRepositoryImpl {
 
 func (r *UserRepositoryImpl) Create(user *entities.User) error {
 	query := `
-		INSERT INTO users (user_id, clerk_id, telegram_id, created_at, updated_at)
-		VALUES (:user_id, :clerk_id, :telegram_id, :created_at, :updated_at)
+		INSERT INTO users (user_id, clerk_id, telegram_id, reporting_currency, created_at, updated_at)
+		VALUES (:user_id, :clerk_id, :telegram_id, :reporting_currency, :created_at, :updated_at)
 	`
 	_, err := r.db.NamedExec(query, user)
 	return err
@@ -67,7 +67,7 @@ func (r *UserRepositoryImpl) FindByTelegramID(telegramID int64) (*entities.User,
 func (r *UserRepositoryImpl) Update(user *entities.User) error {
 	query := `
 		UPDATE users
-		SET clerk_id = :clerk_id, telegram_id = :telegram_id, updated_at = :updated_at
+		SET clerk_id = :clerk_id, telegram_id = :telegram_id, reporting_currency = :reporting_currency, updated_at = :updated_at
 		WHERE user_id = :user_id
 	`
 	_, err := r.db.NamedExec(query, user)
@@ -83,3 +83,9 @@ func (r *UserRepositoryImpl) LinkClerkAccount(userID string, clerkID string) err
 	_, err := r.db.Exec(query, clerkID, userID)
 	return err
 }
+
+func (r *UserRepositoryImpl) Delete(userID string) error {
+	query := `DELETE FROM users WHERE user_id = ?`
+	_, err := r.db.Exec(query, userID)
+	return err
+}
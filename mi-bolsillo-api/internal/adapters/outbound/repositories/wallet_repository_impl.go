@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+type WalletRepositoryImpl struct {
+	db queryer
+}
+
+func NewWalletRepository(db *sqlx.DB) *WalletRepositoryImpl {
+	return &WalletRepositoryImpl{db: db}
+}
+
+func (r *WalletRepositoryImpl) Create(wallet *entities.Wallet) error {
+	query := `
+		INSERT INTO wallets (wallet_id, name, owner_id, telegram_chat_id, created_at, updated_at)
+		VALUES (:wallet_id, :name, :owner_id, :telegram_chat_id, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, wallet)
+	return err
+}
+
+func (r *WalletRepositoryImpl) FindByID(walletID string) (*entities.Wallet, error) {
+	var wallet entities.Wallet
+	query := `SELECT * FROM wallets WHERE wallet_id = ?`
+	err := r.db.Get(&wallet, query, walletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepositoryImpl) FindByUserID(userID string) ([]*entities.Wallet, error) {
+	var wallets []*entities.Wallet
+	query := `
+		SELECT w.* FROM wallets w
+		JOIN wallet_members m ON m.wallet_id = w.wallet_id
+		WHERE m.user_id = ?
+		ORDER BY w.created_at
+	`
+	err := r.db.Select(&wallets, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+func (r *WalletRepositoryImpl) FindByTelegramChatID(chatID int64) (*entities.Wallet, error) {
+	var wallet entities.Wallet
+	query := `SELECT * FROM wallets WHERE telegram_chat_id = ?`
+	err := r.db.Get(&wallet, query, chatID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepositoryImpl) Update(wallet *entities.Wallet) error {
+	query := `
+		UPDATE wallets
+		SET name = :name, owner_id = :owner_id, telegram_chat_id = :telegram_chat_id, updated_at = :updated_at
+		WHERE wallet_id = :wallet_id
+	`
+	_, err := r.db.NamedExec(query, wallet)
+	return err
+}
+
+func (r *WalletRepositoryImpl) Delete(walletID string) error {
+	queries := []string{
+		`UPDATE bills SET wallet_id = '' WHERE wallet_id = ?`,
+		`UPDATE bill_drafts SET wallet_id = '' WHERE wallet_id = ?`,
+		`UPDATE bill_splits SET wallet_id = '' WHERE wallet_id = ?`,
+		`UPDATE settlements SET wallet_id = '' WHERE wallet_id = ?`,
+		`DELETE FROM wallet_invites WHERE wallet_id = ?`,
+		`DELETE FROM wallet_members WHERE wallet_id = ?`,
+		`DELETE FROM wallets WHERE wallet_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query, walletID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *WalletRepositoryImpl) AddMember(member *entities.WalletMember) error {
+	query := `
+		INSERT INTO wallet_members (wallet_id, user_id, role, created_at, updated_at)
+		VALUES (:wallet_id, :user_id, :role, :created_at, :updated_at)
+	`
+	_, err := r.db.NamedExec(query, member)
+	return err
+}
+
+func (r *WalletRepositoryImpl) FindMember(walletID string, userID string) (*entities.WalletMember, error) {
+	var member entities.WalletMember
+	query := `SELECT * FROM wallet_members WHERE wallet_id = ? AND user_id = ?`
+	err := r.db.Get(&member, query, walletID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *WalletRepositoryImpl) FindMembers(walletID string) ([]*entities.WalletMember, error) {
+	var members []*entities.WalletMember
+	query := `SELECT * FROM wallet_members WHERE wallet_id = ? ORDER BY created_at`
+	err := r.db.Select(&members, query, walletID)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *WalletRepositoryImpl) UpdateMember(member *entities.WalletMember) error {
+	query := `
+		UPDATE wallet_members SET role = :role, updated_at = :updated_at
+		WHERE wallet_id = :wallet_id AND user_id = :user_id
+	`
+	_, err := r.db.NamedExec(query, member)
+	return err
+}
+
+func (r *WalletRepositoryImpl) RemoveMember(walletID string, userID string) error {
+	query := `DELETE FROM wallet_members WHERE wallet_id = ? AND user_id = ?`
+	_, err := r.db.Exec(query, walletID, userID)
+	return err
+}
+
+func (r *WalletRepositoryImpl) CreateInvite(invite *entities.WalletInvite) error {
+	query := `
+		INSERT INTO wallet_invites (code, wallet_id, role, created_by, expires_at, created_at)
+		VALUES (:code, :wallet_id, :role, :created_by, :expires_at, :created_at)
+	`
+	_, err := r.db.NamedExec(query, invite)
+	return err
+}
+
+func (r *WalletRepositoryImpl) FindInvite(code string) (*entities.WalletInvite, error) {
+	var invite entities.WalletInvite
+	query := `SELECT * FROM wallet_invites WHERE code = ?`
+	err := r.db.Get(&invite, query, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (r *WalletRepositoryImpl) UpdateUserID(oldUserID string, newUserID string) error {
+	// Promote the new user's membership in wallets both users belong to, ranking owner > editor > viewer
+	query := `
+		UPDATE wallet_members
+		SET role = (
+			SELECT o.role FROM wallet_members o
+			WHERE o.wallet_id = wallet_members.wallet_id AND o.user_id = ?
+		)
+		WHERE user_id = ? AND EXISTS (
+			SELECT 1 FROM wallet_members o
+			WHERE o.wallet_id = wallet_members.wallet_id AND o.user_id = ?
+				AND (o.role = 'owner' OR (o.role = 'editor' AND wallet_members.role = 'viewer'))
+		)
+	`
+	if _, err := r.db.Exec(query, oldUserID, newUserID, oldUserID); err != nil {
+		return err
+	}
+
+	queries := []string{
+		`UPDATE OR IGNORE wallet_members SET user_id = ? WHERE user_id = ?`,
+		`UPDATE wallets SET owner_id = ? WHERE owner_id = ?`,
+		`UPDATE wallet_invites SET created_by = ? WHERE created_by = ?`,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query, newUserID, oldUserID); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.db.Exec(`DELETE FROM wallet_members WHERE user_id = ?`, oldUserID)
+	return err
+}
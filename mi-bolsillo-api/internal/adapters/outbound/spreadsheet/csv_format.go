@@ -0,0 +1,59 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// CSVFormat writes exports as comma separated values, streaming each row as it's written
+type CSVFormat struct{}
+
+func NewCSVFormat() *CSVFormat {
+	return &CSVFormat{}
+}
+
+func (f *CSVFormat) Name() string {
+	return "csv"
+}
+
+func (f *CSVFormat) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (f *CSVFormat) NewWriter(w io.Writer) (ports.SpreadsheetWriter, error) {
+	return &csvWriter{writer: csv.NewWriter(w)}, nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	closed bool
+}
+
+func (w *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case nil:
+			record[i] = ""
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,70 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxSheet = "Sheet1"
+
+// XLSXFormat writes exports as an Excel workbook with a single sheet. Rows are streamed
+// into a temporary file by excelize and the workbook is written out on Close
+type XLSXFormat struct{}
+
+func NewXLSXFormat() *XLSXFormat {
+	return &XLSXFormat{}
+}
+
+func (f *XLSXFormat) Name() string {
+	return "xlsx"
+}
+
+func (f *XLSXFormat) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (f *XLSXFormat) NewWriter(w io.Writer) (ports.SpreadsheetWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create xlsx stream: %w", err)
+	}
+	return &xlsxWriter{file: file, stream: stream, out: w}, nil
+}
+
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+	closed bool
+}
+
+func (w *xlsxWriter) WriteRow(values []interface{}) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to flush xlsx stream: %w", err)
+	}
+	if _, err := w.file.WriteTo(w.out); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
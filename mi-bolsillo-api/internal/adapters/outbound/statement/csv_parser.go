@@ -0,0 +1,215 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// CSVMapping describes the layout of a bank's CSV export. Columns are matched by header name,
+// case-insensitively. A statement either has a signed AmountColumn (charges negative) or
+// separate DebitColumn and CreditColumn with positive values
+type CSVMapping struct {
+	Delimiter string `json:"delimiter"`
+	// SkipRows is the number of lines before the header row, e.g. account details
+	SkipRows          int    `json:"skipRows"`
+	DateColumn        string `json:"dateColumn"`
+	DateLayout        string `json:"dateLayout"`
+	DescriptionColumn string `json:"descriptionColumn"`
+	AmountColumn      string `json:"amountColumn"`
+	DebitColumn       string `json:"debitColumn"`
+	CreditColumn      string `json:"creditColumn"`
+	CurrencyColumn    string `json:"currencyColumn"`
+	DefaultCurrency   string `json:"defaultCurrency"`
+	// DecimalSeparator is "." unless the bank writes amounts as 1.234,56
+	DecimalSeparator string `json:"decimalSeparator"`
+}
+
+// LoadCSVParsers reads the bank CSV mappings from a JSON file keyed by format name:
+//
+//	{"bcp": {"delimiter": ";", "dateColumn": "Fecha", ...}}
+func LoadCSVParsers(path string) (map[string]ports.StatementParser, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bank statement formats file: %w", err)
+	}
+
+	var mappings map[string]CSVMapping
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bank statement formats file: %w", err)
+	}
+
+	parsers := make(map[string]ports.StatementParser, len(mappings))
+	for name, mapping := range mappings {
+		parsers[name] = NewCSVParser(mapping)
+	}
+	return parsers, nil
+}
+
+// CSVParser reads bank statements exported as CSV using a column mapping
+type CSVParser struct {
+	mapping CSVMapping
+}
+
+func NewCSVParser(mapping CSVMapping) *CSVParser {
+	return &CSVParser{mapping: mapping}
+}
+
+func (p *CSVParser) Parse(r io.Reader) ([]entities.StatementTransaction, error) {
+	reader := bufio.NewReader(r)
+	for i := 0; i < p.mapping.SkipRows; i++ {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, fmt.Errorf("statement has fewer than %d preamble rows", p.mapping.SkipRows)
+		}
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	if p.mapping.Delimiter != "" {
+		csvReader.Comma = []rune(p.mapping.Delimiter)[0]
+	}
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[normalizeHeader(name)] = i
+	}
+
+	dateIndex, ok := columns[normalizeHeader(p.mapping.DateColumn)]
+	if !ok {
+		return nil, fmt.Errorf("statement has no %q column", p.mapping.DateColumn)
+	}
+	descriptionIndex, ok := columns[normalizeHeader(p.mapping.DescriptionColumn)]
+	if !ok {
+		return nil, fmt.Errorf("statement has no %q column", p.mapping.DescriptionColumn)
+	}
+
+	var transactions []entities.StatementTransaction
+	for line := 2 + p.mapping.SkipRows; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		// Banks append totals and blank lines after the movements
+		value := field(record, dateIndex)
+		if value == "" {
+			continue
+		}
+		date, err := time.ParseInLocation(p.mapping.DateLayout, value, time.Local)
+		if err != nil {
+			continue
+		}
+
+		amount, err := p.amount(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		currency := p.mapping.DefaultCurrency
+		if index, ok := columns[normalizeHeader(p.mapping.CurrencyColumn)]; ok && p.mapping.CurrencyColumn != "" {
+			if value := field(record, index); value != "" {
+				currency = value
+			}
+		}
+
+		transactions = append(transactions, entities.StatementTransaction{
+			Date:        date,
+			Description: field(record, descriptionIndex),
+			Amount:      amount,
+			Currency:    currency,
+		})
+	}
+
+	return transactions, nil
+}
+
+// amount returns the signed amount of a row, charges are negative
+func (p *CSVParser) amount(record []string, columns map[string]int) (float64, error) {
+	if p.mapping.AmountColumn != "" {
+		index, ok := columns[normalizeHeader(p.mapping.AmountColumn)]
+		if !ok {
+			return 0, fmt.Errorf("statement has no %q column", p.mapping.AmountColumn)
+		}
+		return p.parseAmount(field(record, index))
+	}
+
+	var debit, credit float64
+	if index, ok := columns[normalizeHeader(p.mapping.DebitColumn)]; ok {
+		value, err := p.parseAmount(field(record, index))
+		if err != nil {
+			return 0, err
+		}
+		debit = value
+	}
+	if index, ok := columns[normalizeHeader(p.mapping.CreditColumn)]; ok {
+		value, err := p.parseAmount(field(record, index))
+		if err != nil {
+			return 0, err
+		}
+		credit = value
+	}
+
+	// Some banks already write charges as negative numbers in the debit column
+	if debit < 0 {
+		debit = -debit
+	}
+	return credit - debit, nil
+}
+
+func (p *CSVParser) parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	// Drop currency symbols and thousands separators, e.g. "S/ 1,234.56"
+	decimal := p.mapping.DecimalSeparator
+	if decimal == "" {
+		decimal = "."
+	}
+	var cleaned strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9', r == '-':
+			cleaned.WriteRune(r)
+		case string(r) == decimal:
+			cleaned.WriteRune('.')
+		}
+	}
+
+	amount, err := strconv.ParseFloat(cleaned.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// normalizeHeader lowercases a column name, dropping the UTF-8 BOM some banks prepend
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
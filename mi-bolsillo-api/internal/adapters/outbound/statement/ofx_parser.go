@@ -0,0 +1,109 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+var (
+	ofxTransactionStart = regexp.MustCompile(`(?i)<STMTTRN>`)
+	ofxTransactionEnd   = regexp.MustCompile(`(?i)</STMTTRN>|</BANKTRANLIST>`)
+	ofxCurrencyPattern  = regexp.MustCompile(`(?i)<CURDEF>\s*([A-Za-z]{3})`)
+)
+
+// ofxElementPatterns match the value of each leaf element read from a transaction, which runs
+// until the next tag or line break
+var ofxElementPatterns = map[string]*regexp.Regexp{}
+
+func init() {
+	for _, name := range []string{"DTPOSTED", "TRNAMT", "NAME", "MEMO", "CURRENCY", "FITID"} {
+		ofxElementPatterns[name] = regexp.MustCompile(`(?i)<` + name + `>([^<\r\n]*)`)
+	}
+}
+
+// OFXParser reads OFX statements, both the SGML 1.x flavour (no closing element tags)
+// and the XML 2.x one
+type OFXParser struct{}
+
+func NewOFXParser() *OFXParser {
+	return &OFXParser{}
+}
+
+func (p *OFXParser) Parse(r io.Reader) ([]entities.StatementTransaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OFX statement: %w", err)
+	}
+	content := string(data)
+
+	currency := "PEN"
+	if match := ofxCurrencyPattern.FindStringSubmatch(content); match != nil {
+		currency = strings.ToUpper(match[1])
+	}
+
+	// SGML statements may leave <STMTTRN> unclosed, so each transaction runs until the next one
+	// starts, the closing tag or the end of the transaction list
+	var transactions []entities.StatementTransaction
+	for _, block := range ofxTransactionStart.Split(content, -1)[1:] {
+		if end := ofxTransactionEnd.FindStringIndex(block); end != nil {
+			block = block[:end[0]]
+		}
+
+		date, err := parseOFXDate(ofxElement(block, "DTPOSTED"))
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(ofxElement(block, "TRNAMT"), ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OFX amount %q", ofxElement(block, "TRNAMT"))
+		}
+
+		description := ofxElement(block, "NAME")
+		if memo := ofxElement(block, "MEMO"); memo != "" && memo != description {
+			description = strings.TrimSpace(description + " " + memo)
+		}
+
+		transactionCurrency := currency
+		if value := ofxElement(block, "CURRENCY"); len(value) == 3 {
+			transactionCurrency = strings.ToUpper(value)
+		}
+
+		transactions = append(transactions, entities.StatementTransaction{
+			Date:        date,
+			Description: description,
+			Amount:      amount,
+			Currency:    transactionCurrency,
+			Reference:   ofxElement(block, "FITID"),
+		})
+	}
+
+	return transactions, nil
+}
+
+// ofxElement returns the value of a leaf element listed in ofxElementPatterns
+func ofxElement(block string, name string) string {
+	match := ofxElementPatterns[name].FindStringSubmatch(block)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(match[1])
+}
+
+// parseOFXDate parses YYYYMMDD[HHMMSS[.XXX]][gmt offset], only the calendar day is kept
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid OFX date %q", value)
+	}
+	date, err := time.ParseInLocation("20060102", value[:8], time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid OFX date %q", value)
+	}
+	return date, nil
+}
@@ -0,0 +1,77 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOFXParserSGMLWithoutClosingTags(t *testing.T) {
+	content := `OFXHEADER:100
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>PEN
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20251010
+<TRNAMT>-45.90
+<FITID>0001
+<NAME>WONG SAN ISIDRO
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20251011120000
+<TRNAMT>-12.50
+<FITID>0002
+<NAME>STARBUCKS
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20251012
+<TRNAMT>1500.00
+<FITID>0003
+<NAME>SUELDO
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+	transactions, err := NewOFXParser().Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(transactions) != 3 {
+		t.Fatalf("Parse() returned %d transactions, want 3", len(transactions))
+	}
+
+	wantReferences := []string{"0001", "0002", "0003"}
+	wantAmounts := []float64{-45.90, -12.50, 1500}
+	for i, transaction := range transactions {
+		if transaction.Reference != wantReferences[i] || transaction.Amount != wantAmounts[i] || transaction.Currency != "PEN" {
+			t.Errorf("transaction %d = %+v, want reference %s amount %v in PEN", i, transaction, wantReferences[i], wantAmounts[i])
+		}
+	}
+	if transactions[1].Date.Format("2006-01-02") != "2025-10-11" {
+		t.Errorf("transaction 1 date = %s, want 2025-10-11", transactions[1].Date.Format("2006-01-02"))
+	}
+}
+
+func TestOFXParserXML(t *testing.T) {
+	content := `<?xml version="1.0"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD</CURDEF>
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>20251010</DTPOSTED><TRNAMT>-20.00</TRNAMT><FITID>A1</FITID><NAME>AMAZON</NAME><MEMO>Books</MEMO></STMTTRN>
+<STMTTRN><DTPOSTED>20251011</DTPOSTED><TRNAMT>-5.00</TRNAMT><FITID>A2</FITID><NAME>UBER</NAME></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+	transactions, err := NewOFXParser().Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("Parse() returned %d transactions, want 2", len(transactions))
+	}
+	if transactions[0].Description != "AMAZON Books" || transactions[0].Currency != "USD" || transactions[0].Reference != "A1" {
+		t.Errorf("transaction 0 = %+v, want AMAZON Books in USD with reference A1", transactions[0])
+	}
+	if transactions[1].Reference != "A2" || transactions[1].Amount != -5 {
+		t.Errorf("transaction 1 = %+v, want reference A2 amount -5", transactions[1])
+	}
+}
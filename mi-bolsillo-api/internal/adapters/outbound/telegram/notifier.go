@@ -0,0 +1,33 @@
+package telegram
+
+import (
+	"fmt"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// NotifyBudgetAlert sends a budget threshold alert to the user's Telegram chat
+// Implements the ports.Notifier interface
+func (c *TelegramClient) NotifyBudgetAlert(telegramID int64, alert *entities.BudgetAlert) error {
+	title := "⚠️ *Alerta de presupuesto*"
+	if alert.Threshold >= 1 {
+		title = "🚨 *Presupuesto excedido*"
+	}
+
+	percent := 0.0
+	if alert.AmountLimit > 0 {
+		percent = alert.Spent / alert.AmountLimit * 100
+	}
+
+	text := fmt.Sprintf("%s\n\n🏷️ Categoría: %s\n📅 Mes: %s\n💰 Gastado: %s %.2f de %s %.2f (%.0f%%)\n💵 Restante: %s %.2f",
+		title,
+		alert.Category,
+		alert.Month,
+		alert.Currency, alert.Spent,
+		alert.Currency, alert.AmountLimit,
+		percent,
+		alert.Currency, alert.AmountLimit-alert.Spent,
+	)
+
+	return c.SendMessage(telegramID, text)
+}
@@ -0,0 +1,104 @@
+package whisper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WhisperClient talks to any server exposing the OpenAI audio transcriptions API, such as OpenAI
+// itself or a local faster-whisper / LocalAI server, so voice notes can be transcribed offline
+type WhisperClient struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	language   string
+	httpClient *http.Client
+}
+
+func NewWhisperClient(baseURL string, apiKey string, model string, language string) *WhisperClient {
+	return &WhisperClient{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		apiKey:   apiKey,
+		model:    model,
+		language: language,
+		httpClient: &http.Client{
+			// Local models on CPU are much slower than hosted ones
+			Timeout: 120 * time.Second,
+		},
+	}
+}
+
+type transcriptionResponse struct {
+	Text string `json:"text"`
+}
+
+// Transcribe returns the text spoken in the audio
+// Implements the ports.SpeechToText interface
+func (c *WhisperClient) Transcribe(audioData []byte, fileName string) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(audioData); err != nil {
+		return "", fmt.Errorf("failed to write audio: %w", err)
+	}
+
+	fields := map[string]string{
+		"model":           c.model,
+		"response_format": "json",
+	}
+	// The language hint improves accuracy on short notes, it's detected when empty
+	if c.language != "" {
+		fields["language"] = c.language
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return "", fmt.Errorf("failed to write field %s: %w", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+"/audio/transcriptions", &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	// Local servers usually don't require a key
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("transcriptions API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var transcription transcriptionResponse
+	if err := json.Unmarshal(respBody, &transcription); err != nil {
+		return "", fmt.Errorf("failed to unmarshal transcription response: %w", err)
+	}
+
+	return strings.TrimSpace(transcription.Text), nil
+}
@@ -0,0 +1,36 @@
+// Package bootstrap builds the outbound adapters selected in the config, shared by the API server
+// and the Telegram bot
+package bootstrap
+
+import (
+	"github.com/KKogaa/mi-bolsillo-api/config"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/blobstore"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/exchangerate"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/grok"
+	"github.com/KKogaa/mi-bolsillo-api/internal/adapters/outbound/openai"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// NewExchangeRateFetcher returns the configured source for daily exchange rates
+func NewExchangeRateFetcher(cfg *config.Config) ports.ExchangeRateFetcher {
+	if cfg.ExchangeRateSource == "http" {
+		return exchangerate.NewHTTPFetcher(cfg.ExchangeRateAPIUrl)
+	}
+	return exchangerate.NewFileFetcher(cfg.ExchangeRateFile)
+}
+
+// NewBlobStore returns the configured store for receipt photos
+func NewBlobStore(cfg *config.Config) ports.BlobStore {
+	if cfg.BlobStore == "s3" {
+		return blobstore.NewS3Store(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKeyID, cfg.S3SecretAccessKey)
+	}
+	return blobstore.NewLocalStore(cfg.BlobStorePath)
+}
+
+// NewReceiptParser returns the configured LLM adapter used to read receipt photos and PDFs
+func NewReceiptParser(cfg *config.Config, grokClient *grok.GrokClient) ports.ReceiptParser {
+	if cfg.ReceiptParser == "openai" {
+		return openai.NewOpenAIClient(cfg.ReceiptParserBaseURL, cfg.ReceiptParserAPIKey, cfg.ReceiptParserModel)
+	}
+	return grokClient
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// Bot conversation states, each one waits for the answer to a follow-up question
+const (
+	SessionAwaitingAmount   = "awaiting_amount"
+	SessionAwaitingCategory = "awaiting_category"
+	SessionAwaitingCurrency = "awaiting_currency"
+	SessionAwaitingDate     = "awaiting_date"
+)
+
+// BotSession is the conversation state of a user in a chat: the intent being completed
+// and the slot the bot asked the user for. Each member of a group has their own session
+type BotSession struct {
+	ChatID     int64     `json:"chatId"`
+	TelegramID int64     `json:"telegramId"`
+	State      string    `json:"state"`
+	Intent     *Intent   `json:"intent"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
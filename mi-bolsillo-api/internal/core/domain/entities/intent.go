@@ -13,5 +13,6 @@ const (
 	IntentSummaryBills  = "summary_bills"
 	IntentUploadBill    = "upload_bill"
 	IntentCreateExpense = "create_expense"
+	IntentCreateIncome  = "create_income"
 	IntentUnknown       = "unknown"
 )
@@ -0,0 +1,29 @@
+package entities
+
+// ParsedBill is the data a receipt parser extracted from a bill photo or document
+type ParsedBill struct {
+	Items        []ParsedBillItem `json:"items"`
+	TotalAmount  float64          `json:"total_amount"`
+	Currency     string           `json:"currency"`
+	Date         string           `json:"date"`
+	MerchantName string           `json:"merchant_name"`
+	// Tax breakdown, zero when the receipt doesn't show it. TaxAmount is the IGV (or VAT abroad)
+	// and TipAmount the tip or service charge
+	Subtotal  float64 `json:"subtotal,omitempty"`
+	TaxAmount float64 `json:"tax_amount,omitempty"`
+	TipAmount float64 `json:"tip_amount,omitempty"`
+	// Issuer's RUC and serie-número, always present on electronic invoices
+	SupplierRUC   string `json:"supplier_ruc,omitempty"`
+	InvoiceNumber string `json:"invoice_number,omitempty"`
+	// CardLastFour are the last 4 digits of the card the bill was paid with, when printed
+	CardLastFour string `json:"card_last_four,omitempty"`
+}
+
+// ParsedBillItem is a line item read from a receipt
+type ParsedBillItem struct {
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category"`
+	// CategoryID is the user's category the item was filed under, set once the category is resolved
+	CategoryID string `json:"category_id,omitempty"`
+}
@@ -4,15 +4,35 @@ import "time"
 
 // Bill represents a bill entity
 type Bill struct {
-	BillId      string    `json:"billId" db:"bill_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	AmountPen   float64   `json:"amountPen" db:"amount_pen" example:"95.75"`
-	AmountUsd   float64   `json:"amountUsd" db:"amount_usd" example:"25.50"`
-	Description string    `json:"description" db:"description" example:"Grocery shopping"`
-	Category    string    `json:"category" db:"category" example:"Food"`
-	Currency    string    `json:"currency" db:"currency" example:"USD"`
-	UserID      string    `json:"userId" db:"user_id" example:"user_123456789"`
-	Source      string    `json:"source" db:"source" example:"web"`
-	Date        time.Time `json:"date" db:"date" example:"2025-10-10T10:00:00Z"`
-	CreatedAt   time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
-	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+	BillId            string    `json:"billId" db:"bill_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AmountPen         float64   `json:"amountPen" db:"amount_pen" example:"95.75"`
+	AmountUsd         float64   `json:"amountUsd" db:"amount_usd" example:"25.50"`
+	AmountOriginal    float64   `json:"amountOriginal" db:"amount_original" example:"23.40"`
+	ReportingCurrency string    `json:"reportingCurrency" db:"reporting_currency" example:"PEN"`
+	AmountReporting   float64   `json:"amountReporting" db:"amount_reporting" example:"95.75"`
+	Description       string    `json:"description" db:"description" example:"Grocery shopping"`
+	Category          string    `json:"category" db:"category" example:"Food"`
+	CategoryID        string    `json:"categoryId,omitempty" db:"category_id" example:"123e4567-e89b-12d3-a456-426614174005"`
+	Currency          string    `json:"currency" db:"currency" example:"USD"`
+	UserID            string    `json:"userId" db:"user_id" example:"user_123456789"`
+	WalletID          string    `json:"walletId,omitempty" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	Source            string    `json:"source" db:"source" example:"web"`
+	Date              time.Time `json:"date" db:"date" example:"2025-10-10T10:00:00Z"`
+	CreatedAt         time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt         time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+	// ReceiptKey locates the original receipt photo in the blob store, empty when there's none
+	ReceiptKey string `json:"receiptKey,omitempty" db:"receipt_key" example:"receipts/user_123456789/123e4567-e89b-12d3-a456-426614174000.jpg"`
+	// SupplierRUC and InvoiceNumber identify the SUNAT electronic invoice the bill was read from
+	SupplierRUC   string `json:"supplierRuc,omitempty" db:"supplier_ruc" example:"20100070970"`
+	InvoiceNumber string `json:"invoiceNumber,omitempty" db:"invoice_number" example:"F001-00012345"`
+	// Tax breakdown in the bill's currency, zero when unknown. TaxAmount is the IGV and
+	// TipAmount the tip or service charge
+	Subtotal  float64 `json:"subtotal" db:"subtotal" example:"77.97"`
+	TaxAmount float64 `json:"taxAmount" db:"tax_amount" example:"14.03"`
+	TipAmount float64 `json:"tipAmount" db:"tip_amount" example:"9.20"`
+	// PaymentMethodID is the account the bill was paid with, empty when unknown
+	PaymentMethodID string `json:"paymentMethodId,omitempty" db:"payment_method_id" example:"123e4567-e89b-12d3-a456-426614174012"`
+	// StatementReference is the bank's identifier of the statement movement the bill was imported
+	// from (the OFX FITID), empty for bills that weren't imported or formats without one
+	StatementReference string `json:"statementReference,omitempty" db:"statement_reference" example:"202510100001"`
 }
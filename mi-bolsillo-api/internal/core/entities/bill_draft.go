@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// Bill draft validation warnings
+const (
+	DraftWarningTotalMismatch   = "total_mismatch"
+	DraftWarningMissingDate     = "missing_date"
+	DraftWarningUnknownCurrency = "unknown_currency"
+	DraftWarningNoItems         = "no_items"
+)
+
+// BillDraft is a parsed receipt awaiting the user's review. Confirming it creates a bill,
+// discarding it deletes it along with its receipt photo
+type BillDraft struct {
+	DraftID     string          `json:"draftId" db:"draft_id" example:"123e4567-e89b-12d3-a456-426614174004"`
+	UserID      string          `json:"userId" db:"user_id" example:"user_123456789"`
+	WalletID    string          `json:"walletId,omitempty" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	Source      string          `json:"source" db:"source" example:"web"`
+	Description string          `json:"description" db:"description" example:"Wong"`
+	Currency    string          `json:"currency" db:"currency" example:"PEN"`
+	Date        string          `json:"date" db:"date" example:"2025-10-10"`
+	TotalAmount float64         `json:"totalAmount" db:"total_amount" example:"45.80"`
+	Items       []BillDraftItem `json:"items" db:"-"`
+	// Warnings lists what looked wrong in the parsed receipt, e.g. total_mismatch
+	Warnings      []string  `json:"warnings" db:"-"`
+	ReceiptKey    string    `json:"receiptKey,omitempty" db:"receipt_key" example:"receipts/user_123456789/123e4567-e89b-12d3-a456-426614174000.jpg"`
+	SupplierRUC   string    `json:"supplierRuc,omitempty" db:"supplier_ruc" example:"20100070970"`
+	InvoiceNumber string    `json:"invoiceNumber,omitempty" db:"invoice_number" example:"F001-00012345"`
+	Subtotal      float64   `json:"subtotal" db:"subtotal" example:"38.81"`
+	TaxAmount     float64   `json:"taxAmount" db:"tax_amount" example:"6.99"`
+	TipAmount     float64   `json:"tipAmount" db:"tip_amount" example:"0"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt     time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+	// PaymentMethodID is the user's card matching the one printed on the receipt, if any
+	PaymentMethodID string `json:"paymentMethodId,omitempty" db:"payment_method_id" example:"123e4567-e89b-12d3-a456-426614174012"`
+}
+
+// BillDraftItem is a line of a bill draft, it becomes an expense when the draft is confirmed
+type BillDraftItem struct {
+	Description string  `json:"description" example:"Leche Gloria"`
+	Amount      float64 `json:"amount" example:"4.50"`
+	Category    string  `json:"category" example:"Food"`
+}
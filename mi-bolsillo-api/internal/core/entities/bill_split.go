@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// SplitParticipantMe stands for the user who owns a personal ledger, e.g. when they paid a group dinner
+const SplitParticipantMe = "me"
+
+// BillSplit divides a bill between the people who shared it and records who paid it. Splits of a
+// shared wallet's bills belong to the wallet's ledger, the others to the bill owner's
+type BillSplit struct {
+	BillID    string           `json:"billId" db:"bill_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID    string           `json:"userId" db:"user_id" example:"user_123456789"`
+	WalletID  string           `json:"walletId,omitempty" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	PaidBy    string           `json:"paidBy" db:"paid_by" example:"me"`
+	Amount    float64          `json:"amount" db:"amount" example:"180.00"`
+	Currency  string           `json:"currency" db:"currency" example:"PEN"`
+	Shares    []BillSplitShare `json:"shares" db:"-"`
+	CreatedAt time.Time        `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt time.Time        `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
+
+// BillSplitShare is the part of a split bill a participant consumed. ExpenseIDs are the bill lines
+// assigned to them and Percentage their part of what isn't assigned, zero when split evenly
+type BillSplitShare struct {
+	BillID      string   `json:"-" db:"bill_id"`
+	Participant string   `json:"participant" db:"participant" example:"Ana"`
+	Amount      float64  `json:"amount" db:"amount" example:"60.00"`
+	Percentage  float64  `json:"percentage,omitempty" db:"percentage" example:"33.33"`
+	ExpenseIDs  []string `json:"expenseIds,omitempty" db:"-"`
+}
+
+// BillSplitItem assigns a bill line to a participant, lines shared by several are split evenly
+type BillSplitItem struct {
+	BillID      string `db:"bill_id"`
+	ExpenseID   string `db:"expense_id"`
+	Participant string `db:"participant"`
+}
+
+// Settlement records a payment from one participant to another that pays back what they owe
+type Settlement struct {
+	SettlementID    string    `json:"settlementId" db:"settlement_id" example:"123e4567-e89b-12d3-a456-426614174010"`
+	UserID          string    `json:"userId" db:"user_id" example:"user_123456789"`
+	WalletID        string    `json:"walletId,omitempty" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	FromParticipant string    `json:"from" db:"from_participant" example:"Ana"`
+	ToParticipant   string    `json:"to" db:"to_participant" example:"me"`
+	Amount          float64   `json:"amount" db:"amount" example:"60.00"`
+	Currency        string    `json:"currency" db:"currency" example:"PEN"`
+	Note            string    `json:"note,omitempty" db:"note" example:"Yape"`
+	Date            time.Time `json:"date" db:"date" example:"2025-10-12T10:00:00Z"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at" example:"2025-10-12T10:00:00Z"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// Budget represents a monthly spending limit for a category
+type Budget struct {
+	BudgetID    string    `json:"budgetId" db:"budget_id" example:"123e4567-e89b-12d3-a456-426614174002"`
+	UserID      string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Category    string    `json:"category" db:"category" example:"Food"`
+	AmountLimit float64   `json:"amountLimit" db:"amount_limit" example:"800.00"`
+	Currency    string    `json:"currency" db:"currency" example:"PEN"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
+
+// BudgetAlert is raised when spending in a month crosses a threshold of a budget
+type BudgetAlert struct {
+	Category    string  `json:"category" example:"Food"`
+	Month       string  `json:"month" example:"2025-10"`
+	Threshold   float64 `json:"threshold" example:"0.8"`
+	Spent       float64 `json:"spent" example:"650.00"`
+	AmountLimit float64 `json:"amountLimit" example:"800.00"`
+	Currency    string  `json:"currency" example:"PEN"`
+}
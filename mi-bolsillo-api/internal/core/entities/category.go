@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// Category is one of a user's expense categories. Subcategories point to a top-level category
+// through ParentID, which is empty for top-level ones. Names are unique per user
+type Category struct {
+	CategoryID string    `json:"categoryId" db:"category_id" example:"123e4567-e89b-12d3-a456-426614174005"`
+	UserID     string    `json:"userId" db:"user_id" example:"user_123456789"`
+	ParentID   string    `json:"parentId,omitempty" db:"parent_id" example:""`
+	Name       string    `json:"name" db:"name" example:"Food"`
+	Icon       string    `json:"icon" db:"icon" example:"🛒"`
+	Color      string    `json:"color" db:"color" example:"#4CAF50"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt  time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
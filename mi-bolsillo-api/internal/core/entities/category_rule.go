@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// Fields a category rule can match on. The merchant is the bill's description, which receipts
+// and electronic invoices fill with the store's name
+const (
+	RuleFieldDescription = "description"
+	RuleFieldMerchant    = "merchant"
+)
+
+// Operators of a category rule, matching ignores case and accents
+const (
+	RuleOperatorContains   = "contains"
+	RuleOperatorEquals     = "equals"
+	RuleOperatorStartsWith = "starts_with"
+)
+
+// CategoryRule files bills and expenses matching a pattern under a category, e.g.
+// "description contains 'uber'" → Transportation. Lower priorities are checked first
+type CategoryRule struct {
+	RuleID     string    `json:"ruleId" db:"rule_id" example:"123e4567-e89b-12d3-a456-426614174006"`
+	UserID     string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Field      string    `json:"field" db:"field" example:"description"`
+	Operator   string    `json:"operator" db:"operator" example:"contains"`
+	Pattern    string    `json:"pattern" db:"pattern" example:"uber"`
+	CategoryID string    `json:"categoryId" db:"category_id" example:"123e4567-e89b-12d3-a456-426614174005"`
+	Priority   int       `json:"priority" db:"priority" example:"0"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt  time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
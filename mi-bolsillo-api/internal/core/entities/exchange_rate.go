@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// ExchangeRate represents the daily rate to convert one unit of BaseCurrency into QuoteCurrency
+type ExchangeRate struct {
+	Date          string    `json:"date" db:"date" example:"2025-10-10"`
+	BaseCurrency  string    `json:"baseCurrency" db:"base_currency" example:"USD"`
+	QuoteCurrency string    `json:"quoteCurrency" db:"quote_currency" example:"PEN"`
+	Rate          float64   `json:"rate" db:"rate" example:"3.75"`
+	Source        string    `json:"source" db:"source" example:"http"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+}
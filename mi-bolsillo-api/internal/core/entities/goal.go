@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// Goal progress statuses
+const (
+	GoalStatusAchieved = "achieved"
+	GoalStatusOnTrack  = "on_track"
+	GoalStatusBehind   = "behind"
+	GoalStatusOverdue  = "overdue"
+)
+
+// Goal is an amount the user is saving up for by a deadline, e.g. a trip or an emergency fund
+type Goal struct {
+	GoalID       string    `json:"goalId" db:"goal_id" example:"123e4567-e89b-12d3-a456-426614174013"`
+	UserID       string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Name         string    `json:"name" db:"name" example:"Viaje a Cusco"`
+	TargetAmount float64   `json:"targetAmount" db:"target_amount" example:"3000"`
+	Currency     string    `json:"currency" db:"currency" example:"PEN"`
+	Deadline     time.Time `json:"deadline" db:"deadline" example:"2026-07-28T00:00:00Z"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt    time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+	// SavedAmount is the sum of the goal's contributions, computed when the goal is read
+	SavedAmount float64 `json:"savedAmount" db:"saved_amount" example:"1250"`
+}
+
+// GoalContribution is money put towards a goal in the goal's currency, negative for a withdrawal
+type GoalContribution struct {
+	ContributionID string    `json:"contributionId" db:"contribution_id" example:"123e4567-e89b-12d3-a456-426614174014"`
+	GoalID         string    `json:"goalId" db:"goal_id" example:"123e4567-e89b-12d3-a456-426614174013"`
+	UserID         string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Amount         float64   `json:"amount" db:"amount" example:"250"`
+	Note           string    `json:"note,omitempty" db:"note" example:"Gratificación"`
+	Date           time.Time `json:"date" db:"date" example:"2025-10-30T10:00:00Z"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at" example:"2025-10-30T10:00:00Z"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Income types
+const (
+	IncomeTypeSalary    = "salary"
+	IncomeTypeFreelance = "freelance"
+	IncomeTypeRefund    = "refund"
+	IncomeTypeOther     = "other"
+)
+
+// Income represents money the user received, e.g. their salary or a refund
+type Income struct {
+	IncomeID          string    `json:"incomeId" db:"income_id" example:"123e4567-e89b-12d3-a456-426614174011"`
+	UserID            string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Type              string    `json:"type" db:"type" example:"salary"`
+	Description       string    `json:"description" db:"description" example:"Sueldo de octubre"`
+	AmountPen         float64   `json:"amountPen" db:"amount_pen" example:"3000.00"`
+	AmountUsd         float64   `json:"amountUsd" db:"amount_usd" example:"800.00"`
+	AmountOriginal    float64   `json:"amountOriginal" db:"amount_original" example:"3000.00"`
+	ReportingCurrency string    `json:"reportingCurrency" db:"reporting_currency" example:"PEN"`
+	AmountReporting   float64   `json:"amountReporting" db:"amount_reporting" example:"3000.00"`
+	ExchangeRate      float64   `json:"exchangeRate" db:"exchange_rate" example:"3.75"`
+	Currency          string    `json:"currency" db:"currency" example:"PEN"`
+	Source            string    `json:"source" db:"source" example:"web"`
+	Date              time.Time `json:"date" db:"date" example:"2025-10-30T10:00:00Z"`
+	CreatedAt         time.Time `json:"createdAt" db:"created_at" example:"2025-10-30T10:00:00Z"`
+	UpdatedAt         time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-30T10:00:00Z"`
+}
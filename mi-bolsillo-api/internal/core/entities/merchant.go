@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// Merchant is a store the user buys from and the category the user last filed it under. The names
+// it shows up as on receipts and messages, e.g. "WONG SAN ISIDRO" and "wong", are its aliases
+type Merchant struct {
+	MerchantID string    `json:"merchantId" db:"merchant_id" example:"123e4567-e89b-12d3-a456-426614174007"`
+	UserID     string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Name       string    `json:"name" db:"name" example:"Wong"`
+	Key        string    `json:"key" db:"merchant_key" example:"wong"`
+	CategoryID string    `json:"categoryId" db:"category_id" example:"123e4567-e89b-12d3-a456-426614174005"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt  time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
+
+// MerchantAlias is a normalized name that refers to one of the user's merchants
+type MerchantAlias struct {
+	UserID     string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Alias      string    `json:"alias" db:"alias" example:"wong san isidro"`
+	MerchantID string    `json:"merchantId" db:"merchant_id" example:"123e4567-e89b-12d3-a456-426614174007"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+}
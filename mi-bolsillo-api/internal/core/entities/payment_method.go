@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Payment method types
+const (
+	PaymentMethodCreditCard  = "credit_card"
+	PaymentMethodDebitCard   = "debit_card"
+	PaymentMethodYape        = "yape"
+	PaymentMethodPlin        = "plin"
+	PaymentMethodCash        = "cash"
+	PaymentMethodBankAccount = "bank_account"
+)
+
+// PaymentMethod is an account the user pays bills with, e.g. a credit card, Yape or cash
+type PaymentMethod struct {
+	PaymentMethodID string `json:"paymentMethodId" db:"payment_method_id" example:"123e4567-e89b-12d3-a456-426614174012"`
+	UserID          string `json:"userId" db:"user_id" example:"user_123456789"`
+	Name            string `json:"name" db:"name" example:"Visa BCP"`
+	Type            string `json:"type" db:"type" example:"credit_card"`
+	Currency        string `json:"currency" db:"currency" example:"PEN"`
+	// LastFour are the last 4 digits of a card, used to match the card printed on receipts
+	LastFour string `json:"lastFour,omitempty" db:"last_four" example:"4321"`
+	// CreditLimit and StatementClosingDay only apply to credit cards, zero when unknown.
+	// The closing day is the day of the month the statement's billing cycle ends
+	CreditLimit         float64   `json:"creditLimit" db:"credit_limit" example:"5000"`
+	StatementClosingDay int       `json:"statementClosingDay" db:"statement_closing_day" example:"20"`
+	CreatedAt           time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt           time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// Recurring bill frequencies
+const (
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// RecurringBill represents a bill that repeats on a schedule, such as rent or a subscription.
+// Each occurrence is materialized as a regular bill with source "recurring"
+type RecurringBill struct {
+	RecurringBillID string    `json:"recurringBillId" db:"recurring_bill_id" example:"123e4567-e89b-12d3-a456-426614174003"`
+	UserID          string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Description     string    `json:"description" db:"description" example:"Netflix"`
+	Category        string    `json:"category" db:"category" example:"Entertainment"`
+	Amount          float64   `json:"amount" db:"amount" example:"44.90"`
+	Currency        string    `json:"currency" db:"currency" example:"PEN"`
+	Frequency       string    `json:"frequency" db:"frequency" example:"monthly"`
+	Interval        int       `json:"interval" db:"interval_count" example:"1"`
+	DayOfMonth      int       `json:"dayOfMonth" db:"day_of_month" example:"15"`
+	DayOfWeek       int       `json:"dayOfWeek" db:"day_of_week" example:"1"`
+	MonthOfYear     int       `json:"monthOfYear" db:"month_of_year" example:"3"`
+	StartDate       string    `json:"startDate" db:"start_date" example:"2025-10-01"`
+	EndDate         *string   `json:"endDate,omitempty" db:"end_date" example:"2026-10-01"`
+	NextOccurrence  string    `json:"nextOccurrence" db:"next_occurrence" example:"2025-10-15"`
+	Active          bool      `json:"active" db:"active" example:"true"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt       time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
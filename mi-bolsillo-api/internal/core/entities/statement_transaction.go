@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// StatementTransaction is a single movement read from a bank or card statement.
+// Charges have a negative amount and credits a positive one
+type StatementTransaction struct {
+	Date        time.Time `json:"date" example:"2025-10-10T00:00:00Z"`
+	Description string    `json:"description" example:"COMPRA WONG SAN ISIDRO"`
+	Amount      float64   `json:"amount" example:"-85.40"`
+	Currency    string    `json:"currency" example:"PEN"`
+	// Reference is the bank's identifier of the movement when the format provides one
+	Reference string `json:"reference,omitempty" example:"202510100001"`
+}
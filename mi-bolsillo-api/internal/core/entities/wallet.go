@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+// Roles of a wallet member. Owners manage the wallet and its members, editors add and change
+// bills, viewers only read them
+const (
+	WalletRoleOwner  = "owner"
+	WalletRoleEditor = "editor"
+	WalletRoleViewer = "viewer"
+)
+
+// Wallet is a shared household ledger, e.g. a couple's joint groceries. Its bills are visible to
+// every member. TelegramChatID is the group chat that posts into it, zero when none is linked
+type Wallet struct {
+	WalletID       string    `json:"walletId" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	Name           string    `json:"name" db:"name" example:"Casa"`
+	OwnerID        string    `json:"ownerId" db:"owner_id" example:"user_123456789"`
+	TelegramChatID int64     `json:"telegramChatId,omitempty" db:"telegram_chat_id" example:"-1001234567890"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt      time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
+
+// WalletMember gives a user a role in a wallet
+type WalletMember struct {
+	WalletID  string    `json:"walletId" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	UserID    string    `json:"userId" db:"user_id" example:"user_123456789"`
+	Role      string    `json:"role" db:"role" example:"editor"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at" example:"2025-10-10T10:00:00Z"`
+}
+
+// WalletInvite lets anyone with its code join a wallet with its role until it expires
+type WalletInvite struct {
+	Code      string    `json:"code" db:"code" example:"3f2b9c1d8e7a4b6c9d0e1f2a3b4c5d6e"`
+	WalletID  string    `json:"walletId" db:"wallet_id" example:"123e4567-e89b-12d3-a456-426614174008"`
+	Role      string    `json:"role" db:"role" example:"editor"`
+	CreatedBy string    `json:"createdBy" db:"created_by" example:"user_123456789"`
+	ExpiresAt time.Time `json:"expiresAt" db:"expires_at" example:"2025-10-13T10:00:00Z"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at" example:"2025-10-10T10:00:00Z"`
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type BillDraftRepository interface {
+	Create(draft *entities.BillDraft) error
+	FindByID(draftID string) (*entities.BillDraft, error)
+	Update(draft *entities.BillDraft) error
+	// Delete removes the draft, returning false when it no longer exists so a draft
+	// can only be confirmed or discarded once
+	Delete(draftID string) (bool, error)
+}
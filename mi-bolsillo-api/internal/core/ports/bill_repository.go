@@ -1,11 +1,57 @@
 package ports
 
-import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+import (
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
 
 type BillRepository interface {
 	Create(bill *entities.Bill) error
 	FindByID(billID string) (*entities.Bill, error)
 	FindByUserID(userID string) ([]*entities.Bill, error)
+	// FindByStatementReference returns the user's bill imported from the statement movement, if any
+	FindByStatementReference(userID string, reference string) (*entities.Bill, error)
+	Search(criteria BillSearchCriteria) ([]*entities.Bill, error)
+	Update(bill *entities.Bill) error
+	// RecalculateTotals sets the bill's amounts to the sum of its expenses
+	RecalculateTotals(billID string, updatedAt time.Time) error
 	Delete(billID string) error
 	UpdateUserID(oldUserID string, newUserID string) error
+	UpdateReportingAmount(billID string, reportingCurrency string, amountReporting float64) error
+	// ReassignCategory moves the user's bills linked to fromID or named fromName to another category,
+	// empty values match nothing
+	ReassignCategory(userID string, fromID string, fromName string, categoryID string, name string) error
+	FindCategoryNames(userID string) ([]string, error)
+}
+
+// Bill sort fields accepted by BillRepository.Search
+const (
+	BillSortDate      = "date"
+	BillSortAmount    = "amount"
+	BillSortCreatedAt = "createdAt"
+)
+
+// BillSearchCriteria filters, sorts and pages a user's bills, or a wallet's bills when WalletID is set.
+// Zero values disable a filter. Amounts are compared against the bill's reporting-currency amount
+type BillSearchCriteria struct {
+	UserID      string
+	WalletID    string
+	From        *time.Time
+	To          *time.Time
+	Category    string
+	Currency    string
+	Source      string
+	MinAmount   *float64
+	MaxAmount   *float64
+	Description string
+	SortBy      string
+	Ascending   bool
+	// AfterValue and AfterID continue after the last bill of the previous page, AfterValue
+	// holds that bill's sort field (time.Time for dates, float64 for amounts)
+	AfterValue interface{}
+	AfterID    string
+	Limit      int
+	// PaymentMethodID keeps the bills paid with the account
+	PaymentMethodID string
 }
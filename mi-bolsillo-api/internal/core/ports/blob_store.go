@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"errors"
+	"io"
+)
+
+// BlobStore defines the outbound port for storing binary objects such as receipt photos
+type BlobStore interface {
+	Put(key string, data []byte, contentType string) error
+	// Get returns the object's content and its content type, or ErrBlobNotFound
+	Get(key string) (io.ReadCloser, string, error)
+	Delete(key string) error
+}
+
+var ErrBlobNotFound = errors.New("blob not found")
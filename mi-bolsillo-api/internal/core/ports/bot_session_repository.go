@@ -0,0 +1,11 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+
+type BotSessionRepository interface {
+	// Find returns the session of a user in a chat
+	Find(chatID int64, telegramID int64) (*entities.BotSession, error)
+	// Save creates the user's session in the chat or replaces the existing one
+	Save(session *entities.BotSession) error
+	Delete(chatID int64, telegramID int64) error
+}
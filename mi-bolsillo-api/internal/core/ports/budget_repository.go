@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type BudgetRepository interface {
+	Create(budget *entities.Budget) error
+	FindByID(budgetID string) (*entities.Budget, error)
+	FindByUserID(userID string) ([]*entities.Budget, error)
+	Update(budget *entities.Budget) error
+	Delete(budgetID string) error
+	// UpdateUserID moves the budgets of categories the new user has no budget for and drops the rest
+	UpdateUserID(oldUserID string, newUserID string) error
+	// RenameCategory renames the category of the user's budgets, matching it regardless of case
+	RenameCategory(userID string, from string, to string) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type CategoryRepository interface {
+	Create(category *entities.Category) error
+	FindByID(categoryID string) (*entities.Category, error)
+	FindByUserID(userID string) ([]*entities.Category, error)
+	Update(category *entities.Category) error
+	Delete(categoryID string) error
+	// UpdateUserID moves the categories the new user doesn't have yet and drops the rest
+	UpdateUserID(oldUserID string, newUserID string) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type CategoryRuleRepository interface {
+	Create(rule *entities.CategoryRule) error
+	FindByID(ruleID string) (*entities.CategoryRule, error)
+	// FindByUserID returns the user's rules in the order they are checked
+	FindByUserID(userID string) ([]*entities.CategoryRule, error)
+	Update(rule *entities.CategoryRule) error
+	Delete(ruleID string) error
+	// ReassignCategory points the rules of a category to another one
+	ReassignCategory(fromCategoryID string, toCategoryID string) error
+	UpdateUserID(oldUserID string, newUserID string) error
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// ExchangeRateProvider resolves how many units of quoteCurrency one unit of baseCurrency
+// was worth on the given date
+type ExchangeRateProvider interface {
+	GetRate(baseCurrency string, quoteCurrency string, date time.Time) (float64, error)
+}
+
+// ExchangeRateFetcher defines the outbound port for retrieving rates from an external source
+// (an HTTP rates API, a local file, etc.). The returned rate's Date is the day the source actually
+// published it, which can be earlier than the requested date when the source has gaps
+type ExchangeRateFetcher interface {
+	FetchRate(baseCurrency string, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error)
+	Name() string
+}
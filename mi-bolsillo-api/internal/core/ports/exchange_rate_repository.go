@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type ExchangeRateRepository interface {
+	Upsert(rate *entities.ExchangeRate) error
+	FindByDate(baseCurrency string, quoteCurrency string, date string) (*entities.ExchangeRate, error)
+	FindLatestOnOrBefore(baseCurrency string, quoteCurrency string, date string) (*entities.ExchangeRate, error)
+}
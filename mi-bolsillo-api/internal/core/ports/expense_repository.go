@@ -5,7 +5,17 @@ import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
 type ExpenseRepository interface {
 	Create(expense *entities.Expense) error
 	CreateBatch(expenses []*entities.Expense) error
+	FindByID(expenseID string) (*entities.Expense, error)
 	FindByBillID(billID string) ([]*entities.Expense, error)
+	FindByBillIDs(billIDs []string) ([]*entities.Expense, error)
+	FindByUserIDAndDateRange(userID string, from string, to string) ([]*entities.Expense, error)
+	Update(expense *entities.Expense) error
+	Delete(expenseID string) error
 	DeleteByBillID(billID string) error
 	UpdateUserID(oldUserID string, newUserID string) error
+	UpdateReportingAmount(expenseID string, reportingCurrency string, amountReporting float64) error
+	// ReassignCategory moves the user's expenses linked to fromID or named fromName to another category,
+	// empty values match nothing
+	ReassignCategory(userID string, fromID string, fromName string, categoryID string, name string) error
+	FindCategoryNames(userID string) ([]string, error)
 }
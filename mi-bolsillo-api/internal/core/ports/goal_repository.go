@@ -0,0 +1,18 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+// GoalRepository stores savings goals and their contributions. Goals are read with their saved amount
+type GoalRepository interface {
+	Create(goal *entities.Goal) error
+	FindByID(goalID string) (*entities.Goal, error)
+	FindByUserID(userID string) ([]*entities.Goal, error)
+	Update(goal *entities.Goal) error
+	// Delete removes the goal along with its contributions
+	Delete(goalID string) error
+	CreateContribution(contribution *entities.GoalContribution) error
+	FindContributionByID(contributionID string) (*entities.GoalContribution, error)
+	FindContributionsByGoalID(goalID string) ([]*entities.GoalContribution, error)
+	DeleteContribution(contributionID string) error
+	UpdateUserID(oldUserID string, newUserID string) error
+}
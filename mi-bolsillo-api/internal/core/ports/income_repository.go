@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+type IncomeRepository interface {
+	Create(income *entities.Income) error
+	FindByID(incomeID string) (*entities.Income, error)
+	// FindByUserID returns the user's incomes from from and before to, the latest first. Nil bounds are open
+	FindByUserID(userID string, from *time.Time, to *time.Time) ([]*entities.Income, error)
+	Update(income *entities.Income) error
+	Delete(incomeID string) error
+	UpdateUserID(oldUserID string, newUserID string) error
+	UpdateReportingAmount(incomeID string, reportingCurrency string, amountReporting float64) error
+}
@@ -4,6 +4,7 @@ import "github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
 
 // IntentDetector defines the outbound port for detecting user intent from text
 // This is an interface that external adapters (like GrokClient) will implement
+// The expense category is one of categories, the user's category labels
 type IntentDetector interface {
-	DetectIntent(userText string) (*entities.Intent, error)
+	DetectIntent(userText string, categories []string) (*entities.Intent, error)
 }
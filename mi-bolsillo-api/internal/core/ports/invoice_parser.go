@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+
+// InvoiceParser defines the outbound port for reading structured electronic invoices,
+// such as SUNAT UBL 2.1 XML, without going through an LLM
+type InvoiceParser interface {
+	ParseInvoice(data []byte) (*entities.ParsedBill, error)
+}
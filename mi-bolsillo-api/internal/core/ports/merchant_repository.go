@@ -0,0 +1,17 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type MerchantRepository interface {
+	Create(merchant *entities.Merchant) error
+	FindByUserID(userID string) ([]*entities.Merchant, error)
+	Update(merchant *entities.Merchant) error
+	// CreateAlias adds an alias, doing nothing if the user already has it
+	CreateAlias(alias *entities.MerchantAlias) error
+	FindAliasesByUserID(userID string) ([]*entities.MerchantAlias, error)
+	// ReassignCategory points the merchants of a category to another one
+	ReassignCategory(fromCategoryID string, toCategoryID string) error
+	// UpdateUserID moves the merchants the new user doesn't have yet, the aliases of the rest are
+	// linked to the new user's merchant of the same key
+	UpdateUserID(oldUserID string, newUserID string) error
+}
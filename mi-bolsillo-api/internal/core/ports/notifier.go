@@ -0,0 +1,8 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+// Notifier defines the outbound port for pushing notifications to a user's Telegram chat
+type Notifier interface {
+	NotifyBudgetAlert(telegramID int64, alert *entities.BudgetAlert) error
+}
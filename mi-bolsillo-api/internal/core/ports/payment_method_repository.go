@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type PaymentMethodRepository interface {
+	Create(paymentMethod *entities.PaymentMethod) error
+	FindByID(paymentMethodID string) (*entities.PaymentMethod, error)
+	FindByUserID(userID string) ([]*entities.PaymentMethod, error)
+	Update(paymentMethod *entities.PaymentMethod) error
+	// Delete removes the payment method and unlinks the bills and drafts paid with it
+	Delete(paymentMethodID string) error
+	UpdateUserID(oldUserID string, newUserID string) error
+}
@@ -0,0 +1,9 @@
+package ports
+
+// PDFReader defines the outbound port for reading the content of PDF receipts
+type PDFReader interface {
+	// ExtractText returns the text drawn on the pages of a PDF, empty when it has none (e.g. a scan)
+	ExtractText(data []byte) (string, error)
+	// ExtractImages returns the JPEG images embedded in a PDF, largest first
+	ExtractImages(data []byte) ([][]byte, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+
+// ReceiptParser defines the outbound port for extracting a bill from a receipt photo or the text of a receipt document.
+// Implemented by LLM adapters such as GrokClient and the OpenAI-compatible client. Items are filed
+// under one of categories, the user's category labels
+type ReceiptParser interface {
+	ParseBillImage(imageData []byte, categories []string) (*entities.ParsedBill, error)
+	ParseBillText(text string, categories []string) (*entities.ParsedBill, error)
+}
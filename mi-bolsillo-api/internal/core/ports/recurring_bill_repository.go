@@ -0,0 +1,18 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type RecurringBillRepository interface {
+	Create(recurringBill *entities.RecurringBill) error
+	FindByID(recurringBillID string) (*entities.RecurringBill, error)
+	FindByUserID(userID string) ([]*entities.RecurringBill, error)
+	FindDue(date string) ([]*entities.RecurringBill, error)
+	Update(recurringBill *entities.RecurringBill) error
+	Delete(recurringBillID string) error
+	UpdateUserID(oldUserID string, newUserID string) error
+	// RenameCategory renames the category of the user's recurring bills, matching it regardless of case
+	RenameCategory(userID string, from string, to string) error
+	// ClaimOccurrence records that the occurrence on date was materialized as billID,
+	// returning false when it was already claimed
+	ClaimOccurrence(recurringBillID string, date string, billID string) (bool, error)
+}
@@ -0,0 +1,7 @@
+package ports
+
+// SpeechToText defines the outbound port for transcribing audio, such as Telegram voice notes.
+// fileName hints the audio format through its extension, e.g. voice.ogg
+type SpeechToText interface {
+	Transcribe(audioData []byte, fileName string) (string, error)
+}
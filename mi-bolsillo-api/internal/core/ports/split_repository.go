@@ -0,0 +1,18 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+// SplitRepository stores bill splits and settlements. A ledger is a shared wallet's when walletID is set,
+// otherwise the user's personal one
+type SplitRepository interface {
+	// Save creates or replaces the split of a bill with its shares and assigned lines
+	Save(split *entities.BillSplit, items []*entities.BillSplitItem) error
+	FindByBillID(billID string) (*entities.BillSplit, error)
+	FindByLedger(userID string, walletID string) ([]*entities.BillSplit, error)
+	DeleteByBillID(billID string) error
+	CreateSettlement(settlement *entities.Settlement) error
+	FindSettlementByID(settlementID string) (*entities.Settlement, error)
+	FindSettlementsByLedger(userID string, walletID string) ([]*entities.Settlement, error)
+	DeleteSettlement(settlementID string) error
+	UpdateUserID(oldUserID string, newUserID string) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import "io"
+
+// SpreadsheetWriter writes the rows of a tabular export
+type SpreadsheetWriter interface {
+	WriteRow(values []interface{}) error
+	// Close flushes the remaining rows and releases the writer, the export is incomplete until it
+	// is called. Calling it again is a no-op
+	Close() error
+}
+
+// SpreadsheetFormat creates writers for one export file format, such as CSV or XLSX
+type SpreadsheetFormat interface {
+	Name() string
+	ContentType() string
+	NewWriter(w io.Writer) (SpreadsheetWriter, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"io"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// StatementParser reads the transactions of a bank statement file in one format
+type StatementParser interface {
+	Parse(r io.Reader) ([]entities.StatementTransaction, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+// TxRepositories groups the repositories that share the transaction of a unit of work
+type TxRepositories struct {
+	Bills          BillRepository
+	Expenses       ExpenseRepository
+	Budgets        BudgetRepository
+	RecurringBills RecurringBillRepository
+	Categories     CategoryRepository
+	CategoryRules  CategoryRuleRepository
+	Merchants      MerchantRepository
+	Wallets        WalletRepository
+	Splits         SplitRepository
+	Incomes        IncomeRepository
+	PaymentMethods PaymentMethodRepository
+	Goals          GoalRepository
+	Users          UserRepository
+	OTPs           OTPRepository
+}
+
+type UnitOfWork interface {
+	// Do runs fn in a single transaction, committing when it returns nil and rolling back otherwise
+	Do(fn func(repos TxRepositories) error) error
+}
@@ -9,4 +9,5 @@ type UserRepository interface {
 	FindByTelegramID(telegramID int64) (*entities.User, error)
 	Update(user *entities.User) error
 	LinkClerkAccount(userID string, clerkID string) error
+	Delete(userID string) error
 }
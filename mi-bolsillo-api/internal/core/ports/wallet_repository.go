@@ -0,0 +1,25 @@
+package ports
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+type WalletRepository interface {
+	Create(wallet *entities.Wallet) error
+	FindByID(walletID string) (*entities.Wallet, error)
+	// FindByUserID returns the wallets the user is a member of
+	FindByUserID(userID string) ([]*entities.Wallet, error)
+	FindByTelegramChatID(chatID int64) (*entities.Wallet, error)
+	Update(wallet *entities.Wallet) error
+	// Delete removes the wallet with its members and invites, its bills go back to their creators.
+	// It spans several tables, callers run it in a unit of work
+	Delete(walletID string) error
+	AddMember(member *entities.WalletMember) error
+	FindMember(walletID string, userID string) (*entities.WalletMember, error)
+	FindMembers(walletID string) ([]*entities.WalletMember, error)
+	UpdateMember(member *entities.WalletMember) error
+	RemoveMember(walletID string, userID string) error
+	CreateInvite(invite *entities.WalletInvite) error
+	FindInvite(code string) (*entities.WalletInvite, error)
+	// UpdateUserID moves ownerships and memberships to the new user, keeping the highest role
+	// of wallets both users are members of
+	UpdateUserID(oldUserID string, newUserID string) error
+}
@@ -12,25 +12,22 @@ import (
 )
 
 type AccountLinkService struct {
-	userRepo    ports.UserRepository
-	otpRepo     ports.OTPRepository
-	billRepo    ports.BillRepository
-	expenseRepo ports.ExpenseRepository
+	userRepo             ports.UserRepository
+	otpRepo              ports.OTPRepository
+	unitOfWork           ports.UnitOfWork
 	otpExpirationMinutes int
 }
 
 func NewAccountLinkService(
 	userRepo ports.UserRepository,
 	otpRepo ports.OTPRepository,
-	billRepo ports.BillRepository,
-	expenseRepo ports.ExpenseRepository,
+	unitOfWork ports.UnitOfWork,
 	otpExpirationMinutes int,
 ) *AccountLinkService {
 	return &AccountLinkService{
-		userRepo:    userRepo,
-		otpRepo:     otpRepo,
-		billRepo:    billRepo,
-		expenseRepo: expenseRepo,
+		userRepo:             userRepo,
+		otpRepo:              otpRepo,
+		unitOfWork:           unitOfWork,
 		otpExpirationMinutes: otpExpirationMinutes,
 	}
 }
@@ -97,20 +94,29 @@ func (s *AccountLinkService) VerifyAndLinkAccounts(otpCode string, clerkID strin
 			return nil
 		}
 
-		// Prioritize the Clerk user (existing web user) and add Telegram ID to it
-		existingClerkUser.TelegramID = &otp.TelegramID
-		existingClerkUser.UpdatedAt = now
-		if err := s.userRepo.Update(existingClerkUser); err != nil {
-			return fmt.Errorf("failed to update clerk user with telegram id: %w", err)
-		}
-
-		// Migrate bills from Telegram user to Clerk user
-		if err := s.migrateBills(existingTelegramUser.UserID, existingClerkUser.UserID); err != nil {
-			return fmt.Errorf("failed to migrate bills from telegram user: %w", err)
-		}
-
-		_ = s.otpRepo.Delete(otpCode)
-		return nil
+		// Prioritize the Clerk user (existing web user): move the Telegram user's data to it, drop the
+		// Telegram user and add its Telegram ID to the Clerk user, all or nothing
+		return s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+			if err := migrateBills(repos, existingTelegramUser.UserID, existingClerkUser.UserID); err != nil {
+				return fmt.Errorf("failed to migrate bills from telegram user: %w", err)
+			}
+
+			// The Telegram ID is unique, the old user goes before the Clerk user takes it
+			if err := repos.Users.Delete(existingTelegramUser.UserID); err != nil {
+				return fmt.Errorf("failed to delete telegram user: %w", err)
+			}
+
+			existingClerkUser.TelegramID = &otp.TelegramID
+			existingClerkUser.UpdatedAt = now
+			if err := repos.Users.Update(existingClerkUser); err != nil {
+				return fmt.Errorf("failed to update clerk user with telegram id: %w", err)
+			}
+
+			if err := repos.OTPs.Delete(otpCode); err != nil {
+				return fmt.Errorf("failed to delete OTP: %w", err)
+			}
+			return nil
+		})
 	}
 
 	// Case 2: Only Clerk user exists - add Telegram ID
@@ -139,11 +145,12 @@ func (s *AccountLinkService) VerifyAndLinkAccounts(otpCode string, clerkID strin
 
 	// Case 4: Neither user exists - create a new linked user
 	newUser := &entities.User{
-		UserID:     uuid.New().String(),
-		ClerkID:    &clerkID,
-		TelegramID: &otp.TelegramID,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		UserID:            uuid.New().String(),
+		ClerkID:           &clerkID,
+		TelegramID:        &otp.TelegramID,
+		ReportingCurrency: entities.DefaultReportingCurrency,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 
 	if err := s.userRepo.Create(newUser); err != nil {
@@ -178,10 +185,11 @@ func (s *AccountLinkService) GetOrCreateUserByTelegramID(telegramID int64) (*ent
 	// Create new user
 	now := time.Now()
 	newUser := &entities.User{
-		UserID:     uuid.New().String(),
-		TelegramID: &telegramID,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		UserID:            uuid.New().String(),
+		TelegramID:        &telegramID,
+		ReportingCurrency: entities.DefaultReportingCurrency,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 
 	if err := s.userRepo.Create(newUser); err != nil {
@@ -205,10 +213,11 @@ func (s *AccountLinkService) GetOrCreateUserByClerkID(clerkID string) (*entities
 	// Create new user
 	now := time.Now()
 	newUser := &entities.User{
-		UserID:    uuid.New().String(),
-		ClerkID:   &clerkID,
-		CreatedAt: now,
-		UpdatedAt: now,
+		UserID:            uuid.New().String(),
+		ClerkID:           &clerkID,
+		ReportingCurrency: entities.DefaultReportingCurrency,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 
 	if err := s.userRepo.Create(newUser); err != nil {
@@ -218,18 +227,73 @@ func (s *AccountLinkService) GetOrCreateUserByClerkID(clerkID string) (*entities
 	return newUser, nil
 }
 
-// migrateBills updates all bills and expenses from oldUserID to newUserID
-func (s *AccountLinkService) migrateBills(oldUserID string, newUserID string) error {
+// migrateBills moves everything owned by oldUserID to newUserID within the caller's unit of work,
+// so a failed merge never leaves data split between both users. It covers:
+//   - bills, expenses and bill splits
+//   - budgets, recurring bills and savings goals
+//   - categories, category rules and merchants
+//   - incomes, payment methods and wallets
+func migrateBills(repos ports.TxRepositories, oldUserID string, newUserID string) error {
 	// Update bills
-	if err := s.billRepo.UpdateUserID(oldUserID, newUserID); err != nil {
+	if err := repos.Bills.UpdateUserID(oldUserID, newUserID); err != nil {
 		return fmt.Errorf("failed to migrate bills: %w", err)
 	}
 
 	// Update expenses
-	if err := s.expenseRepo.UpdateUserID(oldUserID, newUserID); err != nil {
+	if err := repos.Expenses.UpdateUserID(oldUserID, newUserID); err != nil {
 		return fmt.Errorf("failed to migrate expenses: %w", err)
 	}
 
+	// Update budgets
+	if err := repos.Budgets.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate budgets: %w", err)
+	}
+
+	// Update recurring bills
+	if err := repos.RecurringBills.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate recurring bills: %w", err)
+	}
+
+	// Update category rules
+	if err := repos.CategoryRules.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate category rules: %w", err)
+	}
+
+	// Update merchants
+	if err := repos.Merchants.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate merchants: %w", err)
+	}
+
+	// Update incomes
+	if err := repos.Incomes.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate incomes: %w", err)
+	}
+
+	// Update payment methods
+	if err := repos.PaymentMethods.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate payment methods: %w", err)
+	}
+
+	// Update savings goals and their contributions
+	if err := repos.Goals.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate savings goals: %w", err)
+	}
+
+	// Update bill splits and settlements
+	if err := repos.Splits.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate bill splits: %w", err)
+	}
+
+	// Update wallet ownerships and memberships
+	if err := repos.Wallets.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate wallets: %w", err)
+	}
+
+	// Merge categories, after bills, expenses, rules and merchants so those linked to a duplicate are relinked
+	if err := repos.Categories.UpdateUserID(oldUserID, newUserID); err != nil {
+		return fmt.Errorf("failed to migrate categories: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+const (
+	defaultBillPageSize = 50
+	maxBillPageSize     = 200
+)
+
+// billCursor is the position of the last bill of a page, encoded as opaque base64 JSON
+type billCursor struct {
+	Date   *time.Time `json:"d,omitempty"`
+	Amount *float64   `json:"a,omitempty"`
+	BillID string     `json:"id"`
+}
+
+func encodeBillCursor(bill *entities.Bill, sortBy string) string {
+	cursor := billCursor{BillID: bill.BillId}
+	switch sortBy {
+	case ports.BillSortAmount:
+		cursor.Amount = &bill.AmountReporting
+	case ports.BillSortCreatedAt:
+		cursor.Date = &bill.CreatedAt
+	default:
+		cursor.Date = &bill.Date
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeBillCursor returns the sort value and bill ID to continue after
+func decodeBillCursor(value string, sortBy string) (interface{}, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: malformed cursor", ErrInvalidBillSearch)
+	}
+
+	var cursor billCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.BillID == "" {
+		return nil, "", fmt.Errorf("%w: malformed cursor", ErrInvalidBillSearch)
+	}
+
+	// A cursor is only valid for the sort it was issued with
+	if sortBy == ports.BillSortAmount {
+		if cursor.Amount == nil {
+			return nil, "", fmt.Errorf("%w: cursor does not match sort", ErrInvalidBillSearch)
+		}
+		return *cursor.Amount, cursor.BillID, nil
+	}
+	if cursor.Date == nil {
+		return nil, "", fmt.Errorf("%w: cursor does not match sort", ErrInvalidBillSearch)
+	}
+	return *cursor.Date, cursor.BillID, nil
+}
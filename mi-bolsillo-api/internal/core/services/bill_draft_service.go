@@ -0,0 +1,315 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+// draftTotalTolerance absorbs rounding when comparing a receipt's total with the sum of its items
+const draftTotalTolerance = 0.01
+
+// draftDifferenceDescription names the expense that covers what the items of a draft miss of its total
+const draftDifferenceDescription = "Diferencia con el total"
+
+// BillDraftService keeps parsed receipts as drafts so the user can review them before they're saved
+type BillDraftService struct {
+	draftRepo               ports.BillDraftRepository
+	billWithExpensesService *BillWithExpensesService
+}
+
+func NewBillDraftService(draftRepo ports.BillDraftRepository, billWithExpensesService *BillWithExpensesService) *BillDraftService {
+	return &BillDraftService{
+		draftRepo:               draftRepo,
+		billWithExpensesService: billWithExpensesService,
+	}
+}
+
+// CreateDraft validates a parsed receipt and stores it as a draft along with its photo
+func (s *BillDraftService) CreateDraft(dto dtos.CreateBillDraftDTO) (*entities.BillDraft, error) {
+	bills := s.billWithExpensesService
+	if dto.WalletID != "" {
+		if _, _, err := bills.walletService.requireRole(dto.WalletID, dto.UserID, entities.WalletRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	draft := &entities.BillDraft{
+		DraftID:       uuid.New().String(),
+		UserID:        dto.UserID,
+		WalletID:      dto.WalletID,
+		Source:        dto.Source,
+		Description:   dto.Description,
+		Currency:      normalizeCurrency(dto.Currency),
+		Date:          strings.TrimSpace(dto.Date),
+		TotalAmount:   dto.TotalAmount,
+		Items:         dto.Items,
+		SupplierRUC:   dto.SupplierRUC,
+		InvoiceNumber: dto.InvoiceNumber,
+		Subtotal:      dto.Subtotal,
+		TaxAmount:     dto.TaxAmount,
+		TipAmount:     dto.TipAmount,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if draft.Items == nil {
+		draft.Items = []entities.BillDraftItem{}
+	}
+	draft.Warnings = s.validate(draft)
+
+	// Link the card printed on the receipt when it's one of the user's
+	paymentMethodID, err := bills.paymentMethodService.resolvePaymentMethod(dto.UserID, "", dto.CardLastFour)
+	if err != nil {
+		return nil, err
+	}
+	draft.PaymentMethodID = paymentMethodID
+
+	// Keep the photo now, it's attached to the bill on confirmation
+	if len(dto.ReceiptImage) > 0 && bills.blobStore != nil {
+		receiptKey, err := bills.storeReceipt(dto.UserID, dto.ReceiptImage)
+		if err != nil {
+			log.Printf("Failed to store receipt for draft %s: %v", draft.DraftID, err)
+		}
+		draft.ReceiptKey = receiptKey
+	}
+
+	if err := s.draftRepo.Create(draft); err != nil {
+		bills.deleteReceipt(draft.ReceiptKey)
+		return nil, fmt.Errorf("failed to create bill draft: %w", err)
+	}
+
+	return draft, nil
+}
+
+// GetDraft returns a draft owned by the user
+func (s *BillDraftService) GetDraft(draftID string, userID string) (*entities.BillDraft, error) {
+	draft, err := s.draftRepo.FindByID(draftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bill draft: %w", err)
+	}
+	if draft == nil {
+		return nil, ErrBillDraftNotFound
+	}
+	if draft.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return draft, nil
+}
+
+// UpdateDraft applies the user's corrections to a draft and validates it again
+func (s *BillDraftService) UpdateDraft(draftID string, userID string, dto dtos.UpdateBillDraftDTO) (*entities.BillDraft, error) {
+	draft, err := s.GetDraft(draftID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Description != nil {
+		draft.Description = *dto.Description
+	}
+	if dto.Currency != nil {
+		draft.Currency = normalizeCurrency(*dto.Currency)
+	}
+	if dto.Date != nil {
+		draft.Date = strings.TrimSpace(*dto.Date)
+	}
+	if dto.TotalAmount != nil {
+		draft.TotalAmount = *dto.TotalAmount
+	}
+	if dto.Items != nil {
+		draft.Items = *dto.Items
+	}
+	for _, item := range draft.Items {
+		if item.Amount <= 0 {
+			return nil, ErrInvalidExpense
+		}
+	}
+	if dto.PaymentMethodID != nil {
+		if draft.PaymentMethodID, err = s.billWithExpensesService.paymentMethodService.resolvePaymentMethod(userID, *dto.PaymentMethodID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	draft.Warnings = s.validate(draft)
+	draft.UpdatedAt = time.Now()
+	if err := s.draftRepo.Update(draft); err != nil {
+		return nil, fmt.Errorf("failed to update bill draft: %w", err)
+	}
+
+	return draft, nil
+}
+
+// ConfirmDraft saves the draft as a bill with one expense per item and removes the draft.
+// The bill adds up to the draft's total, see draftExpenses
+func (s *BillDraftService) ConfirmDraft(draftID string, userID string) (*entities.Bill, []*entities.Expense, error) {
+	draft, err := s.GetDraft(draftID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	billDate, err := time.Parse(dateLayout, draft.Date)
+	if err != nil {
+		billDate = time.Now()
+	}
+	expenses, err := draftExpenses(draft, billDate.Format(dateLayout))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Claim the draft first so a double confirmation can't create the bill twice
+	deleted, err := s.draftRepo.Delete(draft.DraftID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to claim bill draft: %w", err)
+	}
+	if !deleted {
+		return nil, nil, ErrBillDraftNotFound
+	}
+
+	bill, createdExpenses, err := s.billWithExpensesService.CreateBillWithExpenses(dtos.CreateBillWithExpensesDTO{
+		Description:   draft.Description,
+		Category:      "General",
+		UserID:        draft.UserID,
+		WalletID:      draft.WalletID,
+		Source:        draft.Source,
+		Date:          billDate,
+		Currency:      draft.Currency,
+		Expenses:      expenses,
+		ReceiptKey:    draft.ReceiptKey,
+		SupplierRUC:   draft.SupplierRUC,
+		InvoiceNumber: draft.InvoiceNumber,
+		Subtotal:      draft.Subtotal,
+		TaxAmount:     draft.TaxAmount,
+		TipAmount:     draft.TipAmount,
+		// Item categories are the LLM's guesses, the user reviews amounts and names
+		CategoryGuessed: true,
+		PaymentMethodID: draft.PaymentMethodID,
+	})
+	if err != nil {
+		// Put the draft back so the user can fix it and try again
+		if restoreErr := s.draftRepo.Create(draft); restoreErr != nil {
+			log.Printf("Failed to restore bill draft %s: %v", draft.DraftID, restoreErr)
+		}
+		return nil, nil, err
+	}
+
+	return bill, createdExpenses, nil
+}
+
+// draftExpenses returns one expense per item of the draft. When the total, as read or corrected by
+// the user, is above what the items add up to, the difference is saved as one more expense so the
+// bill matches the receipt; a draft without items is saved as a single expense of its total.
+// Items adding up to more than the total can't be reconciled and must be corrected first
+func draftExpenses(draft *entities.BillDraft, date string) ([]dtos.CreateExpenseForBill, error) {
+	expenses := make([]dtos.CreateExpenseForBill, 0, len(draft.Items)+1)
+	var itemsTotal float64
+	for _, item := range draft.Items {
+		itemsTotal += item.Amount
+		expenses = append(expenses, dtos.CreateExpenseForBill{
+			Amount:      item.Amount,
+			Description: item.Description,
+			Category:    item.Category,
+			Date:        date,
+		})
+	}
+
+	difference := draft.TotalAmount - itemsTotal
+	switch {
+	case len(draft.Items) == 0 && draft.TotalAmount <= 0:
+		return nil, ErrEmptyBillDraft
+	case difference < -draftTotalTolerance:
+		return nil, ErrDraftItemsExceedTotal
+	case difference > draftTotalTolerance:
+		description := draftDifferenceDescription
+		if len(draft.Items) == 0 {
+			description = draft.Description
+		}
+		expenses = append(expenses, dtos.CreateExpenseForBill{
+			Amount:      math.Round(difference*100) / 100,
+			Description: description,
+			Date:        date,
+		})
+	}
+	return expenses, nil
+}
+
+// DiscardDraft deletes a draft and its receipt photo
+func (s *BillDraftService) DiscardDraft(draftID string, userID string) error {
+	draft, err := s.GetDraft(draftID, userID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.draftRepo.Delete(draft.DraftID)
+	if err != nil {
+		return fmt.Errorf("failed to delete bill draft: %w", err)
+	}
+	if !deleted {
+		return ErrBillDraftNotFound
+	}
+
+	s.billWithExpensesService.deleteReceipt(draft.ReceiptKey)
+	return nil
+}
+
+// validate returns the warnings of a draft: a total that doesn't match its items,
+// a missing date or a currency without exchange rates
+func (s *BillDraftService) validate(draft *entities.BillDraft) []string {
+	warnings := []string{}
+
+	if len(draft.Items) == 0 {
+		warnings = append(warnings, entities.DraftWarningNoItems)
+	} else {
+		var itemsTotal float64
+		for _, item := range draft.Items {
+			itemsTotal += item.Amount
+		}
+		if math.Abs(itemsTotal-draft.TotalAmount) > draftTotalTolerance {
+			warnings = append(warnings, entities.DraftWarningTotalMismatch)
+		}
+	}
+
+	date, err := time.Parse(dateLayout, draft.Date)
+	if err != nil {
+		warnings = append(warnings, entities.DraftWarningMissingDate)
+		date = time.Now()
+	}
+
+	if !s.isKnownCurrency(draft.Currency, date) {
+		warnings = append(warnings, entities.DraftWarningUnknownCurrency)
+	}
+
+	return warnings
+}
+
+// isKnownCurrency reports whether the currency can be converted, so confirming won't fail
+func (s *BillDraftService) isKnownCurrency(currency string, date time.Time) bool {
+	if !isValidCurrency(currency) {
+		return false
+	}
+	if currency == "PEN" || currency == "USD" {
+		return true
+	}
+
+	_, err := s.billWithExpensesService.exchangeRateProvider.GetRate(currency, "PEN", date)
+	if err != nil {
+		if !errors.Is(err, ErrExchangeRateNotFound) {
+			log.Printf("Failed to check %s exchange rate: %v", currency, err)
+		}
+		return false
+	}
+	return true
+}
+
+var (
+	ErrBillDraftNotFound     = errors.New("bill draft not found")
+	ErrDraftItemsExceedTotal = errors.New("bill draft items add up to more than its total")
+	ErrEmptyBillDraft        = errors.New("bill draft has no items nor total")
+)
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+func TestDraftExpensesMatchTheTotal(t *testing.T) {
+	items := []entities.BillDraftItem{
+		{Description: "Leche", Amount: 5.50},
+		{Description: "Pan", Amount: 3.20},
+	}
+
+	tests := []struct {
+		name    string
+		draft   *entities.BillDraft
+		want    []float64
+		wantErr error
+	}{
+		{"items match the total", &entities.BillDraft{TotalAmount: 8.70, Items: items}, []float64{5.50, 3.20}, nil},
+		{"corrected total above the items", &entities.BillDraft{TotalAmount: 45.80, Items: items}, []float64{5.50, 3.20, 37.10}, nil},
+		{"no items", &entities.BillDraft{Description: "Wong", TotalAmount: 45.80}, []float64{45.80}, nil},
+		{"items above the total", &entities.BillDraft{TotalAmount: 5, Items: items}, nil, ErrDraftItemsExceedTotal},
+		{"no items nor total", &entities.BillDraft{}, nil, ErrEmptyBillDraft},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expenses, err := draftExpenses(tt.draft, "2025-10-10")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("draftExpenses() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(expenses) != len(tt.want) {
+				t.Fatalf("draftExpenses() returned %d expenses, want %d", len(expenses), len(tt.want))
+			}
+			for i, expense := range expenses {
+				if expense.Amount != tt.want[i] {
+					t.Errorf("expense %d amount = %v, want %v", i, expense.Amount, tt.want[i])
+				}
+			}
+		})
+	}
+}
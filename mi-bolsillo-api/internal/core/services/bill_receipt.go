@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// receiptExtensions maps the detected content type of a receipt to the extension of its key
+var receiptExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	// SUNAT electronic invoices
+	"text/xml; charset=utf-8": ".xml",
+}
+
+// GetReceipt returns the original receipt photo of a bill the user can access and its content type
+// The caller must close the returned reader
+func (s *BillWithExpensesService) GetReceipt(billID string, userID string) (io.ReadCloser, string, error) {
+	bill, err := s.getAccessibleBill(billID, userID, entities.WalletRoleViewer)
+	if err != nil {
+		return nil, "", err
+	}
+	if bill.ReceiptKey == "" || s.blobStore == nil {
+		return nil, "", ErrReceiptNotFound
+	}
+
+	content, contentType, err := s.blobStore.Get(bill.ReceiptKey)
+	if err != nil {
+		if errors.Is(err, ports.ErrBlobNotFound) {
+			return nil, "", ErrReceiptNotFound
+		}
+		return nil, "", fmt.Errorf("failed to get receipt: %w", err)
+	}
+	return content, contentType, nil
+}
+
+// storeReceipt saves a receipt photo in the blob store and returns its key
+func (s *BillWithExpensesService) storeReceipt(userID string, data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := receiptExtensions[contentType]
+	if !ok {
+		extension = ".bin"
+	}
+
+	key := fmt.Sprintf("receipts/%s/%s%s", userID, uuid.New().String(), extension)
+	if err := s.blobStore.Put(key, data, contentType); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// deleteReceipt removes a stored receipt, failures only leave an orphaned object so they're logged
+func (s *BillWithExpensesService) deleteReceipt(key string) {
+	if key == "" || s.blobStore == nil {
+		return
+	}
+	if err := s.blobStore.Delete(key); err != nil {
+		log.Printf("Failed to delete receipt %s: %v", key, err)
+	}
+}
+
+var (
+	ErrReceiptNotFound = errors.New("receipt not found")
+)
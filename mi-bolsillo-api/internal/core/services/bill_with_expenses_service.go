@@ -2,7 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
@@ -12,89 +14,232 @@ import (
 )
 
 type BillWithExpensesService struct {
-	billRepo    ports.BillRepository
-	expenseRepo ports.ExpenseRepository
+	billRepo             ports.BillRepository
+	expenseRepo          ports.ExpenseRepository
+	userRepo             ports.UserRepository
+	exchangeRateProvider ports.ExchangeRateProvider
+	budgetService        *BudgetService
+	categoryService      *CategoryService
+	ruleRepo             ports.CategoryRuleRepository
+	merchantService      *MerchantService
+	walletService        *WalletService
+	paymentMethodService *PaymentMethodService
+	unitOfWork           ports.UnitOfWork
+	blobStore            ports.BlobStore
 }
 
-func NewBillWithExpensesService(billRepo ports.BillRepository, expenseRepo ports.ExpenseRepository) *BillWithExpensesService {
+func NewBillWithExpensesService(
+	billRepo ports.BillRepository,
+	expenseRepo ports.ExpenseRepository,
+	userRepo ports.UserRepository,
+	exchangeRateProvider ports.ExchangeRateProvider,
+	budgetService *BudgetService,
+	categoryService *CategoryService,
+	ruleRepo ports.CategoryRuleRepository,
+	merchantService *MerchantService,
+	walletService *WalletService,
+	paymentMethodService *PaymentMethodService,
+	unitOfWork ports.UnitOfWork,
+	blobStore ports.BlobStore,
+) *BillWithExpensesService {
 	return &BillWithExpensesService{
-		billRepo:    billRepo,
-		expenseRepo: expenseRepo,
+		billRepo:             billRepo,
+		expenseRepo:          expenseRepo,
+		userRepo:             userRepo,
+		exchangeRateProvider: exchangeRateProvider,
+		budgetService:        budgetService,
+		categoryService:      categoryService,
+		ruleRepo:             ruleRepo,
+		merchantService:      merchantService,
+		walletService:        walletService,
+		paymentMethodService: paymentMethodService,
+		unitOfWork:           unitOfWork,
+		blobStore:            blobStore,
 	}
 }
 
 func (s *BillWithExpensesService) CreateBillWithExpenses(dto dtos.CreateBillWithExpensesDTO) (*entities.Bill, []*entities.Expense, error) {
+	return s.createBillWithExpenses(dto, nil)
+}
+
+// createBillWithExpenses creates the bill, running withinTx in the same unit of work as the bill
+// insert so the caller's own writes are saved or rolled back together with it
+func (s *BillWithExpensesService) createBillWithExpenses(dto dtos.CreateBillWithExpensesDTO, withinTx func(repos ports.TxRepositories, bill *entities.Bill) error) (*entities.Bill, []*entities.Expense, error) {
 	now := time.Now()
 	billID := uuid.New().String()
 
-	// log the incoming DTO for debugging
-	log.Printf("Creating bill with DTO: %+v", dto)
+	// Only identifiers are logged, the DTO carries the receipt photo and the user's descriptions
+	log.Printf("Creating bill %s for user %s from %s", billID, dto.UserID, dto.Source)
+
+	currency := normalizeCurrency(dto.Currency)
+	if !isValidCurrency(currency) {
+		return nil, nil, ErrUnsupportedCurrency
+	}
+
+	// Bills of a shared wallet are added by its owner and editors
+	if dto.WalletID != "" {
+		if _, _, err := s.walletService.requireRole(dto.WalletID, dto.UserID, entities.WalletRoleEditor); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	paymentMethodID, err := s.paymentMethodService.resolvePaymentMethod(dto.UserID, dto.PaymentMethodID, dto.CardLastFour)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reportingCurrency, err := s.getReportingCurrency(dto.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Resolve the rates for the bill's date, the caller may supply the USD to PEN rate
+	rates, err := resolveConversionRates(s.exchangeRateProvider, currency, reportingCurrency, dto.Date, dto.ExchangeRate)
+	if err != nil {
+		if errors.Is(err, ErrExchangeRateNotFound) {
+			return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedCurrency, err)
+		}
+		return nil, nil, fmt.Errorf("failed to resolve exchange rates: %w", err)
+	}
+
+	// File the bill and its expenses under the user's categories, expenses default to the bill's.
+	// The user's rules override the category given, then a guessed category gives way to the one
+	// the user last chose for the merchant
+	categories, err := s.categoryService.ListCategories(dto.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := s.ruleRepo.FindByUserID(dto.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch category rules: %w", err)
+	}
+	matcher := newCategoryRuleMatcher(rules, categories)
+
+	billCategoryID, billCategory := categoryFields(categories, dto.Category)
+	merchantCategory := s.merchantCategory(dto, categories)
+	preferredCategoryID := ""
+	if !dto.CategoryGuessed && strings.TrimSpace(dto.Category) != "" {
+		preferredCategoryID = billCategoryID
+	}
+	if _, category := matcher.match(dto.Description, dto.Description); category != nil {
+		billCategoryID, billCategory = category.CategoryID, category.Name
+	} else if merchantCategory != nil {
+		billCategoryID, billCategory = merchantCategory.CategoryID, merchantCategory.Name
+	}
 
 	// Create expense entities and calculate totals
 	expenses := make([]*entities.Expense, 0, len(dto.Expenses))
-	var totalAmountPen float64
-	var totalAmountUsd float64
+	var totalAmountPen, totalAmountUsd, totalAmountOriginal, totalAmountReporting float64
 
 	for _, expenseDTO := range dto.Expenses {
-		var amountPen, amountUsd float64
-
-		// Convert amount based on bill's currency
-		if dto.Currency == "PEN" {
-			amountPen = expenseDTO.Amount
-			amountUsd = expenseDTO.Amount / dto.ExchangeRate
-		} else if dto.Currency == "USD" {
-			amountUsd = expenseDTO.Amount
-			amountPen = expenseDTO.Amount * dto.ExchangeRate
-		}
+		amountPen := expenseDTO.Amount * rates.toPen
+		amountUsd := expenseDTO.Amount * rates.toUsd
+		amountReporting := expenseDTO.Amount * rates.toReporting
 
 		totalAmountPen += amountPen
 		totalAmountUsd += amountUsd
+		totalAmountOriginal += expenseDTO.Amount
+		totalAmountReporting += amountReporting
+
+		categoryID, category := billCategoryID, billCategory
+		if _, matched := matcher.match(expenseDTO.Description, dto.Description); matched != nil {
+			categoryID, category = matched.CategoryID, matched.Name
+		} else if merchantCategory == nil && strings.TrimSpace(expenseDTO.Category) != "" {
+			categoryID, category = categoryFields(categories, expenseDTO.Category)
+		}
 
 		expense := &entities.Expense{
-			ExpenseId:    uuid.New().String(),
-			AmountPen:    amountPen,
-			AmountUsd:    amountUsd,
-			ExchangeRate: dto.ExchangeRate,
-			Currency:     dto.Currency,
-			Description:  expenseDTO.Description,
-			Category:     expenseDTO.Category,
-			Date:         expenseDTO.Date,
-			BillID:       billID,
-			UserID:       dto.UserID,
-			Source:       dto.Source,
-			CreatedAt:    now.Format(time.RFC3339),
-			UpdatedAt:    now.Format(time.RFC3339),
+			ExpenseId:         uuid.New().String(),
+			AmountPen:         amountPen,
+			AmountUsd:         amountUsd,
+			AmountOriginal:    expenseDTO.Amount,
+			ReportingCurrency: reportingCurrency,
+			AmountReporting:   amountReporting,
+			ExchangeRate:      rates.usdToPen,
+			Currency:          currency,
+			Description:       expenseDTO.Description,
+			Category:          category,
+			CategoryID:        categoryID,
+			Date:              expenseDTO.Date,
+			BillID:            billID,
+			UserID:            dto.UserID,
+			Source:            dto.Source,
+			CreatedAt:         now.Format(time.RFC3339),
+			UpdatedAt:         now.Format(time.RFC3339),
 		}
 		expenses = append(expenses, expense)
 	}
 
 	// Create bill entity with calculated totals
 	bill := &entities.Bill{
-		BillId:      billID,
-		AmountPen:   totalAmountPen,
-		AmountUsd:   totalAmountUsd,
-		Description: dto.Description,
-		Category:    dto.Category,
-		Currency:    dto.Currency,
-		UserID:      dto.UserID,
-		Source:      dto.Source,
-		Date:        dto.Date,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		BillId:            billID,
+		AmountPen:         totalAmountPen,
+		AmountUsd:         totalAmountUsd,
+		AmountOriginal:    totalAmountOriginal,
+		ReportingCurrency: reportingCurrency,
+		AmountReporting:   totalAmountReporting,
+		Description:       dto.Description,
+		Category:          billCategory,
+		CategoryID:        billCategoryID,
+		Currency:          currency,
+		UserID:            dto.UserID,
+		WalletID:          dto.WalletID,
+		Source:            dto.Source,
+		Date:              dto.Date,
+		ReceiptKey:        dto.ReceiptKey,
+		SupplierRUC:       strings.TrimSpace(dto.SupplierRUC),
+		InvoiceNumber:     strings.ToUpper(strings.TrimSpace(dto.InvoiceNumber)),
+		Subtotal:          dto.Subtotal,
+		TaxAmount:         dto.TaxAmount,
+		TipAmount:         dto.TipAmount,
+		PaymentMethodID:   paymentMethodID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	bill.StatementReference = dto.StatementReference
+
+	// Keep the photo the bill was parsed from, the bill is still saved if the store is unavailable
+	storedReceiptKey := ""
+	if bill.ReceiptKey == "" && len(dto.ReceiptImage) > 0 && s.blobStore != nil {
+		storedReceiptKey, err = s.storeReceipt(dto.UserID, dto.ReceiptImage)
+		if err != nil {
+			log.Printf("Failed to store receipt for bill %s: %v", billID, err)
+		}
+		bill.ReceiptKey = storedReceiptKey
 	}
 
-	// Save bill
-	if err := s.billRepo.Create(bill); err != nil {
+	// Save the bill and its expenses together so a failure never leaves a bill without lines
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Bills.Create(bill); err != nil {
+			return err
+		}
+		if len(expenses) > 0 {
+			if err := repos.Expenses.CreateBatch(expenses); err != nil {
+				return err
+			}
+		}
+		if withinTx != nil {
+			return withinTx(repos, bill)
+		}
+		return nil
+	})
+	if err != nil {
+		s.deleteReceipt(storedReceiptKey)
 		return nil, nil, err
 	}
 
-	// Save expenses in batch
-	if len(expenses) > 0 {
-		if err := s.expenseRepo.CreateBatch(expenses); err != nil {
-			return nil, nil, err
+	// Only saved bills teach the merchant memory, a category the user chose becomes the merchant's preferred one
+	if s.merchantService != nil {
+		if _, err := s.merchantService.RememberMerchant(dto.UserID, dto.Description, preferredCategoryID); err != nil {
+			log.Printf("Failed to remember merchant %q: %v", dto.Description, err)
 		}
 	}
 
+	// Notify budget thresholds crossed by this bill without delaying the response
+	if s.budgetService != nil {
+		go s.budgetService.CheckBudgetAlerts(dto.UserID, expenses)
+	}
+
 	return bill, expenses, nil
 }
 
@@ -106,26 +251,134 @@ func (s *BillWithExpensesService) ListBillsByUserID(userID string) ([]*dtos.Bill
 		return nil, err
 	}
 
-	// For each bill, get its expenses and create response
-	result := make([]*dtos.BillWithExpensesResponse, 0, len(bills))
-	for _, bill := range bills {
-		expenses, err := s.expenseRepo.FindByBillID(bill.BillId)
+	return s.withExpenses(bills)
+}
+
+// SearchBills returns a page of the user's bills matching the filters, with their expenses.
+// Without a limit or cursor every matching bill is returned, as clients that predate paging expect
+func (s *BillWithExpensesService) SearchBills(dto dtos.BillSearchDTO) (*dtos.BillSearchResult, error) {
+	paged := dto.Limit > 0 || dto.Cursor != ""
+	limit := dto.Limit
+	if limit <= 0 {
+		limit = defaultBillPageSize
+	}
+	if limit > maxBillPageSize {
+		limit = maxBillPageSize
+	}
+
+	sortBy := dto.SortBy
+	if sortBy == "" {
+		sortBy = ports.BillSortDate
+	}
+	if sortBy != ports.BillSortDate && sortBy != ports.BillSortAmount && sortBy != ports.BillSortCreatedAt {
+		return nil, fmt.Errorf("%w: sort must be date, amount or createdAt", ErrInvalidBillSearch)
+	}
+
+	// Any member can list the bills of a shared wallet
+	if dto.WalletID != "" {
+		if _, _, err := s.walletService.requireRole(dto.WalletID, dto.UserID, entities.WalletRoleViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	criteria := ports.BillSearchCriteria{
+		UserID:      dto.UserID,
+		WalletID:    dto.WalletID,
+		From:        dto.From,
+		To:          dto.To,
+		Category:    dto.Category,
+		Currency:    dto.Currency,
+		Source:      dto.Source,
+		MinAmount:   dto.MinAmount,
+		MaxAmount:   dto.MaxAmount,
+		Description: dto.Description,
+		SortBy:      sortBy,
+		Ascending:   dto.Ascending,
+		// Fetch one extra bill to know whether there is a next page
+		Limit:           limit + 1,
+		PaymentMethodID: dto.PaymentMethodID,
+	}
+	if criteria.Currency != "" {
+		criteria.Currency = normalizeCurrency(criteria.Currency)
+	}
+	if !paged {
+		criteria.Limit = 0
+	}
+
+	if dto.Cursor != "" {
+		afterValue, afterID, err := decodeBillCursor(dto.Cursor, sortBy)
 		if err != nil {
 			return nil, err
 		}
+		criteria.AfterValue = afterValue
+		criteria.AfterID = afterID
+	}
 
+	bills, err := s.billRepo.Search(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.BillSearchResult{}
+	if paged && len(bills) > limit {
+		bills = bills[:limit]
+		result.NextCursor = encodeBillCursor(bills[limit-1], sortBy)
+	}
+
+	result.Bills, err = s.withExpenses(bills)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// withExpenses loads the expenses of all bills in one query and builds the responses
+func (s *BillWithExpensesService) withExpenses(bills []*entities.Bill) ([]*dtos.BillWithExpensesResponse, error) {
+	billIDs := make([]string, len(bills))
+	for i, bill := range bills {
+		billIDs[i] = bill.BillId
+	}
+
+	expenses, err := s.expenseRepo.FindByBillIDs(billIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByBill := make(map[string][]*entities.Expense, len(bills))
+	for _, expense := range expenses {
+		expensesByBill[expense.BillID] = append(expensesByBill[expense.BillID], expense)
+	}
+
+	result := make([]*dtos.BillWithExpensesResponse, 0, len(bills))
+	for _, bill := range bills {
 		result = append(result, &dtos.BillWithExpensesResponse{
-			BillId:      bill.BillId,
-			AmountPen:   bill.AmountPen,
-			AmountUsd:   bill.AmountUsd,
-			Description: bill.Description,
-			Category:    bill.Category,
-			Currency:    bill.Currency,
-			UserID:      bill.UserID,
-			Date:        bill.Date,
-			CreatedAt:   bill.CreatedAt,
-			UpdatedAt:   bill.UpdatedAt,
-			Expenses:    expenses,
+			BillId:            bill.BillId,
+			AmountPen:         bill.AmountPen,
+			AmountUsd:         bill.AmountUsd,
+			AmountOriginal:    bill.AmountOriginal,
+			ReportingCurrency: bill.ReportingCurrency,
+			AmountReporting:   bill.AmountReporting,
+			Description:       bill.Description,
+			Category:          bill.Category,
+			CategoryID:        bill.CategoryID,
+			Currency:          bill.Currency,
+			UserID:            bill.UserID,
+			WalletID:          bill.WalletID,
+			PaymentMethodID:   bill.PaymentMethodID,
+			Date:              bill.Date,
+			CreatedAt:         bill.CreatedAt,
+			UpdatedAt:         bill.UpdatedAt,
+			Expenses:          expensesByBill[bill.BillId],
+			// Receipt, invoice and tax details
+			Source:             bill.Source,
+			ReceiptKey:         bill.ReceiptKey,
+			SupplierRUC:        bill.SupplierRUC,
+			InvoiceNumber:      bill.InvoiceNumber,
+			Subtotal:           bill.Subtotal,
+			TaxAmount:          bill.TaxAmount,
+			TipAmount:          bill.TipAmount,
+			StatementReference: bill.StatementReference,
 		})
 	}
 
@@ -133,17 +386,12 @@ func (s *BillWithExpensesService) ListBillsByUserID(userID string) ([]*dtos.Bill
 }
 
 func (s *BillWithExpensesService) GetBillWithExpenses(billID string, userID string) (*entities.Bill, []*entities.Expense, error) {
-	// Get the bill
-	bill, err := s.billRepo.FindByID(billID)
+	// Get the bill and verify the user can see it
+	bill, err := s.getAccessibleBill(billID, userID, entities.WalletRoleViewer)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Verify the bill belongs to the user
-	if bill.UserID != userID {
-		return nil, nil, ErrUnauthorized
-	}
-
 	// Get expenses for the bill
 	expenses, err := s.expenseRepo.FindByBillID(billID)
 	if err != nil {
@@ -154,28 +402,449 @@ func (s *BillWithExpensesService) GetBillWithExpenses(billID string, userID stri
 }
 
 func (s *BillWithExpensesService) DeleteBillWithExpenses(billID string, userID string) error {
-	// Get the bill
-	bill, err := s.billRepo.FindByID(billID)
+	// Get the bill and verify the user can change it
+	bill, err := s.getAccessibleBill(billID, userID, entities.WalletRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	// Delete the expenses, the split and the bill together
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Expenses.DeleteByBillID(billID); err != nil {
+			return err
+		}
+		if err := repos.Splits.DeleteByBillID(billID); err != nil {
+			return err
+		}
+		return repos.Bills.Delete(billID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteReceipt(bill.ReceiptKey)
+	return nil
+}
+
+// UpdateBill updates a bill's details. Changing the currency, date or exchange rate reconverts
+// every expense of the bill and recomputes its totals
+func (s *BillWithExpensesService) UpdateBill(billID string, userID string, dto dtos.UpdateBillDTO) (*entities.Bill, []*entities.Expense, error) {
+	bill, err := s.getAccessibleBill(billID, userID, entities.WalletRoleEditor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	expenses, err := s.expenseRepo.FindByBillID(billID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currency := bill.Currency
+	if dto.Currency != "" {
+		currency = normalizeCurrency(dto.Currency)
+		if !isValidCurrency(currency) {
+			return nil, nil, ErrUnsupportedCurrency
+		}
+	}
+
+	date := bill.Date
+	if !dto.Date.IsZero() {
+		date = dto.Date
+	}
+
+	now := time.Now()
+	reconvert := currency != bill.Currency || !date.Equal(bill.Date) || dto.ExchangeRate > 0
+
+	if dto.Description != "" {
+		bill.Description = dto.Description
+	}
+	if dto.Category != "" {
+		category, err := s.categoryService.ResolveCategory(userID, dto.Category)
+		if err != nil {
+			return nil, nil, err
+		}
+		bill.CategoryID, bill.Category = category.CategoryID, category.Name
+	}
+	if dto.SupplierRUC != nil {
+		bill.SupplierRUC = strings.TrimSpace(*dto.SupplierRUC)
+	}
+	if dto.Subtotal != nil {
+		bill.Subtotal = *dto.Subtotal
+	}
+	if dto.TaxAmount != nil {
+		bill.TaxAmount = *dto.TaxAmount
+	}
+	if dto.TipAmount != nil {
+		bill.TipAmount = *dto.TipAmount
+	}
+	if dto.PaymentMethodID != nil {
+		if bill.PaymentMethodID, err = s.paymentMethodService.resolvePaymentMethod(userID, *dto.PaymentMethodID, ""); err != nil {
+			return nil, nil, err
+		}
+	}
+	bill.Currency = currency
+	bill.Date = date
+	bill.UpdatedAt = now
+
+	var rates *conversionRates
+	if reconvert {
+		rates, err = s.resolveBillRates(bill, dto.ExchangeRate)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if rates != nil {
+			for _, expense := range expenses {
+				expense.Currency = currency
+				applyConversionRates(expense, rates, now)
+				if err := repos.Expenses.Update(expense); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := repos.Bills.Update(bill); err != nil {
+			return err
+		}
+
+		bill, err = recalculateBillTotals(repos, billID, now)
+		return err
+	})
 	if err != nil {
+		return nil, nil, err
+	}
+
+	if dto.Category != "" {
+		s.rememberMerchant(userID, bill.Description, bill.CategoryID)
+	}
+
+	return bill, expenses, nil
+}
+
+// AddExpense adds an expense to a bill in the bill's currency and recomputes its totals
+func (s *BillWithExpensesService) AddExpense(billID string, userID string, dto dtos.CreateExpenseForBill) (*entities.Bill, *entities.Expense, error) {
+	bill, err := s.getAccessibleBill(billID, userID, entities.WalletRoleEditor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dto.Amount <= 0 {
+		return nil, nil, fmt.Errorf("%w: amount must be greater than zero", ErrInvalidExpense)
+	}
+
+	// Reuse the USD to PEN rate of the bill's other expenses so the lines stay consistent
+	existing, err := s.expenseRepo.FindByBillID(billID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var usdToPen float64
+	if len(existing) > 0 {
+		usdToPen = existing[0].ExchangeRate
+	}
+
+	rates, err := s.resolveBillRates(bill, usdToPen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	date := dto.Date
+	if date == "" {
+		date = bill.Date.Format("2006-01-02")
+	}
+
+	categoryID, category := bill.CategoryID, bill.Category
+	if strings.TrimSpace(dto.Category) != "" {
+		categories, err := s.categoryService.ListCategories(userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		categoryID, category = categoryFields(categories, dto.Category)
+	}
+
+	now := time.Now()
+	expense := &entities.Expense{
+		ExpenseId:         uuid.New().String(),
+		AmountOriginal:    dto.Amount,
+		ReportingCurrency: bill.ReportingCurrency,
+		Currency:          bill.Currency,
+		Description:       dto.Description,
+		Category:          category,
+		CategoryID:        categoryID,
+		Date:              date,
+		BillID:            billID,
+		UserID:            bill.UserID,
+		Source:            bill.Source,
+		CreatedAt:         now.Format(time.RFC3339),
+	}
+	applyConversionRates(expense, rates, now)
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Expenses.Create(expense); err != nil {
+			return err
+		}
+		bill, err = recalculateBillTotals(repos, billID, now)
 		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.budgetService != nil {
+		go s.budgetService.CheckBudgetAlerts(bill.UserID, []*entities.Expense{expense})
+	}
+
+	return bill, expense, nil
+}
+
+// UpdateExpense applies a partial update to an expense of a bill and recomputes the bill's totals
+func (s *BillWithExpensesService) UpdateExpense(billID string, expenseID string, userID string, dto dtos.UpdateExpenseDTO) (*entities.Bill, *entities.Expense, error) {
+	bill, err := s.getAccessibleBill(billID, userID, entities.WalletRoleEditor)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Verify the bill belongs to the user
-	if bill.UserID != userID {
-		return ErrUnauthorized
+	expense, err := s.getBillExpense(billID, expenseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	previous := *expense
+
+	now := time.Now()
+	if dto.Description != nil {
+		expense.Description = *dto.Description
 	}
+	if dto.Category != nil {
+		category, err := s.categoryService.ResolveCategory(userID, *dto.Category)
+		if err != nil {
+			return nil, nil, err
+		}
+		expense.CategoryID, expense.Category = category.CategoryID, category.Name
+	}
+	if dto.Date != nil {
+		expense.Date = *dto.Date
+	}
+	if dto.Amount != nil {
+		if *dto.Amount <= 0 {
+			return nil, nil, fmt.Errorf("%w: amount must be greater than zero", ErrInvalidExpense)
+		}
+		rates, err := s.resolveBillRates(bill, expense.ExchangeRate)
+		if err != nil {
+			return nil, nil, err
+		}
+		expense.AmountOriginal = *dto.Amount
+		applyConversionRates(expense, rates, now)
+	}
+	expense.UpdatedAt = now.Format(time.RFC3339)
 
-	// Delete all expenses associated with the bill
-	if err := s.expenseRepo.DeleteByBillID(billID); err != nil {
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Expenses.Update(expense); err != nil {
+			return err
+		}
+		bill, err = recalculateBillTotals(repos, billID, now)
 		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.budgetService != nil {
+		if change := budgetChange(&previous, expense); change != nil {
+			go s.budgetService.CheckBudgetAlerts(bill.UserID, change)
+		}
+	}
+
+	// The category of a bill's only expense is the bill's, e.g. expenses logged from Telegram
+	if dto.Category != nil {
+		if expenses, err := s.expenseRepo.FindByBillID(billID); err == nil && len(expenses) == 1 {
+			s.rememberMerchant(userID, bill.Description, expense.CategoryID)
+		}
+	}
+
+	return bill, expense, nil
+}
+
+// DeleteExpense removes an expense from a bill and recomputes the bill's totals
+func (s *BillWithExpensesService) DeleteExpense(billID string, expenseID string, userID string) (*entities.Bill, error) {
+	if _, err := s.getAccessibleBill(billID, userID, entities.WalletRoleEditor); err != nil {
+		return nil, err
 	}
 
-	// Delete the bill
-	if err := s.billRepo.Delete(billID); err != nil {
+	if _, err := s.getBillExpense(billID, expenseID); err != nil {
+		return nil, err
+	}
+
+	var bill *entities.Bill
+	err := s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Expenses.Delete(expenseID); err != nil {
+			return err
+		}
+		var err error
+		bill, err = recalculateBillTotals(repos, billID, time.Now())
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return bill, nil
+}
 
-	return nil
+// getAccessibleBill returns the bill after verifying the user may access it: their own bills, or
+// the bills of a wallet where they have at least role
+func (s *BillWithExpensesService) getAccessibleBill(billID string, userID string, role string) (*entities.Bill, error) {
+	bill, err := s.billRepo.FindByID(billID)
+	if err != nil {
+		return nil, err
+	}
+	if bill == nil {
+		return nil, ErrBillNotFound
+	}
+
+	if bill.WalletID == "" {
+		if bill.UserID != userID {
+			return nil, ErrUnauthorized
+		}
+		return bill, nil
+	}
+
+	if _, _, err := s.walletService.requireRole(bill.WalletID, userID, role); err != nil {
+		if errors.Is(err, ErrUnauthorizedWallet) || errors.Is(err, ErrWalletNotFound) {
+			return nil, ErrUnauthorized
+		}
+		return nil, err
+	}
+	return bill, nil
+}
+
+// getBillExpense returns an expense, ensuring it is a line of the given bill
+func (s *BillWithExpensesService) getBillExpense(billID string, expenseID string) (*entities.Expense, error) {
+	expense, err := s.expenseRepo.FindByID(expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if expense == nil || expense.BillID != billID {
+		return nil, ErrExpenseNotFound
+	}
+	return expense, nil
 }
 
-var ErrUnauthorized = errors.New("unauthorized access to bill")
+// resolveBillRates resolves the conversion rates of a bill's currency on the bill's date
+func (s *BillWithExpensesService) resolveBillRates(bill *entities.Bill, usdToPen float64) (*conversionRates, error) {
+	reportingCurrency := bill.ReportingCurrency
+	if reportingCurrency == "" {
+		reportingCurrency = entities.DefaultReportingCurrency
+	}
+
+	rates, err := resolveConversionRates(s.exchangeRateProvider, bill.Currency, reportingCurrency, bill.Date, usdToPen)
+	if err != nil {
+		if errors.Is(err, ErrExchangeRateNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedCurrency, err)
+		}
+		return nil, fmt.Errorf("failed to resolve exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+// recalculateBillTotals recomputes the bill's amounts from its expense rows and returns the updated bill
+func recalculateBillTotals(repos ports.TxRepositories, billID string, now time.Time) (*entities.Bill, error) {
+	if err := repos.Bills.RecalculateTotals(billID, now); err != nil {
+		return nil, fmt.Errorf("failed to recalculate bill totals: %w", err)
+	}
+
+	bill, err := repos.Bills.FindByID(billID)
+	if err != nil {
+		return nil, err
+	}
+	if bill == nil {
+		return nil, ErrBillNotFound
+	}
+	return bill, nil
+}
+
+// budgetChange returns what editing an expense adds to budget spending, to check alerts against:
+// the expense as it is now plus the reversal of how it was, so a budget counting both only sees the
+// difference. Returns nil when the edit can't raise any budget, e.g. a lower amount in the same category
+func budgetChange(before *entities.Expense, after *entities.Expense) []*entities.Expense {
+	sameMonth := len(before.Date) >= 7 && len(after.Date) >= 7 && before.Date[:7] == after.Date[:7]
+	sameBudgets := sameMonth && before.CategoryID == after.CategoryID && before.Category == after.Category
+	if sameBudgets && after.AmountOriginal <= before.AmountOriginal {
+		return nil
+	}
+
+	current, reversal := *after, *before
+	reversal.AmountOriginal = -before.AmountOriginal
+	reversal.AmountPen = -before.AmountPen
+	reversal.AmountUsd = -before.AmountUsd
+	reversal.AmountReporting = -before.AmountReporting
+	return []*entities.Expense{&current, &reversal}
+}
+
+// applyConversionRates fills the converted amounts of an expense from its original amount
+func applyConversionRates(expense *entities.Expense, rates *conversionRates, now time.Time) {
+	expense.AmountPen = expense.AmountOriginal * rates.toPen
+	expense.AmountUsd = expense.AmountOriginal * rates.toUsd
+	expense.AmountReporting = expense.AmountOriginal * rates.toReporting
+	expense.ExchangeRate = rates.usdToPen
+	expense.UpdatedAt = now.Format(time.RFC3339)
+}
+
+// merchantCategory returns the category the user last chose for the bill's merchant
+// when the bill's category is a guess. A category the user chose becomes the merchant's preferred one
+func (s *BillWithExpensesService) merchantCategory(dto dtos.CreateBillWithExpensesDTO, categories []*entities.Category) *entities.Category {
+	if s.merchantService == nil || !dto.CategoryGuessed {
+		return nil
+	}
+
+	// The memory only improves the category, the bill is saved without it
+	merchant, err := s.merchantService.FindMerchant(dto.UserID, dto.Description)
+	if err != nil {
+		log.Printf("Failed to look up merchant %q: %v", dto.Description, err)
+		return nil
+	}
+	if merchant == nil || merchant.CategoryID == "" {
+		return nil
+	}
+	return findCategory(categories, merchant.CategoryID)
+}
+
+// rememberMerchant stores the category the user chose for a merchant, logging failures
+func (s *BillWithExpensesService) rememberMerchant(userID string, name string, categoryID string) {
+	if s.merchantService == nil || categoryID == "" {
+		return
+	}
+	if _, err := s.merchantService.RememberMerchant(userID, name, categoryID); err != nil {
+		log.Printf("Failed to remember merchant %q: %v", name, err)
+	}
+}
+
+// categoryFields resolves a category value to the ID and name stored on bills and expenses,
+// keeping the value as typed if the user has no categories to match it against
+func categoryFields(categories []*entities.Category, value string) (string, string) {
+	category := resolveCategory(categories, value)
+	if category == nil {
+		return "", strings.TrimSpace(value)
+	}
+	return category.CategoryID, category.Name
+}
+
+// getReportingCurrency returns the user's chosen reporting currency, or the default one
+func (s *BillWithExpensesService) getReportingCurrency(userID string) (string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil || user.ReportingCurrency == "" {
+		return entities.DefaultReportingCurrency, nil
+	}
+	return user.ReportingCurrency, nil
+}
+
+var (
+	ErrUnauthorized        = errors.New("unauthorized access to bill")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrBillNotFound        = errors.New("bill not found")
+	ErrExpenseNotFound     = errors.New("expense not found")
+	ErrInvalidExpense      = errors.New("invalid expense")
+	ErrInvalidBillSearch   = errors.New("invalid bill search")
+)
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// BotSessionService keeps the conversation state of each user in a chat, so the bot can ask follow-up
+// questions across messages and restarts. Sessions left unanswered expire
+type BotSessionService struct {
+	sessionRepo ports.BotSessionRepository
+	ttl         time.Duration
+}
+
+func NewBotSessionService(sessionRepo ports.BotSessionRepository, ttlMinutes int) *BotSessionService {
+	return &BotSessionService{
+		sessionRepo: sessionRepo,
+		ttl:         time.Duration(ttlMinutes) * time.Minute,
+	}
+}
+
+// GetSession returns the user's active session in the chat, or nil when there's none or it expired
+func (s *BotSessionService) GetSession(chatID int64, telegramID int64) (*entities.BotSession, error) {
+	session, err := s.sessionRepo.Find(chatID, telegramID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bot session: %w", err)
+	}
+	if session == nil {
+		return nil, nil
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		if err := s.sessionRepo.Delete(chatID, telegramID); err != nil {
+			return nil, fmt.Errorf("failed to delete expired bot session: %w", err)
+		}
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+// SaveSession records that the chat is waiting for the user's answer of state to complete intent
+func (s *BotSessionService) SaveSession(chatID int64, telegramID int64, state string, intent *entities.Intent) error {
+	now := time.Now()
+	session := &entities.BotSession{
+		ChatID:     chatID,
+		TelegramID: telegramID,
+		State:      state,
+		Intent:     intent,
+		UpdatedAt:  now,
+		ExpiresAt:  now.Add(s.ttl),
+	}
+	if err := s.sessionRepo.Save(session); err != nil {
+		return fmt.Errorf("failed to save bot session: %w", err)
+	}
+	return nil
+}
+
+// ClearSession ends the user's conversation in the chat
+func (s *BotSessionService) ClearSession(chatID int64, telegramID int64) error {
+	if err := s.sessionRepo.Delete(chatID, telegramID); err != nil {
+		return fmt.Errorf("failed to delete bot session: %w", err)
+	}
+	return nil
+}
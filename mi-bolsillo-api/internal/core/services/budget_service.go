@@ -0,0 +1,415 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+// budgetAlertThresholds are the fractions of a budget that trigger a notification when crossed
+var budgetAlertThresholds = []float64{0.8, 1.0}
+
+type BudgetService struct {
+	budgetRepo           ports.BudgetRepository
+	expenseRepo          ports.ExpenseRepository
+	categoryRepo         ports.CategoryRepository
+	userRepo             ports.UserRepository
+	exchangeRateProvider ports.ExchangeRateProvider
+	notifier             ports.Notifier
+}
+
+func NewBudgetService(
+	budgetRepo ports.BudgetRepository,
+	expenseRepo ports.ExpenseRepository,
+	categoryRepo ports.CategoryRepository,
+	userRepo ports.UserRepository,
+	exchangeRateProvider ports.ExchangeRateProvider,
+	notifier ports.Notifier,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:           budgetRepo,
+		expenseRepo:          expenseRepo,
+		categoryRepo:         categoryRepo,
+		userRepo:             userRepo,
+		exchangeRateProvider: exchangeRateProvider,
+		notifier:             notifier,
+	}
+}
+
+// CreateBudget creates a monthly budget for a category. The currency defaults to the user's reporting currency
+func (s *BudgetService) CreateBudget(user *entities.User, category string, amountLimit float64, currency string) (*entities.Budget, error) {
+	category = strings.TrimSpace(category)
+	if category == "" || amountLimit <= 0 {
+		return nil, ErrInvalidBudget
+	}
+
+	if currency == "" {
+		currency = user.ReportingCurrency
+	}
+	currency = normalizeCurrency(currency)
+	if !isValidCurrency(currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	existing, err := s.findBudgetByCategory(user.UserID, category)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrBudgetAlreadyExists
+	}
+
+	now := time.Now()
+	budget := &entities.Budget{
+		BudgetID:    uuid.New().String(),
+		UserID:      user.UserID,
+		Category:    category,
+		AmountLimit: amountLimit,
+		Currency:    currency,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.budgetRepo.Create(budget); err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+// UpdateBudget changes the limit and currency of a budget owned by the user
+func (s *BudgetService) UpdateBudget(budgetID string, userID string, amountLimit float64, currency string) (*entities.Budget, error) {
+	budget, err := s.getOwnedBudget(budgetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amountLimit <= 0 {
+		return nil, ErrInvalidBudget
+	}
+	budget.AmountLimit = amountLimit
+
+	if currency != "" {
+		currency = normalizeCurrency(currency)
+		if !isValidCurrency(currency) {
+			return nil, ErrUnsupportedCurrency
+		}
+		budget.Currency = currency
+	}
+
+	budget.UpdatedAt = time.Now()
+	if err := s.budgetRepo.Update(budget); err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+// DeleteBudget deletes a budget owned by the user
+func (s *BudgetService) DeleteBudget(budgetID string, userID string) error {
+	if _, err := s.getOwnedBudget(budgetID, userID); err != nil {
+		return err
+	}
+	return s.budgetRepo.Delete(budgetID)
+}
+
+// GetBudgetStatus returns the spending status of a single budget for the month containing date
+func (s *BudgetService) GetBudgetStatus(budgetID string, userID string, date time.Time) (*dtos.BudgetStatus, error) {
+	budget, err := s.getOwnedBudget(budgetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.findMonthExpenses(userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.findCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildStatus(budget, categories, expenses, date)
+}
+
+// ListBudgetStatuses returns the spending status of every budget of the user for the month containing date
+func (s *BudgetService) ListBudgetStatuses(userID string, date time.Time) ([]*dtos.BudgetStatus, error) {
+	budgets, err := s.budgetRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch budgets: %w", err)
+	}
+
+	if len(budgets) == 0 {
+		return []*dtos.BudgetStatus{}, nil
+	}
+
+	expenses, err := s.findMonthExpenses(userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.findCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dtos.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := s.buildStatus(budget, categories, expenses, date)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+// CheckBudgetAlerts notifies the user through Telegram when the newly created expenses push
+// a budget past 80% or 100% of its limit. Edits pass the reversal of the old expense along with
+// the new one, see budgetChange. Failures are logged and never block bill creation
+func (s *BudgetService) CheckBudgetAlerts(userID string, newExpenses []*entities.Expense) {
+	if s.notifier == nil || len(newExpenses) == 0 {
+		return
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil || user.TelegramID == nil {
+		return
+	}
+
+	budgets, err := s.budgetRepo.FindByUserID(userID)
+	if err != nil {
+		log.Printf("Failed to fetch budgets for alerts: %v", err)
+		return
+	}
+
+	categories, err := s.findCategories(userID)
+	if err != nil {
+		log.Printf("Failed to fetch categories for budget alerts: %v", err)
+		return
+	}
+
+	for _, budget := range budgets {
+		counts := budgetMatcher(budget, categories)
+
+		// Group the new expenses of this budget by month, a bill may be backdated
+		newByMonth := make(map[string][]*entities.Expense)
+		for _, expense := range newExpenses {
+			if counts(expense) && len(expense.Date) >= 7 {
+				newByMonth[expense.Date[:7]] = append(newByMonth[expense.Date[:7]], expense)
+			}
+		}
+
+		for month, added := range newByMonth {
+			date, err := time.Parse("2006-01", month)
+			if err != nil {
+				continue
+			}
+
+			expenses, err := s.findMonthExpenses(userID, date)
+			if err != nil {
+				log.Printf("Failed to fetch expenses for budget alerts: %v", err)
+				continue
+			}
+
+			spentAfter, err := s.sumMatching(expenses, counts, budget.Currency)
+			if err != nil {
+				log.Printf("Failed to compute budget spending: %v", err)
+				continue
+			}
+			addedAmount, err := s.sumMatching(added, counts, budget.Currency)
+			if err != nil {
+				log.Printf("Failed to compute budget spending: %v", err)
+				continue
+			}
+			spentBefore := spentAfter - addedAmount
+
+			// Only alert on the highest threshold crossed by this bill
+			var crossed float64
+			for _, threshold := range budgetAlertThresholds {
+				limit := budget.AmountLimit * threshold
+				if spentBefore < limit && spentAfter >= limit {
+					crossed = threshold
+				}
+			}
+			if crossed == 0 {
+				continue
+			}
+
+			alert := &entities.BudgetAlert{
+				Category:    budget.Category,
+				Month:       month,
+				Threshold:   crossed,
+				Spent:       spentAfter,
+				AmountLimit: budget.AmountLimit,
+				Currency:    budget.Currency,
+			}
+			if err := s.notifier.NotifyBudgetAlert(*user.TelegramID, alert); err != nil {
+				log.Printf("Failed to send budget alert to Telegram user %d: %v", *user.TelegramID, err)
+			}
+		}
+	}
+}
+
+func (s *BudgetService) buildStatus(budget *entities.Budget, categories []*entities.Category, monthExpenses []*entities.Expense, date time.Time) (*dtos.BudgetStatus, error) {
+	spent, err := s.sumMatching(monthExpenses, budgetMatcher(budget, categories), budget.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+
+	// Past months are fully elapsed, the current month counts today
+	daysElapsed := daysInMonth
+	now := time.Now()
+	if now.Year() == date.Year() && now.Month() == date.Month() {
+		daysElapsed = now.Day()
+	}
+
+	status := &dtos.BudgetStatus{
+		BudgetID:    budget.BudgetID,
+		Category:    budget.Category,
+		Month:       monthStart.Format("2006-01"),
+		Currency:    budget.Currency,
+		AmountLimit: budget.AmountLimit,
+		Spent:       spent,
+		Remaining:   budget.AmountLimit - spent,
+		DaysElapsed: daysElapsed,
+		DaysInMonth: daysInMonth,
+	}
+
+	if budget.AmountLimit > 0 {
+		status.PercentUsed = (spent / budget.AmountLimit) * 100
+	}
+	if daysElapsed > 0 {
+		status.DailyBurnRate = spent / float64(daysElapsed)
+		status.ProjectedSpend = status.DailyBurnRate * float64(daysInMonth)
+	}
+
+	return status, nil
+}
+
+// budgetMatcher returns whether an expense counts toward the budget: expenses filed under the budget's
+// category or any of its subcategories. Expenses not linked to a category yet are matched by name
+func budgetMatcher(budget *entities.Budget, categories []*entities.Category) func(*entities.Expense) bool {
+	categoryIDs := make(map[string]bool)
+	if category := findCategory(categories, budget.Category); category != nil {
+		categoryIDs[category.CategoryID] = true
+		for _, child := range categories {
+			if child.ParentID == category.CategoryID {
+				categoryIDs[child.CategoryID] = true
+			}
+		}
+	}
+
+	return func(expense *entities.Expense) bool {
+		if expense.CategoryID != "" && len(categoryIDs) > 0 {
+			return categoryIDs[expense.CategoryID]
+		}
+		return strings.EqualFold(expense.Category, budget.Category)
+	}
+}
+
+// sumMatching adds the expenses that count toward a budget converted into currency
+func (s *BudgetService) sumMatching(expenses []*entities.Expense, counts func(*entities.Expense) bool, currency string) (float64, error) {
+	var total float64
+	for _, expense := range expenses {
+		if !counts(expense) {
+			continue
+		}
+
+		amount, err := s.amountIn(expense, currency)
+		if err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+// amountIn returns the expense amount in currency, using the stored conversions when possible
+func (s *BudgetService) amountIn(expense *entities.Expense, currency string) (float64, error) {
+	switch currency {
+	case expense.Currency:
+		return expense.AmountOriginal, nil
+	case expense.ReportingCurrency:
+		return expense.AmountReporting, nil
+	case "PEN":
+		return expense.AmountPen, nil
+	case "USD":
+		return expense.AmountUsd, nil
+	}
+
+	date, err := time.Parse("2006-01-02", expense.Date)
+	if err != nil {
+		date = time.Now()
+	}
+	rate, err := s.exchangeRateProvider.GetRate(expense.Currency, currency, date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve %s/%s rate: %w", expense.Currency, currency, err)
+	}
+	return expense.AmountOriginal * rate, nil
+}
+
+func (s *BudgetService) findMonthExpenses(userID string, date time.Time) ([]*entities.Expense, error) {
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	expenses, err := s.expenseRepo.FindByUserIDAndDateRange(userID, monthStart.Format("2006-01-02"), monthEnd.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch expenses: %w", err)
+	}
+	return expenses, nil
+}
+
+func (s *BudgetService) findCategories(userID string) ([]*entities.Category, error) {
+	categories, err := s.categoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	return categories, nil
+}
+
+func (s *BudgetService) findBudgetByCategory(userID string, category string) (*entities.Budget, error) {
+	budgets, err := s.budgetRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch budgets: %w", err)
+	}
+	for _, budget := range budgets {
+		if strings.EqualFold(budget.Category, category) {
+			return budget, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *BudgetService) getOwnedBudget(budgetID string, userID string) (*entities.Budget, error) {
+	budget, err := s.budgetRepo.FindByID(budgetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+	if budget == nil {
+		return nil, ErrBudgetNotFound
+	}
+	if budget.UserID != userID {
+		return nil, ErrUnauthorizedBudget
+	}
+	return budget, nil
+}
+
+var (
+	ErrBudgetNotFound      = errors.New("budget not found")
+	ErrUnauthorizedBudget  = errors.New("unauthorized access to budget")
+	ErrInvalidBudget       = errors.New("budget requires a category and a positive limit")
+	ErrBudgetAlreadyExists = errors.New("a budget already exists for this category")
+)
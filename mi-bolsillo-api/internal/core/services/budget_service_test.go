@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+func TestBudgetMatcherIncludesSubcategories(t *testing.T) {
+	categories := []*entities.Category{
+		{CategoryID: "food", Name: "Food"},
+		{CategoryID: "groceries", ParentID: "food", Name: "Groceries"},
+		{CategoryID: "transport", Name: "Transport"},
+	}
+	counts := budgetMatcher(&entities.Budget{Category: "Food"}, categories)
+
+	tests := []struct {
+		name    string
+		expense *entities.Expense
+		want    bool
+	}{
+		{"category", &entities.Expense{Category: "Food", CategoryID: "food"}, true},
+		{"subcategory", &entities.Expense{Category: "Groceries", CategoryID: "groceries"}, true},
+		{"other category", &entities.Expense{Category: "Transport", CategoryID: "transport"}, false},
+		{"unlinked expense with the same name", &entities.Expense{Category: "food"}, true},
+		{"unlinked expense with another name", &entities.Expense{Category: "Groceries"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := counts(tt.expense); got != tt.want {
+				t.Errorf("budgetMatcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetChangeOnlyCountsTheDifference(t *testing.T) {
+	groceries := entities.Expense{CategoryID: "groceries", Category: "Groceries", Date: "2025-10-10", AmountOriginal: 50, AmountPen: 50}
+	sum := func(expenses []*entities.Expense) float64 {
+		var total float64
+		for _, expense := range expenses {
+			total += expense.AmountPen
+		}
+		return total
+	}
+
+	raised := groceries
+	raised.AmountOriginal, raised.AmountPen = 80, 80
+	if change := budgetChange(&groceries, &raised); sum(change) != 30 {
+		t.Errorf("budgetChange() of a raised amount adds %v, want 30", sum(change))
+	}
+
+	lowered := groceries
+	lowered.AmountOriginal, lowered.AmountPen = 20, 20
+	if change := budgetChange(&groceries, &lowered); change != nil {
+		t.Errorf("budgetChange() of a lowered amount = %v, want nil", change)
+	}
+
+	moved := groceries
+	moved.CategoryID, moved.Category = "transport", "Transport"
+	change := budgetChange(&groceries, &moved)
+	if len(change) != 2 || change[0].CategoryID != "transport" || change[1].CategoryID != "groceries" || change[1].AmountPen != -50 {
+		t.Errorf("budgetChange() of a new category = %v, want the new expense and the old one reversed", change)
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+// maxRulePatternLength keeps patterns to the size of a description
+const maxRulePatternLength = 100
+
+var ruleFields = map[string]bool{
+	entities.RuleFieldDescription: true,
+	entities.RuleFieldMerchant:    true,
+}
+
+var ruleOperators = map[string]bool{
+	entities.RuleOperatorContains:   true,
+	entities.RuleOperatorEquals:     true,
+	entities.RuleOperatorStartsWith: true,
+}
+
+type CategoryRuleService struct {
+	ruleRepo        ports.CategoryRuleRepository
+	billRepo        ports.BillRepository
+	expenseRepo     ports.ExpenseRepository
+	categoryService *CategoryService
+	unitOfWork      ports.UnitOfWork
+}
+
+func NewCategoryRuleService(
+	ruleRepo ports.CategoryRuleRepository,
+	billRepo ports.BillRepository,
+	expenseRepo ports.ExpenseRepository,
+	categoryService *CategoryService,
+	unitOfWork ports.UnitOfWork,
+) *CategoryRuleService {
+	return &CategoryRuleService{
+		ruleRepo:        ruleRepo,
+		billRepo:        billRepo,
+		expenseRepo:     expenseRepo,
+		categoryService: categoryService,
+		unitOfWork:      unitOfWork,
+	}
+}
+
+// ListRules returns the user's rules in the order they are checked
+func (s *CategoryRuleService) ListRules(userID string) ([]*entities.CategoryRule, error) {
+	rules, err := s.ruleRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateRule adds a rule for the user, applied to the bills created from now on
+func (s *CategoryRuleService) CreateRule(userID string, dto dtos.CategoryRuleDTO) (*entities.CategoryRule, error) {
+	now := time.Now()
+	rule := &entities.CategoryRule{
+		RuleID:    uuid.New().String(),
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.applyRuleDTO(rule, dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return rule, nil
+}
+
+// UpdateRule replaces the condition, category and priority of a rule owned by the user
+func (s *CategoryRuleService) UpdateRule(ruleID string, userID string, dto dtos.CategoryRuleDTO) (*entities.CategoryRule, error) {
+	rule, err := s.getOwnedRule(ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRuleDTO(rule, dto); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+	return rule, nil
+}
+
+// DeleteRule deletes a rule owned by the user, bills it already categorized keep their category
+func (s *CategoryRuleService) DeleteRule(ruleID string, userID string) error {
+	if _, err := s.getOwnedRule(ruleID, userID); err != nil {
+		return err
+	}
+	return s.ruleRepo.Delete(ruleID)
+}
+
+// ApplyRules recategorizes the user's existing bills and expenses with the current rules. In a dry
+// run nothing is saved, the result reports what would change
+func (s *CategoryRuleService) ApplyRules(userID string, dryRun bool) (*dtos.RuleApplyResult, error) {
+	rules, err := s.ListRules(userID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryService.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+	matcher := newCategoryRuleMatcher(rules, categories)
+
+	bills, err := s.billRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	billIDs := make([]string, len(bills))
+	for i, bill := range bills {
+		billIDs[i] = bill.BillId
+	}
+	expenses, err := s.expenseRepo.FindByBillIDs(billIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch expenses: %w", err)
+	}
+	expensesByBill := make(map[string][]*entities.Expense, len(bills))
+	for _, expense := range expenses {
+		expensesByBill[expense.BillID] = append(expensesByBill[expense.BillID], expense)
+	}
+
+	now := time.Now()
+	result := &dtos.RuleApplyResult{DryRun: dryRun, Changes: []dtos.RuleChange{}}
+	var changedBills []*entities.Bill
+	var changedExpenses []*entities.Expense
+
+	for _, bill := range bills {
+		if rule, category := matcher.match(bill.Description, bill.Description); category != nil && category.CategoryID != bill.CategoryID {
+			result.Changes = append(result.Changes, ruleChange(bill.BillId, "", bill.Description, bill.Category, rule, category))
+			bill.CategoryID, bill.Category, bill.UpdatedAt = category.CategoryID, category.Name, now
+			changedBills = append(changedBills, bill)
+		}
+
+		for _, expense := range expensesByBill[bill.BillId] {
+			rule, category := matcher.match(expense.Description, bill.Description)
+			if category == nil || category.CategoryID == expense.CategoryID {
+				continue
+			}
+			result.Changes = append(result.Changes, ruleChange(bill.BillId, expense.ExpenseId, expense.Description, expense.Category, rule, category))
+			expense.CategoryID, expense.Category, expense.UpdatedAt = category.CategoryID, category.Name, now.Format(time.RFC3339)
+			changedExpenses = append(changedExpenses, expense)
+		}
+	}
+	result.BillsChanged = len(changedBills)
+	result.ExpensesChanged = len(changedExpenses)
+
+	if dryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		for _, bill := range changedBills {
+			if err := repos.Bills.Update(bill); err != nil {
+				return err
+			}
+		}
+		for _, expense := range changedExpenses {
+			if err := repos.Expenses.Update(expense); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to recategorize bills: %w", err)
+	}
+
+	return result, nil
+}
+
+// applyRuleDTO validates the rule data and sets it on rule
+func (s *CategoryRuleService) applyRuleDTO(rule *entities.CategoryRule, dto dtos.CategoryRuleDTO) error {
+	field := strings.ToLower(strings.TrimSpace(dto.Field))
+	if field == "" {
+		field = entities.RuleFieldDescription
+	}
+	if !ruleFields[field] {
+		return fmt.Errorf("%w: field must be description or merchant", ErrInvalidRule)
+	}
+
+	operator := strings.ToLower(strings.TrimSpace(dto.Operator))
+	if operator == "" {
+		operator = entities.RuleOperatorContains
+	}
+	if !ruleOperators[operator] {
+		return fmt.Errorf("%w: operator must be contains, equals or starts_with", ErrInvalidRule)
+	}
+
+	pattern := strings.TrimSpace(dto.Pattern)
+	if pattern == "" || len(pattern) > maxRulePatternLength {
+		return fmt.Errorf("%w: pattern is required and at most %d characters", ErrInvalidRule, maxRulePatternLength)
+	}
+
+	category, err := s.categoryService.FindCategory(rule.UserID, dto.Category)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return fmt.Errorf("%w: category not found", ErrInvalidRule)
+	}
+
+	rule.Field = field
+	rule.Operator = operator
+	rule.Pattern = pattern
+	rule.CategoryID = category.CategoryID
+	rule.Priority = dto.Priority
+	return nil
+}
+
+// getOwnedRule returns the rule after verifying it belongs to the user
+func (s *CategoryRuleService) getOwnedRule(ruleID string, userID string) (*entities.CategoryRule, error) {
+	rule, err := s.ruleRepo.FindByID(ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rule: %w", err)
+	}
+	if rule == nil {
+		return nil, ErrRuleNotFound
+	}
+	if rule.UserID != userID {
+		return nil, ErrUnauthorizedRule
+	}
+	return rule, nil
+}
+
+func ruleChange(billID string, expenseID string, description string, from string, rule *entities.CategoryRule, category *entities.Category) dtos.RuleChange {
+	return dtos.RuleChange{
+		BillID:       billID,
+		ExpenseID:    expenseID,
+		Description:  description,
+		RuleID:       rule.RuleID,
+		FromCategory: from,
+		ToCategory:   category.Name,
+		ToCategoryID: category.CategoryID,
+	}
+}
+
+// categoryRuleMatcher checks a user's rules in priority order, skipping rules whose category is gone
+type categoryRuleMatcher struct {
+	rules      []*entities.CategoryRule
+	categories map[string]*entities.Category
+}
+
+func newCategoryRuleMatcher(rules []*entities.CategoryRule, categories []*entities.Category) *categoryRuleMatcher {
+	byID := make(map[string]*entities.Category, len(categories))
+	for _, category := range categories {
+		byID[category.CategoryID] = category
+	}
+	return &categoryRuleMatcher{rules: rules, categories: byID}
+}
+
+// match returns the first rule matching a line's description or its bill's merchant, with its category
+func (m *categoryRuleMatcher) match(description string, merchant string) (*entities.CategoryRule, *entities.Category) {
+	for _, rule := range m.rules {
+		category, ok := m.categories[rule.CategoryID]
+		if !ok {
+			continue
+		}
+
+		value := description
+		if rule.Field == entities.RuleFieldMerchant {
+			value = merchant
+		}
+		if ruleMatches(rule, value) {
+			return rule, category
+		}
+	}
+	return nil, nil
+}
+
+// ruleMatches compares a value against the rule's pattern ignoring case and accents
+func ruleMatches(rule *entities.CategoryRule, value string) bool {
+	value = categoryKey(value)
+	pattern := categoryKey(rule.Pattern)
+	if value == "" || pattern == "" {
+		return false
+	}
+
+	switch rule.Operator {
+	case entities.RuleOperatorEquals:
+		return value == pattern
+	case entities.RuleOperatorStartsWith:
+		return strings.HasPrefix(value, pattern)
+	default:
+		return strings.Contains(value, pattern)
+	}
+}
+
+var (
+	ErrRuleNotFound     = errors.New("rule not found")
+	ErrUnauthorizedRule = errors.New("unauthorized access to rule")
+	ErrInvalidRule      = errors.New("invalid rule")
+)
@@ -0,0 +1,546 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+// maxCategoryNameLength keeps names short enough for keyboard buttons and charts
+const maxCategoryNameLength = 40
+
+// categoryPathSeparator joins a subcategory to its parent in the labels given to the LLM, e.g. "Food > Groceries"
+const categoryPathSeparator = " > "
+
+// Icon and color of the categories created from names found in a user's existing bills
+const (
+	customCategoryIcon  = "🏷️"
+	customCategoryColor = "#9E9E9E"
+)
+
+// defaultCategories are the categories every user starts with
+var defaultCategories = []entities.Category{
+	{Name: "General", Icon: "📁", Color: "#9E9E9E"},
+	{Name: "Food", Icon: "🛒", Color: "#4CAF50"},
+	{Name: "Restaurants", Icon: "🍽️", Color: "#FF7043"},
+	{Name: "Hotels", Icon: "🏨", Color: "#5C6BC0"},
+	{Name: "Rent", Icon: "🏠", Color: "#8D6E63"},
+	{Name: "Transportation", Icon: "🚕", Color: "#42A5F5"},
+	{Name: "Entertainment", Icon: "🎬", Color: "#AB47BC"},
+	{Name: "Shopping", Icon: "🛍️", Color: "#EC407A"},
+	{Name: "Utilities", Icon: "💡", Color: "#FFCA28"},
+	{Name: "Healthcare", Icon: "💊", Color: "#EF5350"},
+	{Name: "Other", Icon: "📦", Color: "#78909C"},
+}
+
+// fallbackCategories receive what can't be matched to any of the user's categories, in order of preference
+var fallbackCategories = []string{"Other", "General"}
+
+// categoryAliases maps the Spanish names users type, and the LLM sometimes returns, to the default categories
+var categoryAliases = map[string]string{
+	"comida":          "Food",
+	"alimentos":       "Food",
+	"supermercado":    "Food",
+	"restaurante":     "Restaurants",
+	"restaurantes":    "Restaurants",
+	"hotel":           "Hotels",
+	"hoteles":         "Hotels",
+	"alquiler":        "Rent",
+	"transporte":      "Transportation",
+	"entretenimiento": "Entertainment",
+	"compras":         "Shopping",
+	"servicios":       "Utilities",
+	"salud":           "Healthcare",
+	"otro":            "Other",
+	"otros":           "Other",
+}
+
+var categoryColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// accentReplacer folds the Spanish accents so "Categoría" and "categoria" match
+var accentReplacer = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
+
+type CategoryService struct {
+	categoryRepo ports.CategoryRepository
+	budgetRepo   ports.BudgetRepository
+	unitOfWork   ports.UnitOfWork
+}
+
+func NewCategoryService(categoryRepo ports.CategoryRepository, budgetRepo ports.BudgetRepository, unitOfWork ports.UnitOfWork) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+		budgetRepo:   budgetRepo,
+		unitOfWork:   unitOfWork,
+	}
+}
+
+// ListCategories returns the user's categories. The first time, the default set is created and the
+// categories of the user's existing bills, expenses, budgets and recurring bills are linked to it
+func (s *CategoryService) ListCategories(userID string) ([]*entities.Category, error) {
+	categories, err := s.categoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	if len(categories) > 0 {
+		return categories, nil
+	}
+
+	if err := s.seedCategories(userID); err != nil {
+		// A concurrent request may have seeded them first, the unique names make this one fail
+		categories, findErr := s.categoryRepo.FindByUserID(userID)
+		if findErr == nil && len(categories) > 0 {
+			return categories, nil
+		}
+		return nil, fmt.Errorf("failed to create default categories: %w", err)
+	}
+
+	return s.categoryRepo.FindByUserID(userID)
+}
+
+// CategoryLabels returns the user's categories as shown to the LLM, subcategories prefixed by their parent
+func (s *CategoryService) CategoryLabels(userID string) ([]string, error) {
+	categories, err := s.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+	return categoryLabels(categories), nil
+}
+
+// categoryLabels names the categories as "Parent > Child" for subcategories
+func categoryLabels(categories []*entities.Category) []string {
+	byID := make(map[string]*entities.Category, len(categories))
+	for _, category := range categories {
+		byID[category.CategoryID] = category
+	}
+
+	labels := make([]string, 0, len(categories))
+	for _, category := range categories {
+		if parent, ok := byID[category.ParentID]; ok {
+			labels = append(labels, parent.Name+categoryPathSeparator+category.Name)
+			continue
+		}
+		labels = append(labels, category.Name)
+	}
+	return labels
+}
+
+// FindCategory matches a category ID, name, "Parent > Child" label or Spanish alias against the
+// user's categories, returning nil when nothing matches
+func (s *CategoryService) FindCategory(userID string, value string) (*entities.Category, error) {
+	categories, err := s.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+	return findCategory(categories, value), nil
+}
+
+// ResolveCategory is like FindCategory but falls back to the user's catch-all category
+func (s *CategoryService) ResolveCategory(userID string, value string) (*entities.Category, error) {
+	categories, err := s.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveCategory(categories, value), nil
+}
+
+// CreateCategory adds a category for the user, ParentID makes it a subcategory of a top-level category
+func (s *CategoryService) CreateCategory(userID string, dto dtos.CreateCategoryDTO) (*entities.Category, error) {
+	categories, err := s.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := validateCategoryName(dto.Name)
+	if err != nil {
+		return nil, err
+	}
+	if categoryNamed(categories, name, "") != nil {
+		return nil, ErrCategoryNameTaken
+	}
+
+	color := strings.TrimSpace(dto.Color)
+	if color == "" {
+		color = customCategoryColor
+	}
+	if !categoryColorPattern.MatchString(color) {
+		return nil, fmt.Errorf("%w: color must be #RRGGBB", ErrInvalidCategory)
+	}
+
+	icon := strings.TrimSpace(dto.Icon)
+	if icon == "" {
+		icon = customCategoryIcon
+	}
+
+	parentID := strings.TrimSpace(dto.ParentID)
+	if parentID != "" {
+		if err := validateCategoryParent(categories, parentID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	category := &entities.Category{
+		CategoryID: uuid.New().String(),
+		UserID:     userID,
+		ParentID:   parentID,
+		Name:       name,
+		Icon:       icon,
+		Color:      strings.ToUpper(color),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.categoryRepo.Create(category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return category, nil
+}
+
+// UpdateCategory applies a partial update to a category. Renaming it renames the category of its
+// bills, expenses, budgets and recurring bills too
+func (s *CategoryService) UpdateCategory(categoryID string, userID string, dto dtos.UpdateCategoryDTO) (*entities.Category, error) {
+	category, err := s.getOwnedCategory(categoryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := category.Name
+	if dto.Name != nil {
+		name, err := validateCategoryName(*dto.Name)
+		if err != nil {
+			return nil, err
+		}
+		if categoryNamed(categories, name, category.CategoryID) != nil {
+			return nil, ErrCategoryNameTaken
+		}
+		category.Name = name
+	}
+	if dto.Icon != nil && strings.TrimSpace(*dto.Icon) != "" {
+		category.Icon = strings.TrimSpace(*dto.Icon)
+	}
+	if dto.Color != nil {
+		color := strings.TrimSpace(*dto.Color)
+		if !categoryColorPattern.MatchString(color) {
+			return nil, fmt.Errorf("%w: color must be #RRGGBB", ErrInvalidCategory)
+		}
+		category.Color = strings.ToUpper(color)
+	}
+	if dto.ParentID != nil {
+		parentID := strings.TrimSpace(*dto.ParentID)
+		if parentID != "" {
+			if err := validateCategoryParent(categories, parentID, category.CategoryID); err != nil {
+				return nil, err
+			}
+		}
+		category.ParentID = parentID
+	}
+	category.UpdatedAt = time.Now()
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Categories.Update(category); err != nil {
+			return err
+		}
+		if category.Name == oldName {
+			return nil
+		}
+		return renameCategory(repos, userID, category.CategoryID, oldName, category.CategoryID, category.Name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category, its bills, expenses and rules move to the parent category, or to
+// the catch-all category for top-level ones
+func (s *CategoryService) DeleteCategory(categoryID string, userID string) error {
+	category, err := s.getOwnedCategory(categoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.ListCategories(userID)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]*entities.Category, 0, len(categories))
+	for _, other := range categories {
+		if other.ParentID == category.CategoryID {
+			return ErrCategoryHasChildren
+		}
+		if other.CategoryID != category.CategoryID {
+			remaining = append(remaining, other)
+		}
+	}
+	if len(remaining) == 0 {
+		return fmt.Errorf("%w: the last category can't be deleted", ErrInvalidCategory)
+	}
+
+	// A budget would silently stop tracking anything, it has to be deleted first
+	budgets, err := s.budgetRepo.FindByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch budgets: %w", err)
+	}
+	for _, budget := range budgets {
+		if strings.EqualFold(budget.Category, category.Name) {
+			return ErrCategoryInUse
+		}
+	}
+
+	target := findCategory(remaining, category.ParentID)
+	if target == nil {
+		target = fallbackCategory(remaining)
+	}
+
+	return s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := renameCategory(repos, userID, category.CategoryID, category.Name, target.CategoryID, target.Name); err != nil {
+			return err
+		}
+		if err := repos.CategoryRules.ReassignCategory(category.CategoryID, target.CategoryID); err != nil {
+			return err
+		}
+		if err := repos.Merchants.ReassignCategory(category.CategoryID, target.CategoryID); err != nil {
+			return err
+		}
+		return repos.Categories.Delete(category.CategoryID)
+	})
+}
+
+// seedCategories creates the default categories and links the user's existing data to them,
+// names that match no default category become categories of their own
+func (s *CategoryService) seedCategories(userID string) error {
+	return s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		now := time.Now()
+		categories := make([]*entities.Category, 0, len(defaultCategories))
+		for i, defaultCategory := range defaultCategories {
+			category := defaultCategory
+			category.CategoryID = uuid.New().String()
+			category.UserID = userID
+			// Keep the seed order, categories are listed by creation time
+			category.CreatedAt = now.Add(time.Duration(i) * time.Millisecond)
+			category.UpdatedAt = now
+			if err := repos.Categories.Create(&category); err != nil {
+				return err
+			}
+			categories = append(categories, &category)
+		}
+
+		names, err := existingCategoryNames(repos, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			category := findCategory(categories, name)
+			if category == nil {
+				category = &entities.Category{
+					CategoryID: uuid.New().String(),
+					UserID:     userID,
+					Name:       name,
+					Icon:       customCategoryIcon,
+					Color:      customCategoryColor,
+					CreatedAt:  now.Add(time.Duration(len(categories)) * time.Millisecond),
+					UpdatedAt:  now,
+				}
+				if err := repos.Categories.Create(category); err != nil {
+					return err
+				}
+				categories = append(categories, category)
+			}
+
+			if err := renameCategory(repos, userID, "", name, category.CategoryID, category.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getOwnedCategory returns the category after verifying it belongs to the user
+func (s *CategoryService) getOwnedCategory(categoryID string, userID string) (*entities.Category, error) {
+	category, err := s.categoryRepo.FindByID(categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch category: %w", err)
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+	if category.UserID != userID {
+		return nil, ErrUnauthorizedCategory
+	}
+	return category, nil
+}
+
+// existingCategoryNames returns the distinct category names the user already used, ignoring case
+func existingCategoryNames(repos ports.TxRepositories, userID string) ([]string, error) {
+	billNames, err := repos.Bills.FindCategoryNames(userID)
+	if err != nil {
+		return nil, err
+	}
+	expenseNames, err := repos.Expenses.FindCategoryNames(userID)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := repos.Budgets.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	recurringBills, err := repos.RecurringBills.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := append(billNames, expenseNames...)
+	for _, budget := range budgets {
+		candidates = append(candidates, budget.Category)
+	}
+	for _, recurringBill := range recurringBills {
+		candidates = append(candidates, recurringBill.Category)
+	}
+
+	seen := make(map[string]bool, len(candidates))
+	names := make([]string, 0, len(candidates))
+	for _, name := range candidates {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] || len(name) > maxCategoryNameLength {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// renameCategory moves everything filed under a category, by ID or name, to another category
+func renameCategory(repos ports.TxRepositories, userID string, fromID string, fromName string, categoryID string, name string) error {
+	if err := repos.Bills.ReassignCategory(userID, fromID, fromName, categoryID, name); err != nil {
+		return err
+	}
+	if err := repos.Expenses.ReassignCategory(userID, fromID, fromName, categoryID, name); err != nil {
+		return err
+	}
+	if err := repos.Budgets.RenameCategory(userID, fromName, name); err != nil {
+		return err
+	}
+	return repos.RecurringBills.RenameCategory(userID, fromName, name)
+}
+
+// findCategory matches a category ID, a name or "Parent > Child" label ignoring case and accents,
+// or a Spanish alias of a default category
+func findCategory(categories []*entities.Category, value string) *entities.Category {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	for _, category := range categories {
+		if category.CategoryID == value {
+			return category
+		}
+	}
+
+	if index := strings.LastIndex(value, strings.TrimSpace(categoryPathSeparator)); index != -1 {
+		value = strings.TrimSpace(value[index+1:])
+	}
+	if category := categoryNamed(categories, value, ""); category != nil {
+		return category
+	}
+
+	if alias, ok := categoryAliases[categoryKey(value)]; ok {
+		return categoryNamed(categories, alias, "")
+	}
+	return nil
+}
+
+// resolveCategory is findCategory falling back to the catch-all category, nil only without categories
+func resolveCategory(categories []*entities.Category, value string) *entities.Category {
+	if category := findCategory(categories, value); category != nil {
+		return category
+	}
+	return fallbackCategory(categories)
+}
+
+// fallbackCategory returns the first of the fallback categories the user has, or their first category
+func fallbackCategory(categories []*entities.Category) *entities.Category {
+	for _, name := range fallbackCategories {
+		if category := categoryNamed(categories, name, ""); category != nil {
+			return category
+		}
+	}
+	if len(categories) > 0 {
+		return categories[0]
+	}
+	return nil
+}
+
+// categoryNamed returns the category with name ignoring case and accents, skipping excludeID
+func categoryNamed(categories []*entities.Category, name string, excludeID string) *entities.Category {
+	key := categoryKey(name)
+	for _, category := range categories {
+		if category.CategoryID != excludeID && categoryKey(category.Name) == key {
+			return category
+		}
+	}
+	return nil
+}
+
+func categoryKey(name string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func validateCategoryName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxCategoryNameLength {
+		return "", fmt.Errorf("%w: name is required and at most %d characters", ErrInvalidCategory, maxCategoryNameLength)
+	}
+	if strings.Contains(name, strings.TrimSpace(categoryPathSeparator)) {
+		return "", fmt.Errorf("%w: name can't contain %q", ErrInvalidCategory, strings.TrimSpace(categoryPathSeparator))
+	}
+	return name, nil
+}
+
+// validateCategoryParent checks parentID is one of the user's top-level categories. Subcategories only go
+// one level deep, so a category with subcategories of its own (categoryID) can't be moved under another
+func validateCategoryParent(categories []*entities.Category, parentID string, categoryID string) error {
+	var parent *entities.Category
+	for _, category := range categories {
+		if category.CategoryID == parentID {
+			parent = category
+		}
+		if categoryID != "" && category.ParentID == categoryID {
+			return fmt.Errorf("%w: a category with subcategories can't be a subcategory", ErrInvalidCategory)
+		}
+	}
+	if parent == nil || parent.CategoryID == categoryID {
+		return fmt.Errorf("%w: parent category not found", ErrInvalidCategory)
+	}
+	if parent.ParentID != "" {
+		return fmt.Errorf("%w: subcategories can't have subcategories", ErrInvalidCategory)
+	}
+	return nil
+}
+
+var (
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrUnauthorizedCategory = errors.New("unauthorized access to category")
+	ErrInvalidCategory      = errors.New("invalid category")
+	ErrCategoryNameTaken    = errors.New("a category with this name already exists")
+	ErrCategoryHasChildren  = errors.New("category has subcategories")
+	ErrCategoryInUse        = errors.New("category has a budget")
+)
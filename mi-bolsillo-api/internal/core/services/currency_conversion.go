@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// conversionRates holds the multipliers to convert an amount in a bill's original currency
+// into the PEN and USD columns and into the user's reporting currency
+type conversionRates struct {
+	usdToPen    float64
+	toPen       float64
+	toUsd       float64
+	toReporting float64
+}
+
+// resolveConversionRates looks up the rates for the given date. usdToPen is the caller supplied
+// USD to PEN rate and is resolved through the provider when zero
+func resolveConversionRates(provider ports.ExchangeRateProvider, currency string, reportingCurrency string, date time.Time, usdToPen float64) (*conversionRates, error) {
+	if usdToPen <= 0 {
+		rate, err := provider.GetRate("USD", "PEN", date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve USD/PEN rate: %w", err)
+		}
+		usdToPen = rate
+	}
+
+	rates := &conversionRates{usdToPen: usdToPen}
+
+	switch currency {
+	case "PEN":
+		rates.toPen = 1
+		rates.toUsd = 1 / usdToPen
+	case "USD":
+		rates.toPen = usdToPen
+		rates.toUsd = 1
+	default:
+		toPen, err := provider.GetRate(currency, "PEN", date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s/PEN rate: %w", currency, err)
+		}
+		toUsd, err := provider.GetRate(currency, "USD", date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s/USD rate: %w", currency, err)
+		}
+		rates.toPen = toPen
+		rates.toUsd = toUsd
+	}
+
+	switch reportingCurrency {
+	case currency:
+		rates.toReporting = 1
+	case "PEN":
+		rates.toReporting = rates.toPen
+	case "USD":
+		rates.toReporting = rates.toUsd
+	default:
+		toReporting, err := provider.GetRate(currency, reportingCurrency, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s/%s rate: %w", currency, reportingCurrency, err)
+		}
+		rates.toReporting = toReporting
+	}
+
+	return rates, nil
+}
+
+// currencyAliases maps symbols and names seen on receipts to ISO 4217 codes
+var currencyAliases = map[string]string{
+	"S/":      "PEN",
+	"S/.":     "PEN",
+	"SOL":     "PEN",
+	"SOLES":   "PEN",
+	"$":       "USD",
+	"US$":     "USD",
+	"DOLAR":   "USD",
+	"DOLARES": "USD",
+	"DÓLAR":   "USD",
+	"DÓLARES": "USD",
+	"€":       "EUR",
+	"EURO":    "EUR",
+	"EUROS":   "EUR",
+}
+
+// normalizeCurrency converts a currency code or symbol into an uppercase ISO 4217 code,
+// defaulting to PEN when empty
+func normalizeCurrency(currency string) string {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if code == "" {
+		return "PEN"
+	}
+	if alias, ok := currencyAliases[code]; ok {
+		return alias
+	}
+	return code
+}
+
+// isValidCurrency reports whether code looks like an ISO 4217 currency code
+func isValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
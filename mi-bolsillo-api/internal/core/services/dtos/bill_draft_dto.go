@@ -0,0 +1,35 @@
+package dtos
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+// CreateBillDraftDTO holds a parsed receipt to be reviewed before it's saved as a bill
+type CreateBillDraftDTO struct {
+	UserID      string                   `json:"userId"`
+	WalletID    string                   `json:"walletId"`
+	Source      string                   `json:"source"`
+	Description string                   `json:"description"`
+	Currency    string                   `json:"currency"`
+	Date        string                   `json:"date"`
+	TotalAmount float64                  `json:"totalAmount"`
+	Items       []entities.BillDraftItem `json:"items"`
+	// SupplierRUC and InvoiceNumber are set when the draft comes from a SUNAT electronic invoice
+	SupplierRUC   string  `json:"supplierRuc"`
+	InvoiceNumber string  `json:"invoiceNumber"`
+	Subtotal      float64 `json:"subtotal"`
+	TaxAmount     float64 `json:"taxAmount"`
+	TipAmount     float64 `json:"tipAmount"`
+	ReceiptImage  []byte  `json:"-"`
+	// CardLastFour are the last digits of the card printed on the receipt, matched against the user's cards
+	CardLastFour string `json:"cardLastFour"`
+}
+
+// UpdateBillDraftDTO holds the corrections to a bill draft, nil fields are left unchanged
+type UpdateBillDraftDTO struct {
+	Description *string                   `json:"description"`
+	Currency    *string                   `json:"currency"`
+	Date        *string                   `json:"date"`
+	TotalAmount *float64                  `json:"totalAmount"`
+	Items       *[]entities.BillDraftItem `json:"items"`
+	// PaymentMethodID changes the account the bill was paid with, empty unlinks it
+	PaymentMethodID *string `json:"paymentMethodId"`
+}
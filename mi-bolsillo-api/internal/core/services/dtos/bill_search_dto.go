@@ -0,0 +1,31 @@
+package dtos
+
+import "time"
+
+// BillSearchDTO holds the filters, sort and page of a bill listing
+type BillSearchDTO struct {
+	UserID string
+	// WalletID lists the bills of a shared wallet instead of the user's own
+	WalletID    string
+	From        *time.Time
+	To          *time.Time
+	Category    string
+	Currency    string
+	Source      string
+	MinAmount   *float64
+	MaxAmount   *float64
+	Description string
+	SortBy      string
+	Ascending   bool
+	// Cursor and Limit page the results, every matching bill is returned when neither is set
+	Cursor string
+	Limit  int
+	// PaymentMethodID keeps the bills paid with the account
+	PaymentMethodID string
+}
+
+// BillSearchResult is a page of bills, NextCursor is empty on the last page
+type BillSearchResult struct {
+	Bills      []*BillWithExpensesResponse `json:"bills"`
+	NextCursor string                      `json:"nextCursor,omitempty"`
+}
@@ -8,15 +8,34 @@ import (
 
 // BillWithExpensesResponse represents a bill with its associated expenses
 type BillWithExpensesResponse struct {
-	BillId      string              `json:"billId" example:"123e4567-e89b-12d3-a456-426614174000"`
-	AmountPen   float64             `json:"amountPen" example:"95.75"`
-	AmountUsd   float64             `json:"amountUsd" example:"25.50"`
-	Description string              `json:"description" example:"Grocery shopping"`
-	Category    string              `json:"category" example:"Food"`
-	Currency    string              `json:"currency" example:"USD"`
-	UserID      string              `json:"userId" example:"user_123456789"`
-	Date        time.Time           `json:"date" example:"2025-10-10T10:00:00Z"`
-	CreatedAt   time.Time           `json:"createdAt" example:"2025-10-10T10:00:00Z"`
-	UpdatedAt   time.Time           `json:"updatedAt" example:"2025-10-10T10:00:00Z"`
-	Expenses    []*entities.Expense `json:"expenses"`
+	BillId            string              `json:"billId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AmountPen         float64             `json:"amountPen" example:"95.75"`
+	AmountUsd         float64             `json:"amountUsd" example:"25.50"`
+	AmountOriginal    float64             `json:"amountOriginal" example:"23.40"`
+	ReportingCurrency string              `json:"reportingCurrency" example:"PEN"`
+	AmountReporting   float64             `json:"amountReporting" example:"95.75"`
+	Description       string              `json:"description" example:"Grocery shopping"`
+	Category          string              `json:"category" example:"Food"`
+	CategoryID        string              `json:"categoryId,omitempty" example:"123e4567-e89b-12d3-a456-426614174005"`
+	Currency          string              `json:"currency" example:"USD"`
+	UserID            string              `json:"userId" example:"user_123456789"`
+	WalletID          string              `json:"walletId,omitempty" example:"123e4567-e89b-12d3-a456-426614174008"`
+	PaymentMethodID   string              `json:"paymentMethodId,omitempty" example:"123e4567-e89b-12d3-a456-426614174012"`
+	Date              time.Time           `json:"date" example:"2025-10-10T10:00:00Z"`
+	CreatedAt         time.Time           `json:"createdAt" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt         time.Time           `json:"updatedAt" example:"2025-10-10T10:00:00Z"`
+	Expenses          []*entities.Expense `json:"expenses"`
+	// Source is where the bill was recorded from: web, telegram, recurring or import
+	Source string `json:"source" example:"telegram"`
+	// ReceiptKey locates the original receipt photo, downloadable from GET /bills/{id}/receipt
+	ReceiptKey string `json:"receiptKey,omitempty" example:"receipts/user_123456789/123e4567-e89b-12d3-a456-426614174000.jpg"`
+	// SupplierRUC and InvoiceNumber identify the SUNAT electronic invoice the bill was read from
+	SupplierRUC   string `json:"supplierRuc,omitempty" example:"20100070970"`
+	InvoiceNumber string `json:"invoiceNumber,omitempty" example:"F001-00012345"`
+	// Tax breakdown in the bill's currency, zero when unknown
+	Subtotal  float64 `json:"subtotal" example:"77.97"`
+	TaxAmount float64 `json:"taxAmount" example:"14.03"`
+	TipAmount float64 `json:"tipAmount" example:"9.20"`
+	// StatementReference is the bank's identifier of the statement movement the bill was imported from
+	StatementReference string `json:"statementReference,omitempty" example:"202510100001"`
 }
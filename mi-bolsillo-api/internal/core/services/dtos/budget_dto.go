@@ -0,0 +1,17 @@
+package dtos
+
+// BudgetStatus represents spending against a budget for a given month
+type BudgetStatus struct {
+	BudgetID       string  `json:"budgetId" example:"123e4567-e89b-12d3-a456-426614174002"`
+	Category       string  `json:"category" example:"Food"`
+	Month          string  `json:"month" example:"2025-10"`
+	Currency       string  `json:"currency" example:"PEN"`
+	AmountLimit    float64 `json:"amountLimit" example:"800.00"`
+	Spent          float64 `json:"spent" example:"420.50"`
+	Remaining      float64 `json:"remaining" example:"379.50"`
+	PercentUsed    float64 `json:"percentUsed" example:"52.56"`
+	DaysElapsed    int     `json:"daysElapsed" example:"15"`
+	DaysInMonth    int     `json:"daysInMonth" example:"31"`
+	DailyBurnRate  float64 `json:"dailyBurnRate" example:"28.03"`   // Average spent per elapsed day
+	ProjectedSpend float64 `json:"projectedSpend" example:"869.03"` // Spend at month end at the current burn rate
+}
@@ -0,0 +1,18 @@
+package dtos
+
+// CreateCategoryDTO holds the data to create a category, ParentID makes it a subcategory
+type CreateCategoryDTO struct {
+	ParentID string `json:"parentId"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Color    string `json:"color"`
+}
+
+// UpdateCategoryDTO holds a partial update of a category, nil fields are left unchanged.
+// An empty ParentID moves a subcategory to the top level
+type UpdateCategoryDTO struct {
+	ParentID *string `json:"parentId"`
+	Name     *string `json:"name"`
+	Icon     *string `json:"icon"`
+	Color    *string `json:"color"`
+}
@@ -0,0 +1,30 @@
+package dtos
+
+// CategoryRuleDTO holds the data to create or update a category rule. Category is the ID or name
+// of one of the user's categories
+type CategoryRuleDTO struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Pattern  string `json:"pattern"`
+	Category string `json:"category"`
+	Priority int    `json:"priority"`
+}
+
+// RuleApplyResult reports the bills and expenses the rules recategorized, or would in a dry run
+type RuleApplyResult struct {
+	DryRun          bool         `json:"dryRun" example:"true"`
+	BillsChanged    int          `json:"billsChanged" example:"3"`
+	ExpensesChanged int          `json:"expensesChanged" example:"7"`
+	Changes         []RuleChange `json:"changes"`
+}
+
+// RuleChange is a bill, or an expense of it when ExpenseID is set, moved to another category by a rule
+type RuleChange struct {
+	BillID       string `json:"billId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ExpenseID    string `json:"expenseId,omitempty" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Description  string `json:"description" example:"Uber trip"`
+	RuleID       string `json:"ruleId" example:"123e4567-e89b-12d3-a456-426614174006"`
+	FromCategory string `json:"fromCategory" example:"Other"`
+	ToCategory   string `json:"toCategory" example:"Transportation"`
+	ToCategoryID string `json:"toCategoryId" example:"123e4567-e89b-12d3-a456-426614174005"`
+}
@@ -6,11 +6,32 @@ type CreateBillWithExpensesDTO struct {
 	Description  string                 `json:"description"`
 	Category     string                 `json:"category"`
 	UserID       string                 `json:"userId"`
+	WalletID     string                 `json:"walletId"`
 	Source       string                 `json:"source"`
 	Date         time.Time              `json:"date"`
 	Currency     string                 `json:"currency"`
 	ExchangeRate float64                `json:"exchangeRate"`
 	Expenses     []CreateExpenseForBill `json:"expenses"`
+	// SupplierRUC and InvoiceNumber are set when the bill comes from a SUNAT electronic invoice
+	SupplierRUC   string `json:"supplierRuc"`
+	InvoiceNumber string `json:"invoiceNumber"`
+	// Tax breakdown in the bill's currency, zero when unknown
+	Subtotal  float64 `json:"subtotal"`
+	TaxAmount float64 `json:"taxAmount"`
+	TipAmount float64 `json:"tipAmount"`
+	// ReceiptImage is the photo the bill was parsed from, kept in the blob store when present
+	ReceiptImage []byte `json:"-"`
+	// ReceiptKey attaches a receipt that is already stored, e.g. the photo of a confirmed draft
+	ReceiptKey string `json:"-"`
+	// CategoryGuessed marks the categories as guesses, e.g. the LLM's, which give way to the
+	// category the user last chose for the merchant
+	CategoryGuessed bool `json:"-"`
+	// PaymentMethodID is the account the bill was paid with. Without it, CardLastFour picks the
+	// user's card ending in those digits, e.g. the card printed on a receipt
+	PaymentMethodID string `json:"paymentMethodId"`
+	CardLastFour    string `json:"-"`
+	// StatementReference is the bank's identifier of the imported statement movement
+	StatementReference string `json:"-"`
 }
 
 type CreateExpenseForBill struct {
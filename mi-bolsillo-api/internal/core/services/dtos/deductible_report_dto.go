@@ -0,0 +1,31 @@
+package dtos
+
+// DeductibleReport sums a year's spending that qualifies for SUNAT's additional deduction of
+// up to 3 UIT for individuals. Amounts are in PEN
+type DeductibleReport struct {
+	Year       int                  `json:"year"`
+	Categories []DeductibleCategory `json:"categories"`
+	// TotalDeductible is the sum of the categories before the cap
+	TotalDeductible float64 `json:"totalDeductible"`
+	UIT             float64 `json:"uit"`
+	Cap             float64 `json:"cap"`
+	// Deduction is what can actually be deducted, TotalDeductible limited to Cap
+	Deduction float64 `json:"deduction"`
+}
+
+// DeductibleCategory is one of the expense types SUNAT lets individuals deduct a share of
+type DeductibleCategory struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
+	Rate        float64  `json:"rate"`
+	// Spent only counts bills with the issuer's RUC, SUNAT requires an electronic receipt
+	Spent      float64 `json:"spent"`
+	TaxAmount  float64 `json:"taxAmount"`
+	TipAmount  float64 `json:"tipAmount"`
+	Deductible float64 `json:"deductible"`
+	BillCount  int     `json:"billCount"`
+	// Bills without a RUC aren't deductible until it's added to them
+	SpentWithoutRUC     float64 `json:"spentWithoutRuc"`
+	BillCountWithoutRUC int     `json:"billCountWithoutRuc"`
+}
@@ -0,0 +1,41 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// GoalDTO holds the fields of a savings goal to create or update. On update, zero values keep the goal's
+// current values
+type GoalDTO struct {
+	UserID       string
+	Name         string
+	TargetAmount float64
+	Currency     string
+	Deadline     time.Time
+}
+
+// GoalContributionDTO holds money put towards a goal, in the goal's currency
+type GoalContributionDTO struct {
+	Amount float64
+	Note   string
+	Date   time.Time
+}
+
+// GoalProgress is a savings goal with how far along it is and whether the user's current savings
+// will meet it by its deadline. Amounts are in the goal's currency
+type GoalProgress struct {
+	Goal            *entities.Goal `json:"goal"`
+	RemainingAmount float64        `json:"remainingAmount" example:"1750"`
+	Percentage      float64        `json:"percentage" example:"41.67"`
+	// MonthlySavings is the user's average net savings of the last complete months, the pace the
+	// projection assumes
+	MonthlySavings float64 `json:"monthlySavings" example:"400"`
+	// RequiredMonthly is what's left to save each month to meet the deadline
+	RequiredMonthly float64 `json:"requiredMonthly" example:"218.75"`
+	// ProjectedCompletion is when the goal is met at the current pace, nil when the user isn't saving
+	ProjectedCompletion *time.Time `json:"projectedCompletion,omitempty" example:"2026-03-15T00:00:00Z"`
+	// Status is achieved, on_track, behind or overdue
+	Status string `json:"status" example:"on_track"`
+}
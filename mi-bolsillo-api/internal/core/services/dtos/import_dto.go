@@ -0,0 +1,30 @@
+package dtos
+
+import "github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+
+// ImportResult summarizes a bank statement import
+type ImportResult struct {
+	Imported   []*ImportedTransaction  `json:"imported"`
+	Duplicates []*DuplicateTransaction `json:"duplicates"`
+	Failed     []*FailedTransaction    `json:"failed"`
+	// Skipped counts credits (deposits, refunds), which are not bills
+	Skipped int `json:"skipped" example:"3"`
+}
+
+// ImportedTransaction is a statement charge that was saved as a bill
+type ImportedTransaction struct {
+	Transaction entities.StatementTransaction `json:"transaction"`
+	BillID      string                        `json:"billId" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// DuplicateTransaction is a statement charge that probably matches a bill already recorded
+type DuplicateTransaction struct {
+	Transaction    entities.StatementTransaction `json:"transaction"`
+	ExistingBillID string                        `json:"existingBillId" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// FailedTransaction is a statement charge that could not be saved
+type FailedTransaction struct {
+	Transaction entities.StatementTransaction `json:"transaction"`
+	Error       string                        `json:"error" example:"unsupported currency"`
+}
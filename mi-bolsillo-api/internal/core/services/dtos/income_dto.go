@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+// IncomeDTO holds the fields of an income to create or update. On update, zero values keep the income's
+type IncomeDTO struct {
+	UserID      string
+	Source      string
+	Type        string
+	Description string
+	Amount      float64
+	Currency    string
+	Date        time.Time
+	// ExchangeRate optionally overrides the USD to PEN rate, it's resolved for Date when zero
+	ExchangeRate float64
+}
@@ -0,0 +1,47 @@
+package dtos
+
+import "time"
+
+// PaymentMethodDTO holds the fields of a payment method to create or update. On update, empty
+// strings and nil fields keep the payment method's
+type PaymentMethodDTO struct {
+	UserID   string
+	Name     string
+	Type     string
+	Currency string
+	// LastFour, CreditLimit and StatementClosingDay can be cleared, so they're only changed when set
+	LastFour            *string
+	CreditLimit         *float64
+	StatementClosingDay *int
+}
+
+// StatementCycle is the spending of a credit card billing cycle, from the day after the previous
+// closing day through the cycle's closing day
+type StatementCycle struct {
+	From        time.Time `json:"from" example:"2025-09-21T00:00:00Z"`
+	ClosingDate time.Time `json:"closingDate" example:"2025-10-20T00:00:00Z"`
+	// Total is in the card's currency, TotalPEN and TotalUSD convert each bill at its own date's rate
+	Currency  string  `json:"currency" example:"PEN"`
+	Total     float64 `json:"total" example:"1250.40"`
+	TotalPEN  float64 `json:"totalPen" example:"1250.40"`
+	TotalUSD  float64 `json:"totalUsd" example:"333.44"`
+	BillCount int     `json:"billCount" example:"18"`
+	// Current marks the open cycle, the one today belongs to
+	Current bool `json:"current" example:"true"`
+}
+
+// PaymentMethodStatistics is the spending paid with a payment method over a period
+type PaymentMethodStatistics struct {
+	PaymentMethodID string  `json:"paymentMethodId" example:"123e4567-e89b-12d3-a456-426614174012"`
+	Name            string  `json:"name" example:"Visa BCP"`
+	Type            string  `json:"type" example:"credit_card"`
+	Currency        string  `json:"currency" example:"PEN"`
+	Total           float64 `json:"total" example:"1250.40"`
+	TotalPEN        float64 `json:"totalPen" example:"1250.40"`
+	TotalUSD        float64 `json:"totalUsd" example:"333.44"`
+	BillCount       int     `json:"billCount" example:"18"`
+	// CurrentStatement and AvailableCredit are only set for credit cards with a closing day,
+	// AvailableCredit also needs a credit limit
+	CurrentStatement *StatementCycle `json:"currentStatement,omitempty"`
+	AvailableCredit  *float64        `json:"availableCredit,omitempty" example:"3749.60"`
+}
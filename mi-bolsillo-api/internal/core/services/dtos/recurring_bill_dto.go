@@ -0,0 +1,17 @@
+package dtos
+
+// RecurringBillDTO holds the data to create or update a recurring bill
+type RecurringBillDTO struct {
+	Description string  `json:"description"`
+	Category    string  `json:"category"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Frequency   string  `json:"frequency"`
+	Interval    int     `json:"interval"`
+	DayOfMonth  int     `json:"dayOfMonth"`
+	DayOfWeek   *int    `json:"dayOfWeek"`
+	MonthOfYear int     `json:"monthOfYear"`
+	StartDate   string  `json:"startDate"`
+	EndDate     *string `json:"endDate"`
+	Active      *bool   `json:"active"`
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import "time"
+
+// SplitBillDTO describes how a bill is divided between participants. PaidBy defaults to "me" on
+// personal bills, shared wallet bills must name who paid
+type SplitBillDTO struct {
+	PaidBy       string
+	Participants []SplitParticipantDTO
+}
+
+// SplitParticipantDTO is a participant of a split. Their ExpenseIDs lines are split evenly with the others
+// listing them. The rest of the bill, like tax, tip or unassigned lines, goes by Percentage when any
+// participant has one, otherwise in proportion to each one's lines, or evenly when no line is assigned
+type SplitParticipantDTO struct {
+	Name       string
+	ExpenseIDs []string
+	Percentage float64
+}
+
+// CreateSettlementDTO records a payment between participants, into a shared wallet's ledger when WalletID is set
+type CreateSettlementDTO struct {
+	WalletID string
+	From     string
+	To       string
+	Amount   float64
+	Currency string
+	Note     string
+	Date     time.Time
+}
+
+// LedgerBalances is who owes whom in one currency of a ledger
+type LedgerBalances struct {
+	Currency string               `json:"currency" example:"PEN"`
+	Balances []ParticipantBalance `json:"balances"`
+	// Transfers are the fewest payments that settle every balance
+	Transfers []SettlementTransfer `json:"transfers"`
+}
+
+// ParticipantBalance is a participant's net balance, positive when others owe them
+type ParticipantBalance struct {
+	Participant string  `json:"participant" example:"Ana"`
+	Balance     float64 `json:"balance" example:"-60.00"`
+}
+
+// SettlementTransfer is a payment that settles balances
+type SettlementTransfer struct {
+	From   string  `json:"from" example:"Ana"`
+	To     string  `json:"to" example:"me"`
+	Amount float64 `json:"amount" example:"60.00"`
+}
@@ -10,6 +10,11 @@ type MonthlyStatistics struct {
 	BillCount  int     `json:"billCount"`  // Number of bills
 	Year       int     `json:"year"`       // Year
 	MonthNum   int     `json:"monthNum"`   // Month number (1-12)
+	IncomePEN     float64 `json:"incomePen"`     // Total received in PEN
+	IncomeUSD     float64 `json:"incomeUsd"`     // Total received in USD
+	NetSavingsPEN float64 `json:"netSavingsPen"` // Income minus spending in PEN
+	NetSavingsUSD float64 `json:"netSavingsUsd"` // Income minus spending in USD
+	SavingsRate   float64 `json:"savingsRate"`   // Percentage of income saved, zero without income
 }
 
 // WeeklyStatistics represents spending statistics for a week
@@ -33,10 +38,15 @@ type CategoryStatistics struct {
 
 // DashboardStatistics represents overall dashboard statistics
 type DashboardStatistics struct {
-	MonthlyStats  []MonthlyStatistics  `json:"monthlyStats"`
-	WeeklyStats   []WeeklyStatistics   `json:"weeklyStats"`
-	CategoryStats []CategoryStatistics `json:"categoryStats"`
-	TotalPEN      float64              `json:"totalPen"`
-	TotalUSD      float64              `json:"totalUsd"`
-	TotalBills    int                  `json:"totalBills"`
+	MonthlyStats   []MonthlyStatistics  `json:"monthlyStats"`
+	WeeklyStats    []WeeklyStatistics   `json:"weeklyStats"`
+	CategoryStats  []CategoryStatistics `json:"categoryStats"`
+	TotalPEN       float64              `json:"totalPen"`
+	TotalUSD       float64              `json:"totalUsd"`
+	TotalBills     int                  `json:"totalBills"`
+	TotalIncomePEN float64              `json:"totalIncomePen"`
+	TotalIncomeUSD float64              `json:"totalIncomeUsd"`
+	NetSavingsPEN  float64              `json:"netSavingsPen"` // Total income minus total spending in PEN
+	NetSavingsUSD  float64              `json:"netSavingsUsd"` // Total income minus total spending in USD
+	SavingsRate    float64              `json:"savingsRate"`   // Percentage of total income saved, zero without income
 }
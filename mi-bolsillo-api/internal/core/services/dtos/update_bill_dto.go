@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+// UpdateBillDTO holds the new details of a bill. Zero values keep the current ones
+type UpdateBillDTO struct {
+	Description  string    `json:"description"`
+	Category     string    `json:"category"`
+	Date         time.Time `json:"date"`
+	Currency     string    `json:"currency"`
+	ExchangeRate float64   `json:"exchangeRate"`
+	// The tax details can be cleared, so they're only changed when set
+	SupplierRUC *string  `json:"supplierRuc"`
+	Subtotal    *float64 `json:"subtotal"`
+	TaxAmount   *float64 `json:"taxAmount"`
+	TipAmount   *float64 `json:"tipAmount"`
+	// PaymentMethodID changes the account the bill was paid with, empty unlinks it
+	PaymentMethodID *string `json:"paymentMethodId"`
+}
+
+// UpdateExpenseDTO holds a partial update of an expense, nil fields are left unchanged
+type UpdateExpenseDTO struct {
+	Amount      *float64 `json:"amount"`
+	Description *string  `json:"description"`
+	Category    *string  `json:"category"`
+	Date        *string  `json:"date"`
+}
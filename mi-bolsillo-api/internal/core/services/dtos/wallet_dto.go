@@ -0,0 +1,19 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// WalletResponse is a shared wallet with its members and the role of the user asking
+type WalletResponse struct {
+	WalletID       string                   `json:"walletId" example:"123e4567-e89b-12d3-a456-426614174008"`
+	Name           string                   `json:"name" example:"Casa"`
+	OwnerID        string                   `json:"ownerId" example:"user_123456789"`
+	TelegramChatID int64                    `json:"telegramChatId,omitempty" example:"-1001234567890"`
+	Role           string                   `json:"role" example:"owner"`
+	Members        []*entities.WalletMember `json:"members"`
+	CreatedAt      time.Time                `json:"createdAt" example:"2025-10-10T10:00:00Z"`
+	UpdatedAt      time.Time                `json:"updatedAt" example:"2025-10-10T10:00:00Z"`
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// ExchangeRateService resolves daily exchange rates, caching fetched rates in the repository.
+// It implements the ports.ExchangeRateProvider interface
+type ExchangeRateService struct {
+	rateRepo ports.ExchangeRateRepository
+	fetcher  ports.ExchangeRateFetcher
+}
+
+func NewExchangeRateService(rateRepo ports.ExchangeRateRepository, fetcher ports.ExchangeRateFetcher) *ExchangeRateService {
+	return &ExchangeRateService{
+		rateRepo: rateRepo,
+		fetcher:  fetcher,
+	}
+}
+
+// GetRate returns how many units of quoteCurrency one unit of baseCurrency was worth on date.
+// Pairs without a direct rate are crossed through USD (e.g. EUR -> USD -> PEN)
+func (s *ExchangeRateService) GetRate(baseCurrency string, quoteCurrency string, date time.Time) (float64, error) {
+	if baseCurrency == quoteCurrency {
+		return 1, nil
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	rate, err := s.getDirectRate(baseCurrency, quoteCurrency, date)
+	if !errors.Is(err, ErrExchangeRateNotFound) || baseCurrency == crossCurrency || quoteCurrency == crossCurrency {
+		return rate, err
+	}
+
+	baseToCross, err := s.getDirectRate(baseCurrency, crossCurrency, date)
+	if err != nil {
+		return 0, err
+	}
+	crossToQuote, err := s.getDirectRate(crossCurrency, quoteCurrency, date)
+	if err != nil {
+		return 0, err
+	}
+
+	return baseToCross * crossToQuote, nil
+}
+
+// getDirectRate resolves a single pair: stored rates are used first, then the fetcher,
+// and finally the most recent stored rate before date. Only rates published for the requested
+// day are cached, so an older fallback never gets stored as that day's rate
+func (s *ExchangeRateService) getDirectRate(baseCurrency string, quoteCurrency string, date time.Time) (float64, error) {
+	day := date.Format("2006-01-02")
+
+	// Check the stored rate for that day
+	rate, err := s.findStoredRate(baseCurrency, quoteCurrency, day)
+	if err != nil {
+		return 0, err
+	}
+	if rate > 0 {
+		return rate, nil
+	}
+
+	// Fetch the rate and cache it for next time
+	if s.fetcher != nil {
+		fetched, err := s.fetcher.FetchRate(baseCurrency, quoteCurrency, date)
+		if err == nil {
+			if fetched.Date != day {
+				log.Printf("Warning: no %s/%s rate for %s from %s, using the rate from %s", baseCurrency, quoteCurrency, day, s.fetcher.Name(), fetched.Date)
+				return fetched.Rate, nil
+			}
+			if err := s.SaveRate(baseCurrency, quoteCurrency, date, fetched.Rate, s.fetcher.Name()); err != nil {
+				log.Printf("Failed to cache exchange rate %s/%s for %s: %v", baseCurrency, quoteCurrency, day, err)
+			}
+			return fetched.Rate, nil
+		}
+		log.Printf("Failed to fetch exchange rate %s/%s for %s: %v", baseCurrency, quoteCurrency, day, err)
+	}
+
+	// Fall back to the most recent known rate before the requested date
+	latest, err := s.rateRepo.FindLatestOnOrBefore(baseCurrency, quoteCurrency, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find latest exchange rate: %w", err)
+	}
+	if latest != nil {
+		log.Printf("Warning: no %s/%s rate for %s, using the stored rate from %s", baseCurrency, quoteCurrency, day, latest.Date)
+		return latest.Rate, nil
+	}
+
+	inverse, err := s.rateRepo.FindLatestOnOrBefore(quoteCurrency, baseCurrency, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find latest exchange rate: %w", err)
+	}
+	if inverse != nil && inverse.Rate > 0 {
+		log.Printf("Warning: no %s/%s rate for %s, using the stored rate from %s", baseCurrency, quoteCurrency, day, inverse.Date)
+		return 1 / inverse.Rate, nil
+	}
+
+	return 0, ErrExchangeRateNotFound
+}
+
+// SaveRate stores the rate for a given day, replacing any existing value
+func (s *ExchangeRateService) SaveRate(baseCurrency string, quoteCurrency string, date time.Time, rate float64, source string) error {
+	if rate <= 0 {
+		return ErrInvalidExchangeRate
+	}
+
+	return s.rateRepo.Upsert(&entities.ExchangeRate{
+		Date:          date.Format("2006-01-02"),
+		BaseCurrency:  baseCurrency,
+		QuoteCurrency: quoteCurrency,
+		Rate:          rate,
+		Source:        source,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// findStoredRate looks up the pair for the exact day, also accepting the inverse pair
+func (s *ExchangeRateService) findStoredRate(baseCurrency string, quoteCurrency string, day string) (float64, error) {
+	rate, err := s.rateRepo.FindByDate(baseCurrency, quoteCurrency, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	if rate != nil {
+		return rate.Rate, nil
+	}
+
+	inverse, err := s.rateRepo.FindByDate(quoteCurrency, baseCurrency, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	if inverse != nil && inverse.Rate > 0 {
+		return 1 / inverse.Rate, nil
+	}
+
+	return 0, nil
+}
+
+// crossCurrency is the intermediate currency used when a pair has no direct rate
+const crossCurrency = "USD"
+
+var (
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+	ErrInvalidExchangeRate  = errors.New("exchange rate must be greater than zero")
+)
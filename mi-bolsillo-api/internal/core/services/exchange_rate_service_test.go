@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+// memoryRateRepository keeps rates in memory, keyed by pair and day
+type memoryRateRepository struct {
+	rates map[string]*entities.ExchangeRate
+}
+
+func newMemoryRateRepository() *memoryRateRepository {
+	return &memoryRateRepository{rates: make(map[string]*entities.ExchangeRate)}
+}
+
+func (r *memoryRateRepository) Upsert(rate *entities.ExchangeRate) error {
+	r.rates[rate.BaseCurrency+rate.QuoteCurrency+rate.Date] = rate
+	return nil
+}
+
+func (r *memoryRateRepository) FindByDate(baseCurrency string, quoteCurrency string, date string) (*entities.ExchangeRate, error) {
+	return r.rates[baseCurrency+quoteCurrency+date], nil
+}
+
+func (r *memoryRateRepository) FindLatestOnOrBefore(baseCurrency string, quoteCurrency string, date string) (*entities.ExchangeRate, error) {
+	var latest *entities.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.BaseCurrency == baseCurrency && rate.QuoteCurrency == quoteCurrency && rate.Date <= date && (latest == nil || rate.Date > latest.Date) {
+			latest = rate
+		}
+	}
+	return latest, nil
+}
+
+// stubRateFetcher always answers with the same published rate
+type stubRateFetcher struct {
+	rate entities.ExchangeRate
+}
+
+func (f *stubRateFetcher) FetchRate(baseCurrency string, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error) {
+	rate := f.rate
+	return &rate, nil
+}
+
+func (f *stubRateFetcher) Name() string {
+	return "stub"
+}
+
+func TestGetRateCachesOnlyTheRequestedDay(t *testing.T) {
+	repo := newMemoryRateRepository()
+	fetcher := &stubRateFetcher{rate: entities.ExchangeRate{Date: "2025-10-10", BaseCurrency: "USD", QuoteCurrency: "PEN", Rate: 3.75}}
+	service := NewExchangeRateService(repo, fetcher)
+
+	rate, err := service.GetRate("USD", "PEN", time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC))
+	if err != nil || rate != 3.75 {
+		t.Fatalf("GetRate() = %v, %v, want the earlier rate 3.75", rate, err)
+	}
+	if stored, _ := repo.FindByDate("USD", "PEN", "2025-10-12"); stored != nil {
+		t.Errorf("an earlier day's rate was cached as 2025-10-12: %+v", stored)
+	}
+
+	rate, err = service.GetRate("USD", "PEN", time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil || rate != 3.75 {
+		t.Fatalf("GetRate() = %v, %v, want 3.75", rate, err)
+	}
+	if stored, _ := repo.FindByDate("USD", "PEN", "2025-10-10"); stored == nil || stored.Source != "stub" {
+		t.Errorf("the requested day's rate was not cached: %+v", stored)
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// exportBatchSize is how many bills are loaded per query while streaming an export
+const exportBatchSize = 200
+
+// billExportHeader are the columns of a bill export, bill columns are repeated on every expense row
+var billExportHeader = []interface{}{
+	"Bill ID", "Bill Date", "Bill Description", "Bill Category", "Source", "Currency",
+	"Bill Amount", "Bill Amount PEN", "Bill Amount USD", "Reporting Currency", "Bill Amount Reporting",
+	"Expense ID", "Expense Date", "Expense Description", "Expense Category",
+	"Expense Amount", "Expense Amount PEN", "Expense Amount USD", "Expense Amount Reporting", "Exchange Rate",
+}
+
+// formulaPrefixes start a formula when a cell is opened in a spreadsheet app
+const formulaPrefixes = "=+-@\t\r"
+
+type ExportService struct {
+	billRepo      ports.BillRepository
+	expenseRepo   ports.ExpenseRepository
+	walletService *WalletService
+	formats       map[string]ports.SpreadsheetFormat
+}
+
+func NewExportService(billRepo ports.BillRepository, expenseRepo ports.ExpenseRepository, walletService *WalletService, formats ...ports.SpreadsheetFormat) *ExportService {
+	byName := make(map[string]ports.SpreadsheetFormat, len(formats))
+	for _, format := range formats {
+		byName[format.Name()] = format
+	}
+
+	return &ExportService{
+		billRepo:      billRepo,
+		expenseRepo:   expenseRepo,
+		walletService: walletService,
+		formats:       byName,
+	}
+}
+
+// GetFormat returns the export format with the given name, such as csv or xlsx
+func (s *ExportService) GetFormat(name string) (ports.SpreadsheetFormat, error) {
+	format, ok := s.formats[name]
+	if !ok {
+		return nil, ErrUnsupportedExportFormat
+	}
+	return format, nil
+}
+
+// AuthorizeExport checks that the user can export the bills of the shared wallet, any member can.
+// Personal exports, with an empty walletID, are always allowed
+func (s *ExportService) AuthorizeExport(userID string, walletID string) error {
+	if walletID == "" {
+		return nil
+	}
+	_, _, err := s.walletService.requireRole(walletID, userID, entities.WalletRoleViewer)
+	return err
+}
+
+// ExportBills writes the bills dated within [from, to) with one row per expense, oldest first. Like the
+// bill listing, these are the user's bills or, with walletID, every bill of that shared wallet.
+// Bills are loaded in batches so large histories are streamed instead of held in memory
+func (s *ExportService) ExportBills(userID string, walletID string, format ports.SpreadsheetFormat, from *time.Time, to *time.Time, w io.Writer) error {
+	if err := s.AuthorizeExport(userID, walletID); err != nil {
+		return err
+	}
+
+	writer, err := format.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	if err := writer.WriteRow(billExportHeader); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	criteria := ports.BillSearchCriteria{
+		UserID:    userID,
+		WalletID:  walletID,
+		From:      from,
+		To:        to,
+		SortBy:    ports.BillSortDate,
+		Ascending: true,
+		Limit:     exportBatchSize,
+	}
+
+	for {
+		bills, err := s.billRepo.Search(criteria)
+		if err != nil {
+			return fmt.Errorf("failed to load bills: %w", err)
+		}
+		if len(bills) == 0 {
+			break
+		}
+
+		if err := s.writeBills(writer, bills); err != nil {
+			return err
+		}
+
+		if len(bills) < exportBatchSize {
+			break
+		}
+		last := bills[len(bills)-1]
+		criteria.AfterValue = last.Date
+		criteria.AfterID = last.BillId
+	}
+
+	return writer.Close()
+}
+
+func (s *ExportService) writeBills(writer ports.SpreadsheetWriter, bills []*entities.Bill) error {
+	billIDs := make([]string, len(bills))
+	for i, bill := range bills {
+		billIDs[i] = bill.BillId
+	}
+
+	expenses, err := s.expenseRepo.FindByBillIDs(billIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load expenses: %w", err)
+	}
+
+	expensesByBill := make(map[string][]*entities.Expense, len(bills))
+	for _, expense := range expenses {
+		expensesByBill[expense.BillID] = append(expensesByBill[expense.BillID], expense)
+	}
+
+	for _, bill := range bills {
+		billColumns := []interface{}{
+			bill.BillId, bill.Date.Format("2006-01-02"), spreadsheetText(bill.Description), spreadsheetText(bill.Category), bill.Source, bill.Currency,
+			bill.AmountOriginal, bill.AmountPen, bill.AmountUsd, bill.ReportingCurrency, bill.AmountReporting,
+		}
+
+		billExpenses := expensesByBill[bill.BillId]
+		if len(billExpenses) == 0 {
+			// Keep bills without lines in the export, with empty expense columns
+			row := append(billColumns, make([]interface{}, len(billExportHeader)-len(billColumns))...)
+			if err := writer.WriteRow(row); err != nil {
+				return fmt.Errorf("failed to write export row: %w", err)
+			}
+			continue
+		}
+
+		for _, expense := range billExpenses {
+			row := append(append([]interface{}{}, billColumns...),
+				expense.ExpenseId, expense.Date, spreadsheetText(expense.Description), spreadsheetText(expense.Category),
+				expense.AmountOriginal, expense.AmountPen, expense.AmountUsd, expense.AmountReporting, expense.ExchangeRate,
+			)
+			if err := writer.WriteRow(row); err != nil {
+				return fmt.Errorf("failed to write export row: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// spreadsheetText keeps user text from being read as a formula by prefixing it with a quote
+func spreadsheetText(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
@@ -0,0 +1,23 @@
+package services
+
+import "testing"
+
+func TestSpreadsheetText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Wong", "Wong"},
+		{"", ""},
+		{"=HYPERLINK(\"http://example.com\")", "'=HYPERLINK(\"http://example.com\")"},
+		{"+51 999", "'+51 999"},
+		{"-10 discount", "'-10 discount"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"Caf=e", "Caf=e"},
+	}
+	for _, tt := range tests {
+		if got := spreadsheetText(tt.value); got != tt.want {
+			t.Errorf("spreadsheetText(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+const (
+	// goalSavingsMonths is how many complete months the savings pace of a projection is averaged over
+	goalSavingsMonths = 3
+	// daysPerMonth converts between days and months in projections
+	daysPerMonth = 365.25 / 12
+)
+
+type GoalService struct {
+	goalRepo             ports.GoalRepository
+	statisticsService    *StatisticsService
+	exchangeRateProvider ports.ExchangeRateProvider
+	unitOfWork           ports.UnitOfWork
+}
+
+func NewGoalService(
+	goalRepo ports.GoalRepository,
+	statisticsService *StatisticsService,
+	exchangeRateProvider ports.ExchangeRateProvider,
+	unitOfWork ports.UnitOfWork,
+) *GoalService {
+	return &GoalService{
+		goalRepo:             goalRepo,
+		statisticsService:    statisticsService,
+		exchangeRateProvider: exchangeRateProvider,
+		unitOfWork:           unitOfWork,
+	}
+}
+
+// CreateGoal adds a savings goal. The currency defaults to PEN
+func (s *GoalService) CreateGoal(dto dtos.GoalDTO) (*entities.Goal, error) {
+	if dto.Deadline.IsZero() {
+		return nil, fmt.Errorf("%w: deadline is required", ErrInvalidGoal)
+	}
+
+	now := time.Now()
+	goal := &entities.Goal{
+		GoalID:    uuid.New().String(),
+		UserID:    dto.UserID,
+		Currency:  entities.DefaultReportingCurrency,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := applyGoalDTO(goal, dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.goalRepo.Create(goal); err != nil {
+		return nil, fmt.Errorf("failed to create goal: %w", err)
+	}
+	return goal, nil
+}
+
+// ListGoals returns the progress of the user's goals, the nearest deadline first
+func (s *GoalService) ListGoals(userID string) ([]dtos.GoalProgress, error) {
+	goals, err := s.goalRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch goals: %w", err)
+	}
+	if len(goals) == 0 {
+		return []dtos.GoalProgress{}, nil
+	}
+
+	pace, err := s.newSavingsPace(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dtos.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		progress, err := s.project(goal, pace)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *progress)
+	}
+	return result, nil
+}
+
+// GetGoal returns the progress of a goal owned by the user
+func (s *GoalService) GetGoal(goalID string, userID string) (*dtos.GoalProgress, error) {
+	goal, err := s.getOwnedGoal(goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pace, err := s.newSavingsPace(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.project(goal, pace)
+}
+
+// UpdateGoal updates a goal's details
+func (s *GoalService) UpdateGoal(goalID string, userID string, dto dtos.GoalDTO) (*entities.Goal, error) {
+	goal, err := s.getOwnedGoal(goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Contributions are in the goal's currency, so it's fixed once there are any
+	if dto.Currency != "" && normalizeCurrency(dto.Currency) != goal.Currency {
+		contributions, err := s.goalRepo.FindContributionsByGoalID(goalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch contributions: %w", err)
+		}
+		if len(contributions) > 0 {
+			return nil, fmt.Errorf("%w: currency can't change once the goal has contributions", ErrInvalidGoal)
+		}
+	}
+
+	if err := applyGoalDTO(goal, dto); err != nil {
+		return nil, err
+	}
+
+	goal.UpdatedAt = time.Now()
+	if err := s.goalRepo.Update(goal); err != nil {
+		return nil, fmt.Errorf("failed to update goal: %w", err)
+	}
+	return goal, nil
+}
+
+// DeleteGoal deletes a goal and its contributions
+func (s *GoalService) DeleteGoal(goalID string, userID string) error {
+	if _, err := s.getOwnedGoal(goalID, userID); err != nil {
+		return err
+	}
+
+	err := s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		return repos.Goals.Delete(goalID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+	return nil
+}
+
+// AddContribution records money put towards a goal, or taken from it when negative. The date defaults to now
+func (s *GoalService) AddContribution(goalID string, userID string, dto dtos.GoalContributionDTO) (*entities.GoalContribution, error) {
+	goal, err := s.getOwnedGoal(goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if dto.Amount == 0 {
+		return nil, fmt.Errorf("%w: amount can't be zero", ErrInvalidContribution)
+	}
+	if goal.SavedAmount+dto.Amount < 0 {
+		return nil, fmt.Errorf("%w: can't withdraw more than the %.2f saved", ErrInvalidContribution, goal.SavedAmount)
+	}
+
+	now := time.Now()
+	contribution := &entities.GoalContribution{
+		ContributionID: uuid.New().String(),
+		GoalID:         goal.GoalID,
+		UserID:         userID,
+		Amount:         roundAmount(dto.Amount),
+		Note:           strings.TrimSpace(dto.Note),
+		Date:           dto.Date,
+		CreatedAt:      now,
+	}
+	if contribution.Date.IsZero() {
+		contribution.Date = now
+	}
+
+	if err := s.goalRepo.CreateContribution(contribution); err != nil {
+		return nil, fmt.Errorf("failed to create contribution: %w", err)
+	}
+	return contribution, nil
+}
+
+// ListContributions returns the contributions of a goal owned by the user, the latest first
+func (s *GoalService) ListContributions(goalID string, userID string) ([]*entities.GoalContribution, error) {
+	if _, err := s.getOwnedGoal(goalID, userID); err != nil {
+		return nil, err
+	}
+
+	contributions, err := s.goalRepo.FindContributionsByGoalID(goalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contributions: %w", err)
+	}
+	return contributions, nil
+}
+
+// DeleteContribution deletes a contribution of a goal owned by the user
+func (s *GoalService) DeleteContribution(goalID string, contributionID string, userID string) error {
+	if _, err := s.getOwnedGoal(goalID, userID); err != nil {
+		return err
+	}
+
+	contribution, err := s.goalRepo.FindContributionByID(contributionID)
+	if err != nil {
+		return fmt.Errorf("failed to find contribution: %w", err)
+	}
+	if contribution == nil || contribution.GoalID != goalID {
+		return ErrContributionNotFound
+	}
+	return s.goalRepo.DeleteContribution(contributionID)
+}
+
+// savingsPace is the user's average monthly net savings per currency they were recorded in,
+// converted to each goal's currency on demand
+type savingsPace struct {
+	byCurrency map[string]float64
+}
+
+func (s *GoalService) newSavingsPace(userID string) (*savingsPace, error) {
+	savings, err := s.statisticsService.GetAverageMonthlySavings(userID, goalSavingsMonths)
+	if err != nil {
+		return nil, err
+	}
+	return &savingsPace{byCurrency: savings}, nil
+}
+
+// paceIn returns the monthly savings in a currency, adding up the savings of every currency
+// converted at today's rate, e.g. soles saved plus dollars saved for a goal in soles
+func (s *GoalService) paceIn(pace *savingsPace, currency string) (float64, error) {
+	var total float64
+	for from, amount := range pace.byCurrency {
+		if from == currency || amount == 0 {
+			total += amount
+			continue
+		}
+
+		rate, err := s.exchangeRateProvider.GetRate(from, currency, time.Now())
+		if err != nil {
+			return 0, fmt.Errorf("failed to resolve %s/%s rate: %w", from, currency, err)
+		}
+		total += amount * rate
+	}
+	return total, nil
+}
+
+// project works out a goal's progress and whether saving at the user's current pace meets it by the deadline.
+// The whole pace is assumed to go to the goal
+func (s *GoalService) project(goal *entities.Goal, pace *savingsPace) (*dtos.GoalProgress, error) {
+	monthlySavings, err := s.paceIn(pace, goal.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	remaining := math.Max(goal.TargetAmount-goal.SavedAmount, 0)
+	progress := &dtos.GoalProgress{
+		Goal:            goal,
+		RemainingAmount: roundAmount(remaining),
+		Percentage:      roundAmount(goal.SavedAmount / goal.TargetAmount * 100),
+		MonthlySavings:  roundAmount(monthlySavings),
+	}
+
+	if remaining == 0 {
+		progress.Status = entities.GoalStatusAchieved
+		return progress, nil
+	}
+
+	if monthlySavings > 0 {
+		completion := now.AddDate(0, 0, int(math.Ceil(remaining/monthlySavings*daysPerMonth)))
+		progress.ProjectedCompletion = &completion
+	}
+
+	monthsLeft := goal.Deadline.Sub(now).Hours() / 24 / daysPerMonth
+	switch {
+	case monthsLeft <= 0:
+		progress.Status = entities.GoalStatusOverdue
+		progress.RequiredMonthly = roundAmount(remaining)
+	case progress.ProjectedCompletion != nil && !progress.ProjectedCompletion.After(goal.Deadline):
+		progress.Status = entities.GoalStatusOnTrack
+		progress.RequiredMonthly = roundAmount(remaining / math.Max(monthsLeft, 1))
+	default:
+		progress.Status = entities.GoalStatusBehind
+		progress.RequiredMonthly = roundAmount(remaining / math.Max(monthsLeft, 1))
+	}
+	return progress, nil
+}
+
+func (s *GoalService) getOwnedGoal(goalID string, userID string) (*entities.Goal, error) {
+	goal, err := s.goalRepo.FindByID(goalID)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, ErrGoalNotFound
+	}
+	if goal.UserID != userID {
+		return nil, ErrUnauthorizedGoal
+	}
+	return goal, nil
+}
+
+// applyGoalDTO validates the DTO and sets its fields on the goal
+func applyGoalDTO(goal *entities.Goal, dto dtos.GoalDTO) error {
+	if name := strings.TrimSpace(dto.Name); name != "" {
+		goal.Name = name
+	}
+	if goal.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidGoal)
+	}
+
+	if dto.TargetAmount < 0 {
+		return fmt.Errorf("%w: targetAmount must be positive", ErrInvalidGoal)
+	}
+	if dto.TargetAmount > 0 {
+		goal.TargetAmount = roundAmount(dto.TargetAmount)
+	}
+	if goal.TargetAmount <= 0 {
+		return fmt.Errorf("%w: targetAmount must be positive", ErrInvalidGoal)
+	}
+
+	if dto.Currency != "" {
+		currency := normalizeCurrency(dto.Currency)
+		if !isValidCurrency(currency) {
+			return ErrUnsupportedCurrency
+		}
+		goal.Currency = currency
+	}
+
+	if !dto.Deadline.IsZero() {
+		goal.Deadline = dto.Deadline
+	}
+	return nil
+}
+
+var (
+	ErrInvalidGoal          = errors.New("invalid goal")
+	ErrGoalNotFound         = errors.New("goal not found")
+	ErrUnauthorizedGoal     = errors.New("unauthorized access to goal")
+	ErrInvalidContribution  = errors.New("invalid contribution")
+	ErrContributionNotFound = errors.New("contribution not found")
+)
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fixedRates converts with a table of rates keyed by "BASE/QUOTE"
+type fixedRates map[string]float64
+
+func (r fixedRates) GetRate(baseCurrency string, quoteCurrency string, date time.Time) (float64, error) {
+	rate, ok := r[baseCurrency+"/"+quoteCurrency]
+	if !ok {
+		return 0, ErrExchangeRateNotFound
+	}
+	return rate, nil
+}
+
+func TestPaceInAddsTheSavingsOfEveryCurrency(t *testing.T) {
+	service := &GoalService{exchangeRateProvider: fixedRates{
+		"USD/PEN": 3.5,
+		"PEN/USD": 0.25,
+		"PEN/EUR": 0.25,
+		"USD/EUR": 0.9,
+	}}
+	pace := &savingsPace{byCurrency: map[string]float64{"PEN": 1000, "USD": 100}}
+
+	tests := []struct {
+		currency string
+		want     float64
+	}{
+		{"PEN", 1350},
+		{"USD", 350},
+		{"EUR", 340},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			got, err := service.paceIn(pace, tt.currency)
+			if err != nil {
+				t.Fatalf("paceIn() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("paceIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+const (
+	// duplicateAmountTolerance and duplicateDayWindow define how close an existing bill must be
+	// to a statement charge to be considered the same purchase
+	duplicateAmountTolerance = 0.01
+	duplicateDayWindow       = 2
+)
+
+// statementNoiseWords are words banks add to card movements that say nothing about the merchant
+var statementNoiseWords = map[string]bool{
+	"compra": true, "pago": true, "cargo": true, "consumo": true, "pos": true, "visa": true,
+	"mastercard": true, "debito": true, "credito": true, "tarjeta": true, "lima": true, "per": true, "peru": true,
+}
+
+type ImportService struct {
+	billRepo                ports.BillRepository
+	billWithExpensesService *BillWithExpensesService
+	parsers                 map[string]ports.StatementParser
+}
+
+func NewImportService(billRepo ports.BillRepository, billWithExpensesService *BillWithExpensesService, parsers map[string]ports.StatementParser) *ImportService {
+	return &ImportService{
+		billRepo:                billRepo,
+		billWithExpensesService: billWithExpensesService,
+		parsers:                 parsers,
+	}
+}
+
+// Formats returns the names of the supported statement formats
+func (s *ImportService) Formats() []string {
+	names := make([]string, 0, len(s.parsers))
+	for name := range s.parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ImportStatement parses a bank statement and saves each charge as a bill with source "import".
+// Charges already imported with the same bank reference (the OFX FITID), or that probably match a
+// bill already recorded (same amount ±0.01, date ±2 days and a similar description), are reported
+// as duplicates instead of being saved
+func (s *ImportService) ImportStatement(userID string, format string, r io.Reader) (*dtos.ImportResult, error) {
+	parser, ok := s.parsers[strings.ToLower(format)]
+	if !ok {
+		return nil, ErrUnsupportedStatementFormat
+	}
+
+	transactions, err := parser.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	result := &dtos.ImportResult{
+		Imported:   []*dtos.ImportedTransaction{},
+		Duplicates: []*dtos.DuplicateTransaction{},
+		Failed:     []*dtos.FailedTransaction{},
+	}
+
+	for _, transaction := range transactions {
+		if transaction.Amount >= 0 {
+			result.Skipped++
+			continue
+		}
+
+		duplicate, err := s.findDuplicate(userID, transaction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look for duplicates: %w", err)
+		}
+		if duplicate != nil {
+			result.Duplicates = append(result.Duplicates, &dtos.DuplicateTransaction{
+				Transaction:    transaction,
+				ExistingBillID: duplicate.BillId,
+			})
+			continue
+		}
+
+		bill, _, err := s.billWithExpensesService.CreateBillWithExpenses(dtos.CreateBillWithExpensesDTO{
+			Description: transaction.Description,
+			Category:    "General",
+			UserID:      userID,
+			Source:      "import",
+			Date:        transaction.Date,
+			Currency:    transaction.Currency,
+			Expenses: []dtos.CreateExpenseForBill{
+				{
+					Amount:      -transaction.Amount,
+					Description: transaction.Description,
+					Category:    "General",
+					Date:        transaction.Date.Format("2006-01-02"),
+				},
+			},
+			CategoryGuessed:    true,
+			StatementReference: transaction.Reference,
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, &dtos.FailedTransaction{
+				Transaction: transaction,
+				Error:       err.Error(),
+			})
+			continue
+		}
+
+		result.Imported = append(result.Imported, &dtos.ImportedTransaction{
+			Transaction: transaction,
+			BillID:      bill.BillId,
+		})
+	}
+
+	return result, nil
+}
+
+// findDuplicate returns an existing bill that probably records the same purchase as the charge.
+// A charge with a bank reference is the same as the bill imported with it, and is otherwise only
+// compared with bills that weren't imported with a reference, so two identical charges on the same
+// day both get imported
+func (s *ImportService) findDuplicate(userID string, transaction entities.StatementTransaction) (*entities.Bill, error) {
+	if transaction.Reference != "" {
+		imported, err := s.billRepo.FindByStatementReference(userID, transaction.Reference)
+		if err != nil || imported != nil {
+			return imported, err
+		}
+	}
+
+	day := transaction.Date
+	from := day.AddDate(0, 0, -duplicateDayWindow)
+	to := day.AddDate(0, 0, duplicateDayWindow+1)
+
+	candidates, err := s.billRepo.Search(ports.BillSearchCriteria{
+		UserID:   userID,
+		From:     &from,
+		To:       &to,
+		Currency: normalizeCurrency(transaction.Currency),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	amount := -transaction.Amount
+	for _, candidate := range candidates {
+		if transaction.Reference != "" && candidate.StatementReference != "" {
+			continue
+		}
+		if math.Abs(candidate.AmountOriginal-amount) > duplicateAmountTolerance+1e-9 {
+			continue
+		}
+		if similarDescriptions(candidate.Description, transaction.Description) {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// similarDescriptions reports whether a receipt description and a bank movement likely name the
+// same merchant, e.g. "Wong" and "COMPRA WONG SAN ISIDRO"
+func similarDescriptions(a string, b string) bool {
+	wordsA := descriptionWords(a)
+	wordsB := descriptionWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return false
+	}
+
+	// Banks often glue words together, e.g. "TOTTUSSANMIGUEL", so a word contained in another matches too
+	for wordA := range wordsA {
+		for wordB := range wordsB {
+			if strings.Contains(wordA, wordB) || strings.Contains(wordB, wordA) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// descriptionWords returns the meaningful lowercase words of a description
+func descriptionWords(description string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make(map[string]bool, len(words))
+	for _, word := range words {
+		if len([]rune(word)) < 3 || statementNoiseWords[word] {
+			continue
+		}
+		result[word] = true
+	}
+	return result
+}
+
+var (
+	ErrUnsupportedStatementFormat = errors.New("unsupported statement format")
+	ErrInvalidStatement           = errors.New("invalid statement")
+)
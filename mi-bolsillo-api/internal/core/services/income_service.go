@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+// incomeTypes are the accepted income types
+var incomeTypes = map[string]bool{
+	entities.IncomeTypeSalary:    true,
+	entities.IncomeTypeFreelance: true,
+	entities.IncomeTypeRefund:    true,
+	entities.IncomeTypeOther:     true,
+}
+
+type IncomeService struct {
+	incomeRepo           ports.IncomeRepository
+	userRepo             ports.UserRepository
+	exchangeRateProvider ports.ExchangeRateProvider
+}
+
+func NewIncomeService(
+	incomeRepo ports.IncomeRepository,
+	userRepo ports.UserRepository,
+	exchangeRateProvider ports.ExchangeRateProvider,
+) *IncomeService {
+	return &IncomeService{
+		incomeRepo:           incomeRepo,
+		userRepo:             userRepo,
+		exchangeRateProvider: exchangeRateProvider,
+	}
+}
+
+// CreateIncome records money the user received. The type defaults to other and the date to now
+func (s *IncomeService) CreateIncome(dto dtos.IncomeDTO) (*entities.Income, error) {
+	incomeType, err := validateIncomeType(dto.Type)
+	if err != nil {
+		return nil, err
+	}
+	if dto.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidIncome)
+	}
+
+	now := time.Now()
+	income := &entities.Income{
+		IncomeID:       uuid.New().String(),
+		UserID:         dto.UserID,
+		Type:           incomeType,
+		Description:    strings.TrimSpace(dto.Description),
+		AmountOriginal: dto.Amount,
+		Currency:       normalizeCurrency(dto.Currency),
+		Source:         dto.Source,
+		Date:           dto.Date,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if income.Source == "" {
+		income.Source = "web"
+	}
+	if income.Date.IsZero() {
+		income.Date = now
+	}
+
+	if err := s.convertIncome(income, dto.ExchangeRate); err != nil {
+		return nil, err
+	}
+
+	if err := s.incomeRepo.Create(income); err != nil {
+		return nil, fmt.Errorf("failed to create income: %w", err)
+	}
+	return income, nil
+}
+
+// ListIncomes returns the user's incomes from from and before to, the latest first. Nil bounds are open
+func (s *IncomeService) ListIncomes(userID string, from *time.Time, to *time.Time) ([]*entities.Income, error) {
+	incomes, err := s.incomeRepo.FindByUserID(userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch incomes: %w", err)
+	}
+	return incomes, nil
+}
+
+// GetIncome returns an income owned by the user
+func (s *IncomeService) GetIncome(incomeID string, userID string) (*entities.Income, error) {
+	return s.getOwnedIncome(incomeID, userID)
+}
+
+// UpdateIncome updates an income's details. Changing the amount, currency, date or exchange rate
+// reconverts it
+func (s *IncomeService) UpdateIncome(incomeID string, userID string, dto dtos.IncomeDTO) (*entities.Income, error) {
+	income, err := s.getOwnedIncome(incomeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Type != "" {
+		if income.Type, err = validateIncomeType(dto.Type); err != nil {
+			return nil, err
+		}
+	}
+	if dto.Description != "" {
+		income.Description = strings.TrimSpace(dto.Description)
+	}
+	if dto.Amount < 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidIncome)
+	}
+
+	reconvert := dto.ExchangeRate > 0
+	if dto.Amount > 0 && dto.Amount != income.AmountOriginal {
+		income.AmountOriginal = dto.Amount
+		reconvert = true
+	}
+	if dto.Currency != "" && normalizeCurrency(dto.Currency) != income.Currency {
+		income.Currency = normalizeCurrency(dto.Currency)
+		reconvert = true
+	}
+	if !dto.Date.IsZero() && !dto.Date.Equal(income.Date) {
+		income.Date = dto.Date
+		reconvert = true
+	}
+
+	if reconvert {
+		if err := s.convertIncome(income, dto.ExchangeRate); err != nil {
+			return nil, err
+		}
+	}
+
+	income.UpdatedAt = time.Now()
+	if err := s.incomeRepo.Update(income); err != nil {
+		return nil, fmt.Errorf("failed to update income: %w", err)
+	}
+	return income, nil
+}
+
+// DeleteIncome deletes an income owned by the user
+func (s *IncomeService) DeleteIncome(incomeID string, userID string) error {
+	if _, err := s.getOwnedIncome(incomeID, userID); err != nil {
+		return err
+	}
+	return s.incomeRepo.Delete(incomeID)
+}
+
+// convertIncome fills the PEN, USD and reporting amounts of an income for its currency and date
+func (s *IncomeService) convertIncome(income *entities.Income, usdToPen float64) error {
+	if !isValidCurrency(income.Currency) {
+		return ErrUnsupportedCurrency
+	}
+
+	reportingCurrency := entities.DefaultReportingCurrency
+	user, err := s.userRepo.FindByID(income.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user != nil && user.ReportingCurrency != "" {
+		reportingCurrency = user.ReportingCurrency
+	}
+
+	rates, err := resolveConversionRates(s.exchangeRateProvider, income.Currency, reportingCurrency, income.Date, usdToPen)
+	if err != nil {
+		if errors.Is(err, ErrExchangeRateNotFound) {
+			return fmt.Errorf("%w: %v", ErrUnsupportedCurrency, err)
+		}
+		return fmt.Errorf("failed to resolve exchange rates: %w", err)
+	}
+
+	income.AmountPen = income.AmountOriginal * rates.toPen
+	income.AmountUsd = income.AmountOriginal * rates.toUsd
+	income.ReportingCurrency = reportingCurrency
+	income.AmountReporting = income.AmountOriginal * rates.toReporting
+	income.ExchangeRate = rates.usdToPen
+	return nil
+}
+
+func (s *IncomeService) getOwnedIncome(incomeID string, userID string) (*entities.Income, error) {
+	income, err := s.incomeRepo.FindByID(incomeID)
+	if err != nil {
+		return nil, err
+	}
+	if income == nil {
+		return nil, ErrIncomeNotFound
+	}
+	if income.UserID != userID {
+		return nil, ErrUnauthorizedIncome
+	}
+	return income, nil
+}
+
+func validateIncomeType(incomeType string) (string, error) {
+	incomeType = strings.ToLower(strings.TrimSpace(incomeType))
+	if incomeType == "" {
+		return entities.IncomeTypeOther, nil
+	}
+	if !incomeTypes[incomeType] {
+		return "", fmt.Errorf("%w: type must be salary, freelance, refund or other", ErrInvalidIncome)
+	}
+	return incomeType, nil
+}
+
+var (
+	ErrInvalidIncome      = errors.New("invalid income")
+	ErrIncomeNotFound     = errors.New("income not found")
+	ErrUnauthorizedIncome = errors.New("unauthorized access to income")
+)
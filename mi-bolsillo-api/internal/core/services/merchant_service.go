@@ -0,0 +1,196 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// minMerchantPrefixLength keeps short words like "la" or "el" from matching every merchant starting with them
+const minMerchantPrefixLength = 4
+
+// merchantLegalSuffixes are company types dropped from merchant names, "Supermercados Peruanos S.A.C."
+// and "Supermercados Peruanos" are the same store
+var merchantLegalSuffixes = map[string]bool{
+	"sac":  true,
+	"saa":  true,
+	"sa":   true,
+	"eirl": true,
+	"srl":  true,
+	"sacs": true,
+	"ltda": true,
+	"inc":  true,
+	"llc":  true,
+	"corp": true,
+}
+
+type MerchantService struct {
+	merchantRepo ports.MerchantRepository
+	unitOfWork   ports.UnitOfWork
+}
+
+func NewMerchantService(merchantRepo ports.MerchantRepository, unitOfWork ports.UnitOfWork) *MerchantService {
+	return &MerchantService{
+		merchantRepo: merchantRepo,
+		unitOfWork:   unitOfWork,
+	}
+}
+
+// FindMerchant returns the user's merchant a name refers to, or nil if the user never bought there
+func (s *MerchantService) FindMerchant(userID string, name string) (*entities.Merchant, error) {
+	key := merchantKey(name)
+	if key == "" {
+		return nil, nil
+	}
+
+	merchants, aliases, err := s.loadMerchants(userID)
+	if err != nil {
+		return nil, err
+	}
+	merchant, _ := matchMerchant(merchants, aliases, key)
+	return merchant, nil
+}
+
+// RememberMerchant records a name the user bought from, collapsing it into the merchant it refers to.
+// A non-empty categoryID becomes the merchant's preferred category. Returns nil for names that
+// normalize to nothing
+func (s *MerchantService) RememberMerchant(userID string, name string, categoryID string) (*entities.Merchant, error) {
+	key := merchantKey(name)
+	if key == "" {
+		return nil, nil
+	}
+
+	merchants, aliases, err := s.loadMerchants(userID)
+	if err != nil {
+		return nil, err
+	}
+	merchant, known := matchMerchant(merchants, aliases, key)
+
+	now := time.Now()
+	created, changed := false, false
+	if merchant == nil {
+		merchant = &entities.Merchant{
+			MerchantID: uuid.New().String(),
+			UserID:     userID,
+			Name:       strings.Join(strings.Fields(name), " "),
+			Key:        key,
+			CategoryID: categoryID,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		created = true
+	} else {
+		// The shortest name seen is the canonical one, "Wong" rather than "WONG SAN ISIDRO"
+		if len(key) < len(merchant.Key) {
+			merchant.Name, merchant.Key = strings.Join(strings.Fields(name), " "), key
+			changed = true
+		}
+		if categoryID != "" && categoryID != merchant.CategoryID {
+			merchant.CategoryID = categoryID
+			changed = true
+		}
+	}
+	if known && !created && !changed {
+		return merchant, nil
+	}
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if created {
+			if err := repos.Merchants.Create(merchant); err != nil {
+				return err
+			}
+		} else if changed {
+			merchant.UpdatedAt = now
+			if err := repos.Merchants.Update(merchant); err != nil {
+				return err
+			}
+		}
+		if known {
+			return nil
+		}
+		return repos.Merchants.CreateAlias(&entities.MerchantAlias{
+			UserID:     userID,
+			Alias:      key,
+			MerchantID: merchant.MerchantID,
+			CreatedAt:  now,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save merchant: %w", err)
+	}
+	return merchant, nil
+}
+
+func (s *MerchantService) loadMerchants(userID string) ([]*entities.Merchant, []*entities.MerchantAlias, error) {
+	merchants, err := s.merchantRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch merchants: %w", err)
+	}
+	aliases, err := s.merchantRepo.FindAliasesByUserID(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch merchant aliases: %w", err)
+	}
+	return merchants, aliases, nil
+}
+
+// matchMerchant returns the merchant a normalized name refers to and whether the name is already one of
+// its aliases. Otherwise a name that starts with a known one or the other way round matches, e.g. "wong"
+// and "wong san isidro", preferring the longest name they share
+func matchMerchant(merchants []*entities.Merchant, aliases []*entities.MerchantAlias, key string) (*entities.Merchant, bool) {
+	byID := make(map[string]*entities.Merchant, len(merchants))
+	for _, merchant := range merchants {
+		byID[merchant.MerchantID] = merchant
+	}
+
+	var best *entities.Merchant
+	bestLength := 0
+	for _, alias := range aliases {
+		merchant := byID[alias.MerchantID]
+		if merchant == nil {
+			continue
+		}
+		if alias.Alias == key {
+			return merchant, true
+		}
+
+		shared := 0
+		switch {
+		case merchantKeyHasPrefix(key, alias.Alias):
+			shared = len(alias.Alias)
+		case merchantKeyHasPrefix(alias.Alias, key):
+			shared = len(key)
+		}
+		if shared >= minMerchantPrefixLength && shared > bestLength {
+			best, bestLength = merchant, shared
+		}
+	}
+	return best, false
+}
+
+// merchantKeyHasPrefix reports whether key starts with the whole words of prefix
+func merchantKeyHasPrefix(key string, prefix string) bool {
+	return strings.HasPrefix(key, prefix+" ")
+}
+
+// merchantKey normalizes a merchant name to compare it: lower case without accents, punctuation,
+// company types or store numbers
+func merchantKey(name string) string {
+	key := categoryKey(strings.ReplaceAll(name, ".", ""))
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := words[:0]
+	for _, word := range words {
+		if merchantLegalSuffixes[word] || strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+			continue
+		}
+		kept = append(kept, word)
+	}
+	return strings.Join(kept, " ")
+}
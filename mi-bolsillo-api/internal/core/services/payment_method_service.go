@@ -0,0 +1,418 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultStatementCount = 6
+	maxStatementCount     = 24
+)
+
+// paymentMethodTypes are the accepted payment method types
+var paymentMethodTypes = map[string]bool{
+	entities.PaymentMethodCreditCard:  true,
+	entities.PaymentMethodDebitCard:   true,
+	entities.PaymentMethodYape:        true,
+	entities.PaymentMethodPlin:        true,
+	entities.PaymentMethodCash:        true,
+	entities.PaymentMethodBankAccount: true,
+}
+
+type PaymentMethodService struct {
+	paymentMethodRepo    ports.PaymentMethodRepository
+	billRepo             ports.BillRepository
+	exchangeRateProvider ports.ExchangeRateProvider
+	unitOfWork           ports.UnitOfWork
+}
+
+func NewPaymentMethodService(
+	paymentMethodRepo ports.PaymentMethodRepository,
+	billRepo ports.BillRepository,
+	exchangeRateProvider ports.ExchangeRateProvider,
+	unitOfWork ports.UnitOfWork,
+) *PaymentMethodService {
+	return &PaymentMethodService{
+		paymentMethodRepo:    paymentMethodRepo,
+		billRepo:             billRepo,
+		exchangeRateProvider: exchangeRateProvider,
+		unitOfWork:           unitOfWork,
+	}
+}
+
+// CreatePaymentMethod adds an account the user pays with. The currency defaults to PEN
+func (s *PaymentMethodService) CreatePaymentMethod(dto dtos.PaymentMethodDTO) (*entities.PaymentMethod, error) {
+	now := time.Now()
+	paymentMethod := &entities.PaymentMethod{
+		PaymentMethodID: uuid.New().String(),
+		UserID:          dto.UserID,
+		Currency:        entities.DefaultReportingCurrency,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.applyPaymentMethodDTO(paymentMethod, dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentMethodRepo.Create(paymentMethod); err != nil {
+		return nil, fmt.Errorf("failed to create payment method: %w", err)
+	}
+	return paymentMethod, nil
+}
+
+// ListPaymentMethods returns the user's payment methods by name
+func (s *PaymentMethodService) ListPaymentMethods(userID string) ([]*entities.PaymentMethod, error) {
+	paymentMethods, err := s.paymentMethodRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payment methods: %w", err)
+	}
+	return paymentMethods, nil
+}
+
+// GetPaymentMethod returns a payment method owned by the user
+func (s *PaymentMethodService) GetPaymentMethod(paymentMethodID string, userID string) (*entities.PaymentMethod, error) {
+	return s.getOwnedPaymentMethod(paymentMethodID, userID)
+}
+
+// UpdatePaymentMethod updates a payment method's details
+func (s *PaymentMethodService) UpdatePaymentMethod(paymentMethodID string, userID string, dto dtos.PaymentMethodDTO) (*entities.PaymentMethod, error) {
+	paymentMethod, err := s.getOwnedPaymentMethod(paymentMethodID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyPaymentMethodDTO(paymentMethod, dto); err != nil {
+		return nil, err
+	}
+
+	paymentMethod.UpdatedAt = time.Now()
+	if err := s.paymentMethodRepo.Update(paymentMethod); err != nil {
+		return nil, fmt.Errorf("failed to update payment method: %w", err)
+	}
+	return paymentMethod, nil
+}
+
+// DeletePaymentMethod deletes a payment method, the bills paid with it are kept without one
+func (s *PaymentMethodService) DeletePaymentMethod(paymentMethodID string, userID string) error {
+	if _, err := s.getOwnedPaymentMethod(paymentMethodID, userID); err != nil {
+		return err
+	}
+
+	err := s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		return repos.PaymentMethods.Delete(paymentMethodID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete payment method: %w", err)
+	}
+	return nil
+}
+
+// GetStatistics returns what the user paid with each payment method from from and before to,
+// along with the open statement of their credit cards. Nil bounds are open
+func (s *PaymentMethodService) GetStatistics(userID string, from *time.Time, to *time.Time) ([]dtos.PaymentMethodStatistics, error) {
+	paymentMethods, err := s.ListPaymentMethods(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]dtos.PaymentMethodStatistics, 0, len(paymentMethods))
+	for _, paymentMethod := range paymentMethods {
+		bills, err := s.findBills(paymentMethod, from, to)
+		if err != nil {
+			return nil, err
+		}
+		total, totalPEN, totalUSD, err := s.sumBills(bills, paymentMethod.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		stats := dtos.PaymentMethodStatistics{
+			PaymentMethodID: paymentMethod.PaymentMethodID,
+			Name:            paymentMethod.Name,
+			Type:            paymentMethod.Type,
+			Currency:        paymentMethod.Currency,
+			Total:           roundAmount(total),
+			TotalPEN:        roundAmount(totalPEN),
+			TotalUSD:        roundAmount(totalUSD),
+			BillCount:       len(bills),
+		}
+
+		if hasStatements(paymentMethod) {
+			cycle, err := s.statementCycle(paymentMethod, currentClosingDate(paymentMethod.StatementClosingDay, now))
+			if err != nil {
+				return nil, err
+			}
+			cycle.Current = true
+			stats.CurrentStatement = cycle
+			if paymentMethod.CreditLimit > 0 {
+				available := roundAmount(paymentMethod.CreditLimit - cycle.Total)
+				stats.AvailableCredit = &available
+			}
+		}
+
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+// GetStatements returns the totals of a credit card's last count billing cycles, the open one first
+func (s *PaymentMethodService) GetStatements(paymentMethodID string, userID string, count int) ([]dtos.StatementCycle, error) {
+	paymentMethod, err := s.getOwnedPaymentMethod(paymentMethodID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasStatements(paymentMethod) {
+		return nil, ErrNoStatements
+	}
+
+	if count <= 0 {
+		count = defaultStatementCount
+	}
+	if count > maxStatementCount {
+		count = maxStatementCount
+	}
+
+	closingDate := currentClosingDate(paymentMethod.StatementClosingDay, time.Now())
+	cycles := make([]dtos.StatementCycle, 0, count)
+	for i := 0; i < count; i++ {
+		cycle, err := s.statementCycle(paymentMethod, closingDate)
+		if err != nil {
+			return nil, err
+		}
+		cycle.Current = i == 0
+		cycles = append(cycles, *cycle)
+		closingDate = previousClosingDate(paymentMethod.StatementClosingDay, closingDate)
+	}
+
+	return cycles, nil
+}
+
+// resolvePaymentMethod returns the ID of the payment method a bill was paid with: the one given,
+// which must be the user's, or else the user's card ending in lastFour. It's empty when neither matches
+func (s *PaymentMethodService) resolvePaymentMethod(userID string, paymentMethodID string, lastFour string) (string, error) {
+	if paymentMethodID = strings.TrimSpace(paymentMethodID); paymentMethodID != "" {
+		if _, err := s.getOwnedPaymentMethod(paymentMethodID, userID); err != nil {
+			return "", err
+		}
+		return paymentMethodID, nil
+	}
+
+	lastFour = strings.TrimSpace(lastFour)
+	if len(lastFour) != 4 {
+		return "", nil
+	}
+	paymentMethods, err := s.ListPaymentMethods(userID)
+	if err != nil {
+		return "", err
+	}
+	for _, paymentMethod := range paymentMethods {
+		if paymentMethod.LastFour == lastFour {
+			return paymentMethod.PaymentMethodID, nil
+		}
+	}
+	return "", nil
+}
+
+// statementCycle sums the bills of the billing cycle that closes on closingDate
+func (s *PaymentMethodService) statementCycle(paymentMethod *entities.PaymentMethod, closingDate time.Time) (*dtos.StatementCycle, error) {
+	from := previousClosingDate(paymentMethod.StatementClosingDay, closingDate).AddDate(0, 0, 1)
+	end := closingDate.AddDate(0, 0, 1)
+
+	bills, err := s.findBills(paymentMethod, &from, &end)
+	if err != nil {
+		return nil, err
+	}
+	total, totalPEN, totalUSD, err := s.sumBills(bills, paymentMethod.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.StatementCycle{
+		From:        from,
+		ClosingDate: closingDate,
+		Currency:    paymentMethod.Currency,
+		Total:       roundAmount(total),
+		TotalPEN:    roundAmount(totalPEN),
+		TotalUSD:    roundAmount(totalUSD),
+		BillCount:   len(bills),
+	}, nil
+}
+
+// findBills returns the user's bills paid with the payment method from from and before to
+func (s *PaymentMethodService) findBills(paymentMethod *entities.PaymentMethod, from *time.Time, to *time.Time) ([]*entities.Bill, error) {
+	bills, err := s.billRepo.Search(ports.BillSearchCriteria{
+		UserID:          paymentMethod.UserID,
+		PaymentMethodID: paymentMethod.PaymentMethodID,
+		From:            from,
+		To:              to,
+		SortBy:          ports.BillSortDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	return bills, nil
+}
+
+// sumBills totals the bills in the given currency, in PEN and in USD
+func (s *PaymentMethodService) sumBills(bills []*entities.Bill, currency string) (float64, float64, float64, error) {
+	var total, totalPEN, totalUSD float64
+	for _, bill := range bills {
+		amount, err := s.billAmountIn(bill, currency)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		total += amount
+		totalPEN += bill.AmountPen
+		totalUSD += bill.AmountUsd
+	}
+	return total, totalPEN, totalUSD, nil
+}
+
+// billAmountIn returns the bill's amount in a currency, using the amounts already converted when
+// one of them is in that currency
+func (s *PaymentMethodService) billAmountIn(bill *entities.Bill, currency string) (float64, error) {
+	switch currency {
+	case "PEN":
+		return bill.AmountPen, nil
+	case "USD":
+		return bill.AmountUsd, nil
+	case bill.Currency:
+		return bill.AmountOriginal, nil
+	case bill.ReportingCurrency:
+		return bill.AmountReporting, nil
+	}
+
+	rate, err := s.exchangeRateProvider.GetRate(bill.Currency, currency, bill.Date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve %s/%s rate: %w", bill.Currency, currency, err)
+	}
+	return bill.AmountOriginal * rate, nil
+}
+
+// applyPaymentMethodDTO validates the DTO and sets its fields on the payment method
+func (s *PaymentMethodService) applyPaymentMethodDTO(paymentMethod *entities.PaymentMethod, dto dtos.PaymentMethodDTO) error {
+	if name := strings.TrimSpace(dto.Name); name != "" {
+		paymentMethod.Name = name
+	}
+	if paymentMethod.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPaymentMethod)
+	}
+
+	if dto.Type != "" {
+		paymentMethodType := strings.ToLower(strings.TrimSpace(dto.Type))
+		if !paymentMethodTypes[paymentMethodType] {
+			return fmt.Errorf("%w: type must be credit_card, debit_card, yape, plin, cash or bank_account", ErrInvalidPaymentMethod)
+		}
+		paymentMethod.Type = paymentMethodType
+	}
+	if paymentMethod.Type == "" {
+		return fmt.Errorf("%w: type is required", ErrInvalidPaymentMethod)
+	}
+
+	if dto.Currency != "" {
+		currency := normalizeCurrency(dto.Currency)
+		if !isValidCurrency(currency) {
+			return ErrUnsupportedCurrency
+		}
+		paymentMethod.Currency = currency
+	}
+
+	if dto.LastFour != nil {
+		lastFour := strings.TrimSpace(*dto.LastFour)
+		if lastFour != "" && !isLastFour(lastFour) {
+			return fmt.Errorf("%w: lastFour must be the last 4 digits of the card", ErrInvalidPaymentMethod)
+		}
+		paymentMethod.LastFour = lastFour
+	}
+	if dto.CreditLimit != nil {
+		if *dto.CreditLimit < 0 {
+			return fmt.Errorf("%w: creditLimit can't be negative", ErrInvalidPaymentMethod)
+		}
+		paymentMethod.CreditLimit = *dto.CreditLimit
+	}
+	if dto.StatementClosingDay != nil {
+		if *dto.StatementClosingDay < 0 || *dto.StatementClosingDay > 31 {
+			return fmt.Errorf("%w: statementClosingDay must be between 1 and 31", ErrInvalidPaymentMethod)
+		}
+		paymentMethod.StatementClosingDay = *dto.StatementClosingDay
+	}
+
+	// Only credit cards have a limit and billing cycles
+	if paymentMethod.Type != entities.PaymentMethodCreditCard {
+		paymentMethod.CreditLimit = 0
+		paymentMethod.StatementClosingDay = 0
+	}
+	return nil
+}
+
+func (s *PaymentMethodService) getOwnedPaymentMethod(paymentMethodID string, userID string) (*entities.PaymentMethod, error) {
+	paymentMethod, err := s.paymentMethodRepo.FindByID(paymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentMethod == nil {
+		return nil, ErrPaymentMethodNotFound
+	}
+	if paymentMethod.UserID != userID {
+		return nil, ErrUnauthorizedPaymentMethod
+	}
+	return paymentMethod, nil
+}
+
+// hasStatements reports whether the payment method is a credit card with known billing cycles
+func hasStatements(paymentMethod *entities.PaymentMethod) bool {
+	return paymentMethod.Type == entities.PaymentMethodCreditCard && paymentMethod.StatementClosingDay > 0
+}
+
+func isLastFour(value string) bool {
+	if len(value) != 4 {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// closingDateIn returns the closing date of the billing cycle that ends in the given month.
+// A closing day past the end of the month closes on its last day
+func closingDateIn(closingDay int, year int, month time.Month, loc *time.Location) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if closingDay > lastDay {
+		closingDay = lastDay
+	}
+	return time.Date(year, month, closingDay, 0, 0, 0, 0, loc)
+}
+
+// currentClosingDate returns the closing date of the open billing cycle, the first one on or after today
+func currentClosingDate(closingDay int, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	closingDate := closingDateIn(closingDay, today.Year(), today.Month(), today.Location())
+	if today.After(closingDate) {
+		closingDate = closingDateIn(closingDay, today.Year(), today.Month()+1, today.Location())
+	}
+	return closingDate
+}
+
+// previousClosingDate returns the closing date of the billing cycle before the one closing on closingDate
+func previousClosingDate(closingDay int, closingDate time.Time) time.Time {
+	return closingDateIn(closingDay, closingDate.Year(), closingDate.Month()-1, closingDate.Location())
+}
+
+var (
+	ErrInvalidPaymentMethod      = errors.New("invalid payment method")
+	ErrPaymentMethodNotFound     = errors.New("payment method not found")
+	ErrUnauthorizedPaymentMethod = errors.New("unauthorized access to payment method")
+	ErrNoStatements              = errors.New("payment method is not a credit card with a statement closing day")
+)
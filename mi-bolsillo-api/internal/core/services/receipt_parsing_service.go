@@ -0,0 +1,96 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/domain/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+// ReceiptParsingService reads a bill out of whatever the user uploaded: receipt photos and PDFs go
+// through the LLM receipt parser, SUNAT electronic invoices (UBL XML) are parsed deterministically.
+// Items are filed under the user's categories
+type ReceiptParsingService struct {
+	receiptParser   ports.ReceiptParser
+	invoiceParser   ports.InvoiceParser
+	pdfReader       ports.PDFReader
+	categoryService *CategoryService
+}
+
+func NewReceiptParsingService(receiptParser ports.ReceiptParser, invoiceParser ports.InvoiceParser, pdfReader ports.PDFReader, categoryService *CategoryService) *ReceiptParsingService {
+	return &ReceiptParsingService{
+		receiptParser:   receiptParser,
+		invoiceParser:   invoiceParser,
+		pdfReader:       pdfReader,
+		categoryService: categoryService,
+	}
+}
+
+// ParseReceipt detects the kind of document from its content and extracts the bill in it,
+// with each item's category resolved to one of the user's categories
+func (s *ReceiptParsingService) ParseReceipt(userID string, data []byte) (*entities.ParsedBill, error) {
+	categories, err := s.categoryService.ListCategories(userID)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := s.parseDocument(data, categoryLabels(categories))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range parsed.Items {
+		item := &parsed.Items[i]
+		item.CategoryID, item.Category = categoryFields(categories, item.Category)
+	}
+	return parsed, nil
+}
+
+// parseDocument routes the document to the parser of its kind
+func (s *ReceiptParsingService) parseDocument(data []byte, categories []string) (*entities.ParsedBill, error) {
+	content := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+
+	switch {
+	case bytes.HasPrefix(content, []byte("%PDF")):
+		return s.parsePDF(data, categories)
+	case bytes.HasPrefix(content, []byte("<")):
+		parsed, err := s.invoiceParser.ParseInvoice(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInvoice, err)
+		}
+		return parsed, nil
+	case strings.HasPrefix(http.DetectContentType(data), "image/"):
+		return s.receiptParser.ParseBillImage(data, categories)
+	default:
+		return nil, ErrUnsupportedReceiptFormat
+	}
+}
+
+// parsePDF reads the text of generated PDFs, and falls back to the page image for scans
+func (s *ReceiptParsingService) parsePDF(data []byte, categories []string) (*entities.ParsedBill, error) {
+	text, err := s.pdfReader.ExtractText(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadablePDF, err)
+	}
+	if text != "" {
+		return s.receiptParser.ParseBillText(text, categories)
+	}
+
+	images, err := s.pdfReader.ExtractImages(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadablePDF, err)
+	}
+	if len(images) == 0 {
+		return nil, ErrUnreadablePDF
+	}
+	return s.receiptParser.ParseBillImage(images[0], categories)
+}
+
+var (
+	ErrUnsupportedReceiptFormat = errors.New("unsupported receipt format, send a photo, a PDF or a SUNAT XML invoice")
+	ErrInvalidInvoice           = errors.New("invalid electronic invoice")
+	ErrUnreadablePDF            = errors.New("could not read the PDF")
+)
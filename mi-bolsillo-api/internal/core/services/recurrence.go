@@ -0,0 +1,80 @@
+package services
+
+import (
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+)
+
+const dateLayout = "2006-01-02"
+
+// nextOccurrenceOnOrAfter returns the first date of the recurring bill's schedule that falls on
+// or after from. Schedules behave like a small subset of RRULE:
+//   - monthly: every Interval months on DayOfMonth (clamped to the month's last day)
+//   - weekly: every Interval weeks on DayOfWeek (0 = Sunday)
+//   - yearly: every Interval years on MonthOfYear/DayOfMonth
+//
+// Dates are calendar days in UTC so that arithmetic isn't affected by DST
+func nextOccurrenceOnOrAfter(rb *entities.RecurringBill, from time.Time) (time.Time, error) {
+	start, err := time.Parse(dateLayout, rb.StartDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	if from.Before(start) {
+		from = start
+	}
+
+	interval := rb.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch rb.Frequency {
+	case entities.FrequencyWeekly:
+		offset := (rb.DayOfWeek - int(start.Weekday()) + 7) % 7
+		first := start.AddDate(0, 0, offset)
+		if !first.Before(from) {
+			return first, nil
+		}
+		step := 7 * interval
+		days := int(from.Sub(first).Hours() / 24)
+		periods := (days + step - 1) / step
+		return first.AddDate(0, 0, periods*step), nil
+
+	case entities.FrequencyYearly:
+		year := start.Year()
+		if from.Year() > year {
+			year += ((from.Year() - year) / interval) * interval
+		}
+		for {
+			candidate := dateClampedToMonth(year, time.Month(rb.MonthOfYear), rb.DayOfMonth)
+			if !candidate.Before(from) {
+				return candidate, nil
+			}
+			year += interval
+		}
+
+	default: // monthly
+		startIndex := start.Year()*12 + int(start.Month()) - 1
+		fromIndex := from.Year()*12 + int(from.Month()) - 1
+		index := startIndex + ((fromIndex-startIndex)/interval)*interval
+		for {
+			candidate := dateClampedToMonth(index/12, time.Month(index%12+1), rb.DayOfMonth)
+			if !candidate.Before(from) {
+				return candidate, nil
+			}
+			index += interval
+		}
+	}
+}
+
+// dateClampedToMonth builds a date, using the month's last day when day is past it (e.g. Feb 31 -> Feb 28)
+func dateClampedToMonth(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+type RecurringBillService struct {
+	recurringBillRepo       ports.RecurringBillRepository
+	billWithExpensesService *BillWithExpensesService
+}
+
+func NewRecurringBillService(recurringBillRepo ports.RecurringBillRepository, billWithExpensesService *BillWithExpensesService) *RecurringBillService {
+	return &RecurringBillService{
+		recurringBillRepo:       recurringBillRepo,
+		billWithExpensesService: billWithExpensesService,
+	}
+}
+
+// CreateRecurringBill validates the schedule and stores a new recurring bill for the user
+func (s *RecurringBillService) CreateRecurringBill(userID string, dto dtos.RecurringBillDTO) (*entities.RecurringBill, error) {
+	now := time.Now()
+	recurringBill := &entities.RecurringBill{
+		RecurringBillID: uuid.New().String(),
+		UserID:          userID,
+		Active:          true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.applyDTO(recurringBill, dto, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.recurringBillRepo.Create(recurringBill); err != nil {
+		return nil, fmt.Errorf("failed to create recurring bill: %w", err)
+	}
+
+	return recurringBill, nil
+}
+
+// UpdateRecurringBill replaces the schedule and amounts of a recurring bill owned by the user.
+// Occurrences already materialized are kept
+func (s *RecurringBillService) UpdateRecurringBill(recurringBillID string, userID string, dto dtos.RecurringBillDTO) (*entities.RecurringBill, error) {
+	recurringBill, err := s.GetRecurringBill(recurringBillID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.applyDTO(recurringBill, dto, now); err != nil {
+		return nil, err
+	}
+	recurringBill.UpdatedAt = now
+
+	if err := s.recurringBillRepo.Update(recurringBill); err != nil {
+		return nil, fmt.Errorf("failed to update recurring bill: %w", err)
+	}
+
+	return recurringBill, nil
+}
+
+// GetRecurringBill returns a recurring bill owned by the user
+func (s *RecurringBillService) GetRecurringBill(recurringBillID string, userID string) (*entities.RecurringBill, error) {
+	recurringBill, err := s.recurringBillRepo.FindByID(recurringBillID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring bill: %w", err)
+	}
+	if recurringBill == nil {
+		return nil, ErrRecurringBillNotFound
+	}
+	if recurringBill.UserID != userID {
+		return nil, ErrUnauthorizedRecurringBill
+	}
+	return recurringBill, nil
+}
+
+// ListRecurringBills returns all recurring bills of the user ordered by next occurrence
+func (s *RecurringBillService) ListRecurringBills(userID string) ([]*entities.RecurringBill, error) {
+	return s.recurringBillRepo.FindByUserID(userID)
+}
+
+// DeleteRecurringBill stops and removes a recurring bill, bills already created are kept
+func (s *RecurringBillService) DeleteRecurringBill(recurringBillID string, userID string) error {
+	if _, err := s.GetRecurringBill(recurringBillID, userID); err != nil {
+		return err
+	}
+	return s.recurringBillRepo.Delete(recurringBillID)
+}
+
+// MaterializeDue creates the bills of every occurrence due on or before now, including occurrences
+// missed while the server was down. Each occurrence is claimed before its bill is created so that
+// restarts or concurrent runs never post the same occurrence twice. Returns the number of bills created
+func (s *RecurringBillService) MaterializeDue(now time.Time) (int, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	due, err := s.recurringBillRepo.FindDue(today.Format(dateLayout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find due recurring bills: %w", err)
+	}
+
+	created := 0
+	for _, recurringBill := range due {
+		count, err := s.materializeRecurringBill(recurringBill, today)
+		created += count
+		if err != nil {
+			log.Printf("Failed to materialize recurring bill %s: %v", recurringBill.RecurringBillID, err)
+		}
+	}
+
+	return created, nil
+}
+
+func (s *RecurringBillService) materializeRecurringBill(recurringBill *entities.RecurringBill, today time.Time) (int, error) {
+	created := 0
+
+	for recurringBill.Active {
+		occurrence, err := time.Parse(dateLayout, recurringBill.NextOccurrence)
+		if err != nil {
+			return created, fmt.Errorf("invalid next occurrence %q: %w", recurringBill.NextOccurrence, err)
+		}
+		if occurrence.After(today) {
+			break
+		}
+
+		if recurringBill.EndDate != nil && recurringBill.NextOccurrence > *recurringBill.EndDate {
+			recurringBill.Active = false
+			break
+		}
+
+		if err := s.materializeOccurrence(recurringBill, occurrence); err != nil {
+			return created, err
+		}
+		created++
+
+		next, err := nextOccurrenceOnOrAfter(recurringBill, occurrence.AddDate(0, 0, 1))
+		if err != nil {
+			return created, err
+		}
+		recurringBill.NextOccurrence = next.Format(dateLayout)
+	}
+
+	recurringBill.UpdatedAt = time.Now()
+	if err := s.recurringBillRepo.Update(recurringBill); err != nil {
+		return created, fmt.Errorf("failed to advance recurring bill: %w", err)
+	}
+
+	return created, nil
+}
+
+// materializeOccurrence creates the bill for a single occurrence unless it was already claimed.
+// The occurrence is claimed in the same unit of work as the bill insert, so a failed insert
+// leaves it unclaimed for the next run
+func (s *RecurringBillService) materializeOccurrence(recurringBill *entities.RecurringBill, occurrence time.Time) error {
+	day := occurrence.Format(dateLayout)
+
+	claim := func(repos ports.TxRepositories, bill *entities.Bill) error {
+		claimed, err := repos.RecurringBills.ClaimOccurrence(recurringBill.RecurringBillID, day, bill.BillId)
+		if err != nil {
+			return fmt.Errorf("failed to claim occurrence %s: %w", day, err)
+		}
+		if !claimed {
+			return errOccurrenceClaimed
+		}
+		return nil
+	}
+
+	bill, _, err := s.billWithExpensesService.createBillWithExpenses(dtos.CreateBillWithExpensesDTO{
+		Description: recurringBill.Description,
+		Category:    recurringBill.Category,
+		UserID:      recurringBill.UserID,
+		Source:      "recurring",
+		Date:        time.Date(occurrence.Year(), occurrence.Month(), occurrence.Day(), 0, 0, 0, 0, time.Local),
+		Currency:    recurringBill.Currency,
+		Expenses: []dtos.CreateExpenseForBill{
+			{
+				Amount:      recurringBill.Amount,
+				Description: recurringBill.Description,
+				Category:    recurringBill.Category,
+				Date:        day,
+			},
+		},
+	}, claim)
+	if errors.Is(err, errOccurrenceClaimed) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create bill for occurrence %s: %w", day, err)
+	}
+
+	log.Printf("Materialized recurring bill %s for %s as bill %s", recurringBill.RecurringBillID, day, bill.BillId)
+	return nil
+}
+
+// applyDTO validates the DTO, copies it into the recurring bill and recomputes the next occurrence
+func (s *RecurringBillService) applyDTO(recurringBill *entities.RecurringBill, dto dtos.RecurringBillDTO, now time.Time) error {
+	if strings.TrimSpace(dto.Description) == "" {
+		return fmt.Errorf("%w: description is required", ErrInvalidRecurringBill)
+	}
+	if dto.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidRecurringBill)
+	}
+
+	currency := normalizeCurrency(dto.Currency)
+	if !isValidCurrency(currency) {
+		return ErrUnsupportedCurrency
+	}
+
+	startDate := dto.StartDate
+	if startDate == "" {
+		startDate = now.Format(dateLayout)
+	}
+	start, err := time.Parse(dateLayout, startDate)
+	if err != nil {
+		return fmt.Errorf("%w: startDate must be YYYY-MM-DD", ErrInvalidRecurringBill)
+	}
+
+	if dto.EndDate != nil {
+		end, err := time.Parse(dateLayout, *dto.EndDate)
+		if err != nil {
+			return fmt.Errorf("%w: endDate must be YYYY-MM-DD", ErrInvalidRecurringBill)
+		}
+		if end.Before(start) {
+			return fmt.Errorf("%w: endDate must not be before startDate", ErrInvalidRecurringBill)
+		}
+	}
+
+	interval := dto.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 0 {
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidRecurringBill)
+	}
+
+	// Schedule fields default to the start date
+	dayOfMonth := dto.DayOfMonth
+	if dayOfMonth == 0 {
+		dayOfMonth = start.Day()
+	}
+	monthOfYear := dto.MonthOfYear
+	if monthOfYear == 0 {
+		monthOfYear = int(start.Month())
+	}
+	dayOfWeek := int(start.Weekday())
+	if dto.DayOfWeek != nil {
+		dayOfWeek = *dto.DayOfWeek
+	}
+
+	switch dto.Frequency {
+	case entities.FrequencyMonthly, entities.FrequencyYearly:
+		if dayOfMonth < 1 || dayOfMonth > 31 {
+			return fmt.Errorf("%w: dayOfMonth must be between 1 and 31", ErrInvalidRecurringBill)
+		}
+		if monthOfYear < 1 || monthOfYear > 12 {
+			return fmt.Errorf("%w: monthOfYear must be between 1 and 12", ErrInvalidRecurringBill)
+		}
+	case entities.FrequencyWeekly:
+		if dayOfWeek < 0 || dayOfWeek > 6 {
+			return fmt.Errorf("%w: dayOfWeek must be between 0 (Sunday) and 6 (Saturday)", ErrInvalidRecurringBill)
+		}
+	default:
+		return fmt.Errorf("%w: frequency must be weekly, monthly or yearly", ErrInvalidRecurringBill)
+	}
+
+	recurringBill.Description = strings.TrimSpace(dto.Description)
+	recurringBill.Category = dto.Category
+	recurringBill.Amount = dto.Amount
+	recurringBill.Currency = currency
+	recurringBill.Frequency = dto.Frequency
+	recurringBill.Interval = interval
+	recurringBill.DayOfMonth = dayOfMonth
+	recurringBill.DayOfWeek = dayOfWeek
+	recurringBill.MonthOfYear = monthOfYear
+	recurringBill.StartDate = startDate
+	recurringBill.EndDate = dto.EndDate
+	if dto.Active != nil {
+		recurringBill.Active = *dto.Active
+	}
+
+	// Never schedule into the past, occurrences before today are not back-filled on create/update
+	from := now
+	if start.After(from) {
+		from = start
+	}
+	next, err := nextOccurrenceOnOrAfter(recurringBill, from)
+	if err != nil {
+		return err
+	}
+	recurringBill.NextOccurrence = next.Format(dateLayout)
+
+	return nil
+}
+
+var (
+	ErrRecurringBillNotFound     = errors.New("recurring bill not found")
+	ErrUnauthorizedRecurringBill = errors.New("unauthorized access to recurring bill")
+	ErrInvalidRecurringBill      = errors.New("invalid recurring bill")
+
+	// errOccurrenceClaimed rolls back the bill of an occurrence another run already materialized
+	errOccurrenceClaimed = errors.New("occurrence already claimed")
+)
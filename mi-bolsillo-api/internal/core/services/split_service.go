@@ -0,0 +1,490 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+const maxParticipantNameLength = 40
+
+// balanceEpsilon ignores the rounding leftovers of splitting amounts in cents
+const balanceEpsilon = 0.005
+
+type SplitService struct {
+	splitRepo     ports.SplitRepository
+	expenseRepo   ports.ExpenseRepository
+	billService   *BillWithExpensesService
+	walletService *WalletService
+	unitOfWork    ports.UnitOfWork
+}
+
+func NewSplitService(
+	splitRepo ports.SplitRepository,
+	expenseRepo ports.ExpenseRepository,
+	billService *BillWithExpensesService,
+	walletService *WalletService,
+	unitOfWork ports.UnitOfWork,
+) *SplitService {
+	return &SplitService{
+		splitRepo:     splitRepo,
+		expenseRepo:   expenseRepo,
+		billService:   billService,
+		walletService: walletService,
+		unitOfWork:    unitOfWork,
+	}
+}
+
+// SplitBill divides a bill between participants, replacing its previous split
+func (s *SplitService) SplitBill(billID string, userID string, dto dtos.SplitBillDTO) (*entities.BillSplit, error) {
+	bill, err := s.billService.getAccessibleBill(billID, userID, entities.WalletRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	paidBy := dto.PaidBy
+	if strings.TrimSpace(paidBy) == "" && bill.WalletID == "" {
+		paidBy = entities.SplitParticipantMe
+	}
+	paidBy, err = splitParticipant(paidBy, bill.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.expenseRepo.FindByBillID(billID)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, items, err := computeShares(bill, expenses, dto.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.splitRepo.FindByBillID(billID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	split := &entities.BillSplit{
+		BillID:    bill.BillId,
+		UserID:    bill.UserID,
+		WalletID:  bill.WalletID,
+		PaidBy:    paidBy,
+		Amount:    bill.AmountOriginal,
+		Currency:  bill.Currency,
+		Shares:    shares,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if existing != nil {
+		split.CreatedAt = existing.CreatedAt
+	}
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		return repos.Splits.Save(split, items)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save bill split: %w", err)
+	}
+	return split, nil
+}
+
+// GetBillSplit returns how a bill is split
+func (s *SplitService) GetBillSplit(billID string, userID string) (*entities.BillSplit, error) {
+	if _, err := s.billService.getAccessibleBill(billID, userID, entities.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	split, err := s.splitRepo.FindByBillID(billID)
+	if err != nil {
+		return nil, err
+	}
+	if split == nil {
+		return nil, ErrSplitNotFound
+	}
+	return split, nil
+}
+
+// DeleteBillSplit removes a bill's split, the bill is no longer owed by anyone
+func (s *SplitService) DeleteBillSplit(billID string, userID string) error {
+	if _, err := s.billService.getAccessibleBill(billID, userID, entities.WalletRoleEditor); err != nil {
+		return err
+	}
+
+	split, err := s.splitRepo.FindByBillID(billID)
+	if err != nil {
+		return err
+	}
+	if split == nil {
+		return ErrSplitNotFound
+	}
+
+	return s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		return repos.Splits.DeleteByBillID(billID)
+	})
+}
+
+// GetBalances returns who owes whom in each currency of a ledger, a shared wallet's when walletID
+// is set and the user's personal one otherwise, with the fewest payments that settle it
+func (s *SplitService) GetBalances(userID string, walletID string) ([]dtos.LedgerBalances, error) {
+	if err := s.requireLedger(userID, walletID, entities.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	splits, err := s.splitRepo.FindByLedger(userID, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bill splits: %w", err)
+	}
+	settlements, err := s.splitRepo.FindSettlementsByLedger(userID, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch settlements: %w", err)
+	}
+
+	books := make(map[string]*ledgerBook)
+	book := func(currency string) *ledgerBook {
+		if books[currency] == nil {
+			books[currency] = newLedgerBook()
+		}
+		return books[currency]
+	}
+
+	// The payer is owed each share but their own, and a settlement moves a debt from its payer to its receiver
+	for _, split := range splits {
+		for _, share := range split.Shares {
+			if strings.EqualFold(share.Participant, split.PaidBy) {
+				continue
+			}
+			book(split.Currency).add(split.PaidBy, share.Amount)
+			book(split.Currency).add(share.Participant, -share.Amount)
+		}
+	}
+	for _, settlement := range settlements {
+		book(settlement.Currency).add(settlement.FromParticipant, settlement.Amount)
+		book(settlement.Currency).add(settlement.ToParticipant, -settlement.Amount)
+	}
+
+	currencies := make([]string, 0, len(books))
+	for currency := range books {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	ledgers := make([]dtos.LedgerBalances, 0, len(currencies))
+	for _, currency := range currencies {
+		balances := books[currency].balances()
+		if len(balances) == 0 {
+			continue
+		}
+		ledgers = append(ledgers, dtos.LedgerBalances{
+			Currency:  currency,
+			Balances:  balances,
+			Transfers: settleBalances(balances),
+		})
+	}
+	return ledgers, nil
+}
+
+// RecordSettlement records a payment between participants that pays back what one owes the other.
+// The currency defaults to the user's reporting currency
+func (s *SplitService) RecordSettlement(user *entities.User, dto dtos.CreateSettlementDTO) (*entities.Settlement, error) {
+	if err := s.requireLedger(user.UserID, dto.WalletID, entities.WalletRoleEditor); err != nil {
+		return nil, err
+	}
+
+	from, err := splitParticipant(dto.From, dto.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSettlement, err)
+	}
+	to, err := splitParticipant(dto.To, dto.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSettlement, err)
+	}
+	if strings.EqualFold(from, to) || dto.Amount <= 0 {
+		return nil, ErrInvalidSettlement
+	}
+
+	currency := dto.Currency
+	if currency == "" {
+		currency = user.ReportingCurrency
+	}
+	currency = normalizeCurrency(currency)
+	if !isValidCurrency(currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	now := time.Now()
+	date := dto.Date
+	if date.IsZero() {
+		date = now
+	}
+
+	settlement := &entities.Settlement{
+		SettlementID:    uuid.New().String(),
+		UserID:          user.UserID,
+		WalletID:        dto.WalletID,
+		FromParticipant: from,
+		ToParticipant:   to,
+		Amount:          roundAmount(dto.Amount),
+		Currency:        currency,
+		Note:            strings.TrimSpace(dto.Note),
+		Date:            date,
+		CreatedAt:       now,
+	}
+
+	if err := s.splitRepo.CreateSettlement(settlement); err != nil {
+		return nil, fmt.Errorf("failed to create settlement: %w", err)
+	}
+	return settlement, nil
+}
+
+// ListSettlements returns the settlements of a ledger, the latest first
+func (s *SplitService) ListSettlements(userID string, walletID string) ([]*entities.Settlement, error) {
+	if err := s.requireLedger(userID, walletID, entities.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.splitRepo.FindSettlementsByLedger(userID, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch settlements: %w", err)
+	}
+	return settlements, nil
+}
+
+// DeleteSettlement removes a settlement, the debt it paid is owed again
+func (s *SplitService) DeleteSettlement(settlementID string, userID string) error {
+	settlement, err := s.splitRepo.FindSettlementByID(settlementID)
+	if err != nil {
+		return err
+	}
+	if settlement == nil {
+		return ErrSettlementNotFound
+	}
+
+	if settlement.WalletID == "" && settlement.UserID != userID {
+		return ErrUnauthorizedSettlement
+	}
+	if err := s.requireLedger(userID, settlement.WalletID, entities.WalletRoleEditor); err != nil {
+		if errors.Is(err, ErrUnauthorizedWallet) || errors.Is(err, ErrWalletNotFound) {
+			return ErrUnauthorizedSettlement
+		}
+		return err
+	}
+
+	return s.splitRepo.DeleteSettlement(settlementID)
+}
+
+// requireLedger verifies the user has at least role in a shared wallet's ledger, everyone owns their personal one
+func (s *SplitService) requireLedger(userID string, walletID string, role string) error {
+	if walletID == "" {
+		return nil
+	}
+	_, _, err := s.walletService.requireRole(walletID, userID, role)
+	return err
+}
+
+// computeShares returns what each participant owes of a bill and the lines assigned to them. Lines are
+// split evenly between the participants listing them and the rest of the bill as SplitParticipantDTO describes
+func computeShares(bill *entities.Bill, expenses []*entities.Expense, participants []dtos.SplitParticipantDTO) ([]entities.BillSplitShare, []*entities.BillSplitItem, error) {
+	if len(participants) == 0 {
+		return nil, nil, fmt.Errorf("%w: at least one participant is required", ErrInvalidSplit)
+	}
+	if bill.AmountOriginal <= 0 {
+		return nil, nil, fmt.Errorf("%w: the bill has no amount to split", ErrInvalidSplit)
+	}
+
+	lineAmounts := make(map[string]float64, len(expenses))
+	for _, expense := range expenses {
+		lineAmounts[expense.ExpenseId] = expense.AmountOriginal
+	}
+
+	names := make([]string, len(participants))
+	lines := make([][]string, len(participants))
+	claims := make(map[string]int)
+	seen := make(map[string]bool, len(participants))
+	percentTotal := 0.0
+	for i, participant := range participants {
+		name, err := splitParticipant(participant.Name, bill.WalletID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, nil, fmt.Errorf("%w: %s is listed twice", ErrInvalidSplit, name)
+		}
+		seen[strings.ToLower(name)] = true
+		names[i] = name
+
+		if participant.Percentage < 0 || participant.Percentage > 100 {
+			return nil, nil, fmt.Errorf("%w: percentages must be between 0 and 100", ErrInvalidSplit)
+		}
+		percentTotal += participant.Percentage
+
+		listed := make(map[string]bool, len(participant.ExpenseIDs))
+		for _, expenseID := range participant.ExpenseIDs {
+			if _, ok := lineAmounts[expenseID]; !ok {
+				return nil, nil, fmt.Errorf("%w: expense %s is not a line of the bill", ErrInvalidSplit, expenseID)
+			}
+			if listed[expenseID] {
+				continue
+			}
+			listed[expenseID] = true
+			lines[i] = append(lines[i], expenseID)
+			claims[expenseID]++
+		}
+	}
+	if percentTotal > 0 && math.Abs(percentTotal-100) > 0.01 {
+		return nil, nil, fmt.Errorf("%w: percentages must add up to 100", ErrInvalidSplit)
+	}
+
+	var items []*entities.BillSplitItem
+	itemized := make([]float64, len(participants))
+	assigned := 0.0
+	for i, expenseIDs := range lines {
+		for _, expenseID := range expenseIDs {
+			amount := lineAmounts[expenseID] / float64(claims[expenseID])
+			itemized[i] += amount
+			assigned += amount
+			items = append(items, &entities.BillSplitItem{BillID: bill.BillId, ExpenseID: expenseID, Participant: names[i]})
+		}
+	}
+
+	// The rest of the bill is its tax, tip and the lines nobody listed
+	rest := bill.AmountOriginal - assigned
+	shares := make([]entities.BillSplitShare, len(participants))
+	allocated := 0.0
+	largest := 0
+	for i, participant := range participants {
+		var weight float64
+		switch {
+		case percentTotal > 0:
+			weight = participant.Percentage / 100
+		case assigned > balanceEpsilon:
+			weight = itemized[i] / assigned
+		default:
+			weight = 1 / float64(len(participants))
+		}
+
+		shares[i] = entities.BillSplitShare{
+			BillID:      bill.BillId,
+			Participant: names[i],
+			Amount:      roundAmount(itemized[i] + rest*weight),
+			Percentage:  participant.Percentage,
+			ExpenseIDs:  lines[i],
+		}
+		allocated += shares[i].Amount
+		if shares[i].Amount > shares[largest].Amount {
+			largest = i
+		}
+	}
+
+	// Rounding to cents can leave a cent or two over or short, the largest share absorbs it
+	shares[largest].Amount = roundAmount(shares[largest].Amount + bill.AmountOriginal - allocated)
+	return shares, items, nil
+}
+
+// splitParticipant normalizes a participant's name. "me" in any case stands for the owner of a personal
+// ledger, shared wallet ledgers have no single owner so their participants are always named
+func splitParticipant(name string, walletID string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || len(name) > maxParticipantNameLength {
+		return "", fmt.Errorf("%w: participant names are required and at most %d characters", ErrInvalidSplit, maxParticipantNameLength)
+	}
+	if strings.EqualFold(name, entities.SplitParticipantMe) {
+		if walletID != "" {
+			return "", fmt.Errorf("%w: name the participants of shared wallet bills", ErrInvalidSplit)
+		}
+		return entities.SplitParticipantMe, nil
+	}
+	return name, nil
+}
+
+// ledgerBook accumulates the net balance of each participant, matching names regardless of case
+type ledgerBook struct {
+	names map[string]string
+	net   map[string]float64
+}
+
+func newLedgerBook() *ledgerBook {
+	return &ledgerBook{names: make(map[string]string), net: make(map[string]float64)}
+}
+
+func (b *ledgerBook) add(participant string, amount float64) {
+	key := strings.ToLower(participant)
+	if _, ok := b.names[key]; !ok {
+		b.names[key] = participant
+	}
+	b.net[key] += amount
+}
+
+// balances returns the non-zero balances, creditors first
+func (b *ledgerBook) balances() []dtos.ParticipantBalance {
+	balances := make([]dtos.ParticipantBalance, 0, len(b.net))
+	for key, net := range b.net {
+		if math.Abs(net) < balanceEpsilon {
+			continue
+		}
+		balances = append(balances, dtos.ParticipantBalance{Participant: b.names[key], Balance: roundAmount(net)})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Balance != balances[j].Balance {
+			return balances[i].Balance > balances[j].Balance
+		}
+		return balances[i].Participant < balances[j].Participant
+	})
+	return balances
+}
+
+// settleBalances returns the payments that zero out balances sorted creditors first. The largest debtor
+// always pays the largest creditor, settling n participants in at most n-1 payments
+func settleBalances(balances []dtos.ParticipantBalance) []dtos.SettlementTransfer {
+	var creditors, debtors []dtos.ParticipantBalance
+	for _, balance := range balances {
+		if balance.Balance > 0 {
+			creditors = append(creditors, balance)
+		} else {
+			debtors = append(debtors, balance)
+		}
+	}
+	// Debtors come sorted from the smallest debt, pay the largest first
+	for i, j := 0, len(debtors)-1; i < j; i, j = i+1, j-1 {
+		debtors[i], debtors[j] = debtors[j], debtors[i]
+	}
+
+	transfers := []dtos.SettlementTransfer{}
+	for c, d := 0, 0; c < len(creditors) && d < len(debtors); {
+		amount := math.Min(creditors[c].Balance, -debtors[d].Balance)
+		if amount >= balanceEpsilon {
+			transfers = append(transfers, dtos.SettlementTransfer{
+				From:   debtors[d].Participant,
+				To:     creditors[c].Participant,
+				Amount: roundAmount(amount),
+			})
+		}
+		creditors[c].Balance -= amount
+		debtors[d].Balance += amount
+		if creditors[c].Balance < balanceEpsilon {
+			c++
+		}
+		if -debtors[d].Balance < balanceEpsilon {
+			d++
+		}
+	}
+	return transfers
+}
+
+var (
+	ErrInvalidSplit           = errors.New("invalid bill split")
+	ErrSplitNotFound          = errors.New("bill split not found")
+	ErrInvalidSettlement      = errors.New("invalid settlement")
+	ErrSettlementNotFound     = errors.New("settlement not found")
+	ErrUnauthorizedSettlement = errors.New("unauthorized access to settlement")
+)
@@ -10,12 +10,14 @@ import (
 )
 
 type StatisticsService struct {
-	billRepo ports.BillRepository
+	billRepo   ports.BillRepository
+	incomeRepo ports.IncomeRepository
 }
 
-func NewStatisticsService(billRepo ports.BillRepository) *StatisticsService {
+func NewStatisticsService(billRepo ports.BillRepository, incomeRepo ports.IncomeRepository) *StatisticsService {
 	return &StatisticsService{
-		billRepo: billRepo,
+		billRepo:   billRepo,
+		incomeRepo: incomeRepo,
 	}
 }
 
@@ -27,8 +29,14 @@ func (s *StatisticsService) GetDashboardStatistics(userID string, months int) (*
 		return nil, fmt.Errorf("failed to fetch bills: %w", err)
 	}
 
+	// Get all incomes for the user
+	incomes, err := s.incomeRepo.FindByUserID(userID, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch incomes: %w", err)
+	}
+
 	// Calculate statistics
-	monthlyStats := s.calculateMonthlyStatistics(bills, months)
+	monthlyStats := s.calculateMonthlyStatistics(bills, incomes, months)
 	weeklyStats := s.calculateWeeklyStatistics(bills, 8) // Last 8 weeks
 	categoryStats := s.calculateCategoryStatistics(bills)
 
@@ -39,18 +47,70 @@ func (s *StatisticsService) GetDashboardStatistics(userID string, months int) (*
 		totalUSD += bill.AmountUsd
 	}
 
+	var incomePEN, incomeUSD float64
+	for _, income := range incomes {
+		incomePEN += income.AmountPen
+		incomeUSD += income.AmountUsd
+	}
+
 	return &dtos.DashboardStatistics{
-		MonthlyStats:  monthlyStats,
-		WeeklyStats:   weeklyStats,
-		CategoryStats: categoryStats,
-		TotalPEN:      totalPEN,
-		TotalUSD:      totalUSD,
-		TotalBills:    len(bills),
+		MonthlyStats:   monthlyStats,
+		WeeklyStats:    weeklyStats,
+		CategoryStats:  categoryStats,
+		TotalPEN:       totalPEN,
+		TotalUSD:       totalUSD,
+		TotalBills:     len(bills),
+		TotalIncomePEN: incomePEN,
+		TotalIncomeUSD: incomeUSD,
+		NetSavingsPEN:  incomePEN - totalPEN,
+		NetSavingsUSD:  incomeUSD - totalUSD,
+		SavingsRate:    savingsRate(incomePEN, totalPEN),
 	}, nil
 }
 
-// calculateMonthlyStatistics calculates monthly spending statistics
-func (s *StatisticsService) calculateMonthlyStatistics(bills []*entities.Bill, months int) []dtos.MonthlyStatistics {
+// GetAverageMonthlySavings returns the user's average net savings per month over the last months
+// complete months, keyed by the currency the incomes and bills were recorded in. The current month
+// is left out as its spending isn't over yet
+func (s *StatisticsService) GetAverageMonthlySavings(userID string, months int) (map[string]float64, error) {
+	savings := make(map[string]float64)
+	if months <= 0 {
+		return savings, nil
+	}
+
+	bills, err := s.billRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	incomes, err := s.incomeRepo.FindByUserID(userID, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch incomes: %w", err)
+	}
+
+	now := time.Now()
+	completeMonths := make(map[string]bool, months)
+	for i := 1; i <= months; i++ {
+		completeMonths[now.AddDate(0, -i, 0).Format("2006-01")] = true
+	}
+
+	for _, income := range incomes {
+		if completeMonths[income.Date.Format("2006-01")] {
+			savings[income.Currency] += income.AmountOriginal
+		}
+	}
+	for _, bill := range bills {
+		if completeMonths[bill.Date.Format("2006-01")] {
+			savings[bill.Currency] -= bill.AmountOriginal
+		}
+	}
+
+	for currency := range savings {
+		savings[currency] /= float64(months)
+	}
+	return savings, nil
+}
+
+// calculateMonthlyStatistics calculates monthly spending, income and savings statistics
+func (s *StatisticsService) calculateMonthlyStatistics(bills []*entities.Bill, incomes []*entities.Income, months int) []dtos.MonthlyStatistics {
 	monthlyMap := make(map[string]*dtos.MonthlyStatistics)
 
 	now := time.Now()
@@ -78,12 +138,24 @@ func (s *StatisticsService) calculateMonthlyStatistics(bills []*entities.Bill, m
 		}
 	}
 
+	// Aggregate incomes by month
+	for _, income := range incomes {
+		monthKey := income.Date.Format("2006-01")
+		if stats, exists := monthlyMap[monthKey]; exists {
+			stats.IncomePEN += income.AmountPen
+			stats.IncomeUSD += income.AmountUsd
+		}
+	}
+
 	// Convert map to slice and sort by date (newest first)
 	result := make([]dtos.MonthlyStatistics, 0, len(monthlyMap))
 	for i := 0; i < months; i++ {
 		targetDate := now.AddDate(0, -i, 0)
 		monthKey := targetDate.Format("2006-01")
 		if stats, exists := monthlyMap[monthKey]; exists {
+			stats.NetSavingsPEN = stats.IncomePEN - stats.TotalPEN
+			stats.NetSavingsUSD = stats.IncomeUSD - stats.TotalUSD
+			stats.SavingsRate = savingsRate(stats.IncomePEN, stats.TotalPEN)
 			result = append(result, *stats)
 		}
 	}
@@ -186,6 +258,15 @@ func (s *StatisticsService) calculateCategoryStatistics(bills []*entities.Bill)
 	return result
 }
 
+// savingsRate returns the percentage of income left after spending, negative when spending exceeds it
+// and zero without income
+func savingsRate(income float64, spent float64) float64 {
+	if income <= 0 {
+		return 0
+	}
+	return (income - spent) / income * 100
+}
+
 // getWeekStart returns the Monday of the week for a given date
 func getWeekStart(date time.Time) time.Time {
 	weekday := date.Weekday()
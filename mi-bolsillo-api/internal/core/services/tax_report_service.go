@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+)
+
+// deductibleCapUIT is the yearly limit of the additional deduction, in UIT
+const deductibleCapUIT = 3
+
+// deductibleRule is one of the expenses individuals can deduct a share of from their work income
+// (Art. 46 of the Income Tax Law), matched against expense categories
+type deductibleRule struct {
+	Type        string
+	Description string
+	Categories  []string
+	Rate        float64
+}
+
+var deductibleRules = []deductibleRule{
+	{Type: "restaurants_hotels", Description: "Restaurantes, bares y hoteles", Categories: []string{"Restaurants", "Hotels"}, Rate: 0.15},
+	{Type: "rent", Description: "Arrendamiento de inmuebles", Categories: []string{"Rent"}, Rate: 0.30},
+}
+
+// uitByYear is the SUNAT tax unit (UIT) in PEN, set yearly by supreme decree
+var uitByYear = map[int]float64{
+	2022: 4600,
+	2023: 4950,
+	2024: 5150,
+	2025: 5350,
+	2026: 5500,
+}
+
+type TaxReportService struct {
+	billRepo    ports.BillRepository
+	expenseRepo ports.ExpenseRepository
+}
+
+func NewTaxReportService(billRepo ports.BillRepository, expenseRepo ports.ExpenseRepository) *TaxReportService {
+	return &TaxReportService{
+		billRepo:    billRepo,
+		expenseRepo: expenseRepo,
+	}
+}
+
+// GetDeductibleReport adds up the user's deductible spending in a year. Only bills with the
+// issuer's RUC count, the rest are reported apart so they can be completed
+func (s *TaxReportService) GetDeductibleReport(userID string, year int) (*dtos.DeductibleReport, error) {
+	if year < 2000 || year > 9999 {
+		return nil, ErrInvalidReportYear
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	bills, err := s.billRepo.Search(ports.BillSearchCriteria{UserID: userID, From: &from, To: &to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+
+	billIDs := make([]string, len(bills))
+	for i, bill := range bills {
+		billIDs[i] = bill.BillId
+	}
+	expenses, err := s.expenseRepo.FindByBillIDs(billIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch expenses: %w", err)
+	}
+
+	// PEN spent per bill and rule
+	ruleByCategory := make(map[string]int)
+	for i, rule := range deductibleRules {
+		for _, category := range rule.Categories {
+			ruleByCategory[category] = i
+		}
+	}
+	spentByBill := make(map[string]map[int]float64)
+	for _, expense := range expenses {
+		index, ok := ruleByCategory[expense.Category]
+		if !ok {
+			continue
+		}
+		if spentByBill[expense.BillID] == nil {
+			spentByBill[expense.BillID] = make(map[int]float64)
+		}
+		spentByBill[expense.BillID][index] += expense.AmountPen
+	}
+
+	categories := make([]dtos.DeductibleCategory, len(deductibleRules))
+	for i, rule := range deductibleRules {
+		categories[i] = dtos.DeductibleCategory{
+			Type:        rule.Type,
+			Description: rule.Description,
+			Categories:  rule.Categories,
+			Rate:        rule.Rate,
+		}
+	}
+
+	for _, bill := range bills {
+		for index, spent := range spentByBill[bill.BillId] {
+			category := &categories[index]
+			if bill.SupplierRUC == "" {
+				category.SpentWithoutRUC += spent
+				category.BillCountWithoutRUC++
+				continue
+			}
+
+			category.Spent += spent
+			category.BillCount++
+			// The tax breakdown is in the bill's currency, take the rule's share of it in PEN
+			if bill.AmountOriginal > 0 {
+				category.TaxAmount += bill.TaxAmount * spent / bill.AmountOriginal
+				category.TipAmount += bill.TipAmount * spent / bill.AmountOriginal
+			}
+		}
+	}
+
+	report := &dtos.DeductibleReport{
+		Year: year,
+		UIT:  uitForYear(year),
+	}
+	for i := range categories {
+		category := &categories[i]
+		category.Deductible = roundAmount(category.Spent * category.Rate)
+		category.Spent = roundAmount(category.Spent)
+		category.TaxAmount = roundAmount(category.TaxAmount)
+		category.TipAmount = roundAmount(category.TipAmount)
+		category.SpentWithoutRUC = roundAmount(category.SpentWithoutRUC)
+		report.TotalDeductible += category.Deductible
+	}
+	report.Categories = categories
+	report.TotalDeductible = roundAmount(report.TotalDeductible)
+	report.Cap = report.UIT * deductibleCapUIT
+	report.Deduction = math.Min(report.TotalDeductible, report.Cap)
+
+	return report, nil
+}
+
+// uitForYear returns the UIT of a year, or of the closest earlier year with a known value
+func uitForYear(year int) float64 {
+	closest, earliest := 0, 0
+	for known := range uitByYear {
+		if known <= year && known > closest {
+			closest = known
+		}
+		if earliest == 0 || known < earliest {
+			earliest = known
+		}
+	}
+	if closest == 0 {
+		closest = earliest
+	}
+	return uitByYear[closest]
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+var (
+	ErrInvalidReportYear = errors.New("invalid report year")
+)
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+)
+
+type UserPreferencesService struct {
+	userRepo             ports.UserRepository
+	billRepo             ports.BillRepository
+	expenseRepo          ports.ExpenseRepository
+	incomeRepo           ports.IncomeRepository
+	exchangeRateProvider ports.ExchangeRateProvider
+}
+
+func NewUserPreferencesService(
+	userRepo ports.UserRepository,
+	billRepo ports.BillRepository,
+	expenseRepo ports.ExpenseRepository,
+	incomeRepo ports.IncomeRepository,
+	exchangeRateProvider ports.ExchangeRateProvider,
+) *UserPreferencesService {
+	return &UserPreferencesService{
+		userRepo:             userRepo,
+		billRepo:             billRepo,
+		expenseRepo:          expenseRepo,
+		incomeRepo:           incomeRepo,
+		exchangeRateProvider: exchangeRateProvider,
+	}
+}
+
+// UpdateReportingCurrency changes the user's reporting currency and converts the reporting
+// amounts of their existing bills and incomes using the rate of each one's date
+func (s *UserPreferencesService) UpdateReportingCurrency(user *entities.User, currency string) error {
+	currency = normalizeCurrency(currency)
+	if !isValidCurrency(currency) {
+		return ErrUnsupportedCurrency
+	}
+
+	if user.ReportingCurrency == currency {
+		return nil
+	}
+
+	// Make sure the currency can be converted before touching any data
+	if _, err := s.exchangeRateProvider.GetRate("USD", currency, time.Now()); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedCurrency, err)
+	}
+
+	user.ReportingCurrency = currency
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	bills, err := s.billRepo.FindByUserID(user.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bills: %w", err)
+	}
+
+	for _, bill := range bills {
+		rate, err := s.exchangeRateProvider.GetRate(bill.Currency, currency, bill.Date)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s/%s rate: %w", bill.Currency, currency, err)
+		}
+
+		if err := s.billRepo.UpdateReportingAmount(bill.BillId, currency, bill.AmountOriginal*rate); err != nil {
+			return fmt.Errorf("failed to update bill reporting amount: %w", err)
+		}
+
+		expenses, err := s.expenseRepo.FindByBillID(bill.BillId)
+		if err != nil {
+			return fmt.Errorf("failed to fetch expenses: %w", err)
+		}
+		for _, expense := range expenses {
+			if err := s.expenseRepo.UpdateReportingAmount(expense.ExpenseId, currency, expense.AmountOriginal*rate); err != nil {
+				return fmt.Errorf("failed to update expense reporting amount: %w", err)
+			}
+		}
+	}
+
+	incomes, err := s.incomeRepo.FindByUserID(user.UserID, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch incomes: %w", err)
+	}
+
+	for _, income := range incomes {
+		rate, err := s.exchangeRateProvider.GetRate(income.Currency, currency, income.Date)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s/%s rate: %w", income.Currency, currency, err)
+		}
+
+		if err := s.incomeRepo.UpdateReportingAmount(income.IncomeID, currency, income.AmountOriginal*rate); err != nil {
+			return fmt.Errorf("failed to update income reporting amount: %w", err)
+		}
+	}
+
+	return nil
+}
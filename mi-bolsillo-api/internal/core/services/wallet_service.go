@@ -0,0 +1,356 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/entities"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/ports"
+	"github.com/KKogaa/mi-bolsillo-api/internal/core/services/dtos"
+	"github.com/google/uuid"
+)
+
+const maxWalletNameLength = 60
+
+// walletRoleRanks orders the wallet roles, a role grants everything the lower ones do
+var walletRoleRanks = map[string]int{
+	entities.WalletRoleViewer: 1,
+	entities.WalletRoleEditor: 2,
+	entities.WalletRoleOwner:  3,
+}
+
+type WalletService struct {
+	walletRepo       ports.WalletRepository
+	unitOfWork       ports.UnitOfWork
+	inviteExpiration time.Duration
+}
+
+func NewWalletService(walletRepo ports.WalletRepository, unitOfWork ports.UnitOfWork, inviteExpirationHours int) *WalletService {
+	return &WalletService{
+		walletRepo:       walletRepo,
+		unitOfWork:       unitOfWork,
+		inviteExpiration: time.Duration(inviteExpirationHours) * time.Hour,
+	}
+}
+
+// ListWallets returns the wallets the user is a member of
+func (s *WalletService) ListWallets(userID string) ([]*entities.Wallet, error) {
+	wallets, err := s.walletRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// CreateWallet creates a wallet owned by the user
+func (s *WalletService) CreateWallet(userID string, name string) (*entities.Wallet, error) {
+	name, err := validateWalletName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	wallet := &entities.Wallet{
+		WalletID:  uuid.New().String(),
+		Name:      name,
+		OwnerID:   userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		if err := repos.Wallets.Create(wallet); err != nil {
+			return err
+		}
+		return repos.Wallets.AddMember(&entities.WalletMember{
+			WalletID:  wallet.WalletID,
+			UserID:    userID,
+			Role:      entities.WalletRoleOwner,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet: %w", err)
+	}
+	return wallet, nil
+}
+
+// GetWallet returns a wallet the user is a member of, with its members
+func (s *WalletService) GetWallet(walletID string, userID string) (*dtos.WalletResponse, error) {
+	wallet, member, err := s.requireRole(walletID, userID, entities.WalletRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.walletRepo.FindMembers(walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wallet members: %w", err)
+	}
+
+	return &dtos.WalletResponse{
+		WalletID:       wallet.WalletID,
+		Name:           wallet.Name,
+		OwnerID:        wallet.OwnerID,
+		TelegramChatID: wallet.TelegramChatID,
+		Role:           member.Role,
+		Members:        members,
+		CreatedAt:      wallet.CreatedAt,
+		UpdatedAt:      wallet.UpdatedAt,
+	}, nil
+}
+
+// RenameWallet changes the name of a wallet, only its owner can
+func (s *WalletService) RenameWallet(walletID string, userID string, name string) (*entities.Wallet, error) {
+	wallet, _, err := s.requireRole(walletID, userID, entities.WalletRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet.Name, err = validateWalletName(name)
+	if err != nil {
+		return nil, err
+	}
+	wallet.UpdatedAt = time.Now()
+
+	if err := s.walletRepo.Update(wallet); err != nil {
+		return nil, fmt.Errorf("failed to update wallet: %w", err)
+	}
+	return wallet, nil
+}
+
+// DeleteWallet deletes a wallet, only its owner can. Its bills go back to the members who created them
+func (s *WalletService) DeleteWallet(walletID string, userID string) error {
+	if _, _, err := s.requireRole(walletID, userID, entities.WalletRoleOwner); err != nil {
+		return err
+	}
+
+	err := s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		return repos.Wallets.Delete(walletID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete wallet: %w", err)
+	}
+	return nil
+}
+
+// CreateInvite creates an invite to join a wallet as an editor or a viewer, only the owner can invite
+func (s *WalletService) CreateInvite(walletID string, userID string, role string) (*entities.WalletInvite, error) {
+	if _, _, err := s.requireRole(walletID, userID, entities.WalletRoleOwner); err != nil {
+		return nil, err
+	}
+
+	role = strings.ToLower(strings.TrimSpace(role))
+	if role == "" {
+		role = entities.WalletRoleEditor
+	}
+	if role != entities.WalletRoleEditor && role != entities.WalletRoleViewer {
+		return nil, fmt.Errorf("%w: role must be editor or viewer", ErrInvalidWallet)
+	}
+
+	now := time.Now()
+	invite := &entities.WalletInvite{
+		// Short enough for a Telegram /start payload
+		Code:      strings.ReplaceAll(uuid.New().String(), "-", ""),
+		WalletID:  walletID,
+		Role:      role,
+		CreatedBy: userID,
+		ExpiresAt: now.Add(s.inviteExpiration),
+		CreatedAt: now,
+	}
+	if err := s.walletRepo.CreateInvite(invite); err != nil {
+		return nil, fmt.Errorf("failed to create wallet invite: %w", err)
+	}
+	return invite, nil
+}
+
+// JoinWallet adds the user to the wallet of an invite with the invite's role. Members keep their
+// current role
+func (s *WalletService) JoinWallet(code string, userID string) (*entities.Wallet, error) {
+	invite, err := s.walletRepo.FindInvite(strings.TrimSpace(code))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet invite: %w", err)
+	}
+	if invite == nil {
+		return nil, ErrWalletInviteNotFound
+	}
+	if time.Now().After(invite.ExpiresAt) {
+		return nil, ErrWalletInviteExpired
+	}
+
+	wallet, err := s.walletRepo.FindByID(invite.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletInviteNotFound
+	}
+
+	member, err := s.walletRepo.FindMember(wallet.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member != nil {
+		return wallet, nil
+	}
+
+	now := time.Now()
+	err = s.walletRepo.AddMember(&entities.WalletMember{
+		WalletID:  wallet.WalletID,
+		UserID:    userID,
+		Role:      invite.Role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add wallet member: %w", err)
+	}
+	return wallet, nil
+}
+
+// UpdateMemberRole makes a member an editor or a viewer, only the owner can
+func (s *WalletService) UpdateMemberRole(walletID string, userID string, memberUserID string, role string) (*entities.WalletMember, error) {
+	if _, _, err := s.requireRole(walletID, userID, entities.WalletRoleOwner); err != nil {
+		return nil, err
+	}
+
+	role = strings.ToLower(strings.TrimSpace(role))
+	if role != entities.WalletRoleEditor && role != entities.WalletRoleViewer {
+		return nil, fmt.Errorf("%w: role must be editor or viewer", ErrInvalidWallet)
+	}
+
+	member, err := s.walletRepo.FindMember(walletID, memberUserID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrWalletMemberNotFound
+	}
+	if member.Role == entities.WalletRoleOwner {
+		return nil, fmt.Errorf("%w: the owner's role can't be changed", ErrInvalidWallet)
+	}
+
+	member.Role = role
+	member.UpdatedAt = time.Now()
+	if err := s.walletRepo.UpdateMember(member); err != nil {
+		return nil, fmt.Errorf("failed to update wallet member: %w", err)
+	}
+	return member, nil
+}
+
+// RemoveMember removes a member from a wallet. The owner removes anyone else, other members can
+// only leave. The owner deletes the wallet instead of leaving it
+func (s *WalletService) RemoveMember(walletID string, userID string, memberUserID string) error {
+	role := entities.WalletRoleOwner
+	if memberUserID == userID {
+		role = entities.WalletRoleViewer
+	}
+	wallet, _, err := s.requireRole(walletID, userID, role)
+	if err != nil {
+		return err
+	}
+	if memberUserID == wallet.OwnerID {
+		return fmt.Errorf("%w: the owner can't leave the wallet, delete it instead", ErrInvalidWallet)
+	}
+
+	member, err := s.walletRepo.FindMember(walletID, memberUserID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrWalletMemberNotFound
+	}
+
+	if err := s.walletRepo.RemoveMember(walletID, memberUserID); err != nil {
+		return fmt.Errorf("failed to remove wallet member: %w", err)
+	}
+	return nil
+}
+
+// LinkTelegramChat makes a Telegram group post into a wallet the user can edit, replacing the
+// wallet the group posted into before
+func (s *WalletService) LinkTelegramChat(walletID string, userID string, chatID int64) (*entities.Wallet, error) {
+	wallet, _, err := s.requireRole(walletID, userID, entities.WalletRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.unitOfWork.Do(func(repos ports.TxRepositories) error {
+		linked, err := repos.Wallets.FindByTelegramChatID(chatID)
+		if err != nil {
+			return err
+		}
+		if linked != nil && linked.WalletID != wallet.WalletID {
+			linked.TelegramChatID = 0
+			linked.UpdatedAt = now
+			if err := repos.Wallets.Update(linked); err != nil {
+				return err
+			}
+		}
+
+		wallet.TelegramChatID = chatID
+		wallet.UpdatedAt = now
+		return repos.Wallets.Update(wallet)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link Telegram chat: %w", err)
+	}
+	return wallet, nil
+}
+
+// FindChatWallet returns the wallet a Telegram group posts into, or nil if the group has none.
+// The user must be able to add bills to it
+func (s *WalletService) FindChatWallet(chatID int64, userID string) (*entities.Wallet, error) {
+	wallet, err := s.walletRepo.FindByTelegramChatID(chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet of chat: %w", err)
+	}
+	if wallet == nil {
+		return nil, nil
+	}
+
+	if _, _, err := s.requireRole(wallet.WalletID, userID, entities.WalletRoleEditor); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// requireRole returns a wallet and the user's membership after verifying the user has at least role
+func (s *WalletService) requireRole(walletID string, userID string, role string) (*entities.Wallet, *entities.WalletMember, error) {
+	wallet, err := s.walletRepo.FindByID(walletID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if wallet == nil {
+		return nil, nil, ErrWalletNotFound
+	}
+
+	member, err := s.walletRepo.FindMember(walletID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member == nil || walletRoleRanks[member.Role] < walletRoleRanks[role] {
+		return nil, nil, ErrUnauthorizedWallet
+	}
+	return wallet, member, nil
+}
+
+func validateWalletName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxWalletNameLength {
+		return "", fmt.Errorf("%w: name is required and at most %d characters", ErrInvalidWallet, maxWalletNameLength)
+	}
+	return name, nil
+}
+
+var (
+	ErrWalletNotFound       = errors.New("wallet not found")
+	ErrUnauthorizedWallet   = errors.New("unauthorized access to wallet")
+	ErrInvalidWallet        = errors.New("invalid wallet")
+	ErrWalletMemberNotFound = errors.New("wallet member not found")
+	ErrWalletInviteNotFound = errors.New("wallet invite not found")
+	ErrWalletInviteExpired  = errors.New("wallet invite has expired")
+)